@@ -0,0 +1,45 @@
+package application
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/domain/arbitrage"
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+type ArbitrageScanner struct {
+	eventRepo     event.Repository
+	arbitrageRepo arbitrage.Repository
+	logger        logger
+}
+
+func NewArbitrageScanner(eventRepo event.Repository, arbitrageRepo arbitrage.Repository, logger logger) ArbitrageScanner {
+	return ArbitrageScanner{
+		eventRepo:     eventRepo,
+		arbitrageRepo: arbitrageRepo,
+		logger:        logger,
+	}
+}
+
+// Scan look for arbitrage opportunities over all active events and replace the previous scan result
+func (s ArbitrageScanner) Scan(ctx context.Context) error {
+	events, err := s.eventRepo.ListActiveEvents(ctx)
+	if err != nil {
+		s.logger.WithError(err).Errorf("List active events error")
+		return err
+	}
+
+	opportunities := make([]arbitrage.Opportunity, 0)
+	for _, e := range events {
+		opportunities = append(opportunities, arbitrage.Find(e)...)
+	}
+
+	err = s.arbitrageRepo.ReplaceOpportunities(ctx, opportunities)
+	if err != nil {
+		s.logger.WithError(err).Errorf("Store arbitrage opportunities error")
+		return err
+	}
+
+	s.logger.Infof("Found %d arbitrage opportunities over %d active events", len(opportunities), len(events))
+	return nil
+}
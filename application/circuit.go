@@ -0,0 +1,114 @@
+package application
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	// consecutive failed Cloudbet requests opening the circuit
+	circuitThreshold = 5
+	// time the circuit stays open before a trial request is let through
+	circuitCooldown = 30 * time.Second
+)
+
+var ErrCircuitOpen = errors.New("circuit to Cloudbet API is open after consecutive failures")
+
+// circuit to the Cloudbet API shared by every request to it
+var cloudbetCircuit = newCircuitBreaker(circuitThreshold, circuitCooldown)
+
+// circuitBreaker stop sending requests to an upstream failing consecutively, so crawls fail fast instead of waiting for
+// every request to time out. After the cooldown a single trial request decides whether the circuit closes again.
+type circuitBreaker struct {
+	lock      *sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	failures  int
+	// zero while closed
+	openedAt time.Time
+	// a trial request is in flight after the cooldown
+	trial bool
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		lock:      &sync.Mutex{},
+		threshold: threshold,
+		cooldown:  cooldown,
+	}
+}
+
+// allow report whether a request may be sent at now
+func (c *circuitBreaker) allow(now time.Time) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if c.openedAt.IsZero() {
+		return true
+	}
+	if c.trial || now.Sub(c.openedAt) < c.cooldown {
+		return false
+	}
+	c.trial = true
+	return true
+}
+
+// record the outcome of an allowed request
+func (c *circuitBreaker) record(success bool, now time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.trial = false
+	if success {
+		c.failures = 0
+		c.openedAt = time.Time{}
+		return
+	}
+	c.failures++
+	if c.failures >= c.threshold {
+		c.openedAt = now
+	}
+}
+
+// Open report whether the circuit is open at now, including after the cooldown until a trial request succeeds, and since when
+func (c *circuitBreaker) Open(now time.Time) (bool, time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return !c.openedAt.IsZero(), c.openedAt
+}
+
+// doCloudbet send req to the Cloudbet API through the circuit, counting, timing and tracing it under endpoint.
+// Transport errors, 429 and 5xx responses count as failures, other responses are answers of a healthy upstream.
+func doCloudbet(client *http.Client, req *http.Request, endpoint string) (*http.Response, error) {
+	ctx, span := tracer.Start(req.Context(), "cloudbet "+endpoint, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(endpointKey.String(endpoint)))
+	defer span.End()
+	span.SetAttributes(semconv.HTTPClientAttributesFromHTTPRequest(req)...)
+
+	if !cloudbetCircuit.allow(time.Now()) {
+		upstreamRequests.WithLabelValues(endpoint, "circuit_open").Inc()
+		failSpan(span, ErrCircuitOpen)
+		return nil, ErrCircuitOpen
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req.WithContext(ctx))
+	upstreamDuration.WithLabelValues(endpoint).Observe(time.Since(start).Seconds())
+	if err != nil {
+		cloudbetCircuit.record(false, time.Now())
+		upstreamRequests.WithLabelValues(endpoint, "error").Inc()
+		failSpan(span, err)
+		return nil, err
+	}
+	cloudbetCircuit.record(resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500, time.Now())
+	upstreamRequests.WithLabelValues(endpoint, strconv.Itoa(resp.StatusCode)).Inc()
+	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(resp.StatusCode)...)
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(resp.StatusCode, trace.SpanKindClient))
+	return resp, nil
+}
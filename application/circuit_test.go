@@ -0,0 +1,52 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	c := newCircuitBreaker(2, time.Minute)
+
+	tests := []struct {
+		name string
+		at   time.Duration
+		// outcome recorded when the request is allowed
+		success   bool
+		wantAllow bool
+		wantOpen  bool
+	}{
+		{name: "closed", at: 0, success: true, wantAllow: true},
+		{name: "one failure keeps it closed", at: time.Second, wantAllow: true},
+		{name: "threshold reached opens it", at: 2 * time.Second, wantAllow: true, wantOpen: true},
+		{name: "open during the cooldown", at: 30 * time.Second, wantOpen: true},
+		{name: "failed trial after the cooldown reopens it", at: 63 * time.Second, wantAllow: true, wantOpen: true},
+		{name: "cooldown restarted by the failed trial", at: 100 * time.Second, wantOpen: true},
+		{name: "successful trial closes it", at: 124 * time.Second, success: true, wantAllow: true},
+		{name: "closed again", at: 125 * time.Second, wantAllow: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start.Add(tt.at)
+			allowed := c.allow(now)
+			if allowed != tt.wantAllow {
+				t.Fatalf("allow() = %t, want %t", allowed, tt.wantAllow)
+			}
+			if allowed {
+				c.record(tt.success, now)
+			}
+			if open, _ := c.Open(now); open != tt.wantOpen {
+				t.Errorf("Open() = %t, want %t", open, tt.wantOpen)
+			}
+		})
+	}
+
+	// a single trial is let through until its outcome is recorded
+	c = newCircuitBreaker(1, time.Minute)
+	c.record(false, start)
+	if !c.allow(start.Add(time.Hour)) || c.allow(start.Add(time.Hour)) {
+		t.Errorf("allow() let through no trial or more than one")
+	}
+}
@@ -0,0 +1,115 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/awcjack/cloudbet/application/logging"
+	"github.com/awcjack/cloudbet/domain/apikey"
+	"github.com/sirupsen/logrus"
+)
+
+type CreateKeyHandler struct {
+	apiKeyRepo apikey.Repository
+	logger     logger
+}
+
+func NewCreateKeyHandler(apiKeyRepo apikey.Repository, logger logger) *CreateKeyHandler {
+	return &CreateKeyHandler{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+// Handle create a Key and return it with its secret, which is not stored and cannot be retrieved later
+func (h CreateKeyHandler) Handle(ctx context.Context, name string, scopes []string, rateLimit int, quota int) (apikey.Key, string, error) {
+	id, secret, err := apikey.NewSecret()
+	if err != nil {
+		return apikey.Key{}, "", err
+	}
+	key, err := apikey.NewKey(id, name, apikey.Hash(secret), scopes, rateLimit, quota, time.Now())
+	if err != nil {
+		return apikey.Key{}, "", err
+	}
+	if err := h.apiKeyRepo.SaveKey(ctx, key); err != nil {
+		return apikey.Key{}, "", err
+	}
+
+	logging.FromContext(ctx, h.logger).WithFields(logrus.Fields{"key_id": key.ID(), "key_name": key.Name(), "scopes": key.Scopes()}).Infof("Created api key")
+	return key, secret, nil
+}
+
+type RevokeKeyHandler struct {
+	apiKeyRepo apikey.Repository
+	logger     logger
+}
+
+func NewRevokeKeyHandler(apiKeyRepo apikey.Repository, logger logger) *RevokeKeyHandler {
+	return &RevokeKeyHandler{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+// Handle revoke the Key, revoking twice keep the first revocation time
+func (h RevokeKeyHandler) Handle(ctx context.Context, id string) (apikey.Key, error) {
+	key, err := h.apiKeyRepo.GetKey(ctx, id)
+	if err != nil {
+		return apikey.Key{}, err
+	}
+	if key.Revoked() {
+		return key, nil
+	}
+	key.Revoke(time.Now())
+	if err := h.apiKeyRepo.SaveKey(ctx, key); err != nil {
+		return apikey.Key{}, err
+	}
+
+	logging.FromContext(ctx, h.logger).WithFields(logrus.Fields{"key_id": key.ID(), "key_name": key.Name()}).Infof("Revoked api key")
+	return key, nil
+}
+
+type AuthenticateHandler struct {
+	apiKeyRepo apikey.Repository
+	logger     logger
+}
+
+func NewAuthenticateHandler(apiKeyRepo apikey.Repository, logger logger) *AuthenticateHandler {
+	return &AuthenticateHandler{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+// Handle resolve the secret to its Key, check it is granted scope and count the request against its limits.
+// Rejected requests are counted as well, so a client retrying without waiting stays limited.
+// The returned Limit is set whenever the Key is known, including when ErrRateLimited is returned.
+func (h AuthenticateHandler) Handle(ctx context.Context, secret string, scope string) (apikey.Key, apikey.Limit, error) {
+	if secret == "" {
+		return apikey.Key{}, apikey.Limit{}, apikey.ErrMissingKey
+	}
+	key, err := h.apiKeyRepo.GetKeyByHash(ctx, apikey.Hash(secret))
+	if errors.Is(err, apikey.ErrKeyNotFound) {
+		return apikey.Key{}, apikey.Limit{}, apikey.ErrInvalidKey
+	}
+	if err != nil {
+		return apikey.Key{}, apikey.Limit{}, err
+	}
+	if key.Revoked() {
+		return apikey.Key{}, apikey.Limit{}, apikey.ErrInvalidKey
+	}
+
+	usage, err := h.apiKeyRepo.RecordRequest(ctx, key.ID(), time.Now())
+	if err != nil {
+		return apikey.Key{}, apikey.Limit{}, err
+	}
+	limit := key.Check(usage)
+	if !key.HasScope(scope) {
+		return key, limit, apikey.ErrScopeNotGranted
+	}
+	if limit.Exceeded() {
+		return key, limit, apikey.ErrRateLimited
+	}
+	return key, limit, nil
+}
@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/awcjack/cloudbet/application/logging"
+	"github.com/awcjack/cloudbet/domain/crawl"
+	"github.com/sirupsen/logrus"
+)
+
+type crawlScheduler interface {
+	Trigger(competitionKey string) (crawl.Run, error)
+	SetPaused(paused bool)
+	SetIntervals(interval time.Duration, cutOffInterval time.Duration)
+}
+
+type TriggerCrawlHandler struct {
+	scheduler crawlScheduler
+	logger    logger
+}
+
+func NewTriggerCrawlHandler(scheduler crawlScheduler, logger logger) *TriggerCrawlHandler {
+	return &TriggerCrawlHandler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Handle request an immediate crawl of every competition, or of competitionKey only if not empty
+func (h TriggerCrawlHandler) Handle(ctx context.Context, competitionKey string) (crawl.Run, error) {
+	run, err := h.scheduler.Trigger(competitionKey)
+	if err != nil {
+		return crawl.Run{}, err
+	}
+
+	logging.FromContext(ctx, h.logger).WithFields(logrus.Fields{logging.CrawlRunIDField: run.ID(), "kind": run.Kind()}).Infof("Crawl requested")
+	return run, nil
+}
+
+type PauseCrawlHandler struct {
+	scheduler crawlScheduler
+	logger    logger
+}
+
+func NewPauseCrawlHandler(scheduler crawlScheduler, logger logger) *PauseCrawlHandler {
+	return &PauseCrawlHandler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Handle suspend scheduled crawls if paused, resume them otherwise
+func (h PauseCrawlHandler) Handle(_ context.Context, paused bool) {
+	h.scheduler.SetPaused(paused)
+}
+
+type SetCrawlIntervalsHandler struct {
+	scheduler crawlScheduler
+	logger    logger
+}
+
+func NewSetCrawlIntervalsHandler(scheduler crawlScheduler, logger logger) *SetCrawlIntervalsHandler {
+	return &SetCrawlIntervalsHandler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Handle change the crawl and cutoff check intervals, a nil interval is kept unchanged
+func (h SetCrawlIntervalsHandler) Handle(_ context.Context, interval *time.Duration, cutOffInterval *time.Duration) error {
+	var newInterval, newCutOffInterval time.Duration
+	if interval != nil {
+		if *interval < crawl.MinInterval || *interval > crawl.MaxInterval {
+			return crawl.ErrInvalidInterval
+		}
+		newInterval = *interval
+	}
+	if cutOffInterval != nil {
+		if *cutOffInterval < crawl.MinInterval || *cutOffInterval > crawl.MaxInterval {
+			return crawl.ErrInvalidInterval
+		}
+		newCutOffInterval = *cutOffInterval
+	}
+
+	h.scheduler.SetIntervals(newInterval, newCutOffInterval)
+	return nil
+}
@@ -0,0 +1,30 @@
+package command
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/application/logging"
+	"github.com/awcjack/cloudbet/domain/valuebet"
+)
+
+type UploadModelProbabilitiesHandler struct {
+	valuebetRepo valuebet.Repository
+	logger       logger
+}
+
+func NewUploadModelProbabilitiesHandler(valuebetRepo valuebet.Repository, logger logger) *UploadModelProbabilitiesHandler {
+	return &UploadModelProbabilitiesHandler{
+		valuebetRepo: valuebetRepo,
+		logger:       logger,
+	}
+}
+
+func (u UploadModelProbabilitiesHandler) Handle(ctx context.Context, probabilities []valuebet.Probability) error {
+	err := u.valuebetRepo.SaveProbabilities(ctx, probabilities)
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx, u.logger).Infof("Stored %d model probabilities", len(probabilities))
+	return nil
+}
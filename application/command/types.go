@@ -0,0 +1,8 @@
+package command
+
+import "github.com/sirupsen/logrus"
+
+// logger is the structured logger of the handlers, logging.FromContext add the fields of the request to it
+type logger interface {
+	logrus.FieldLogger
+}
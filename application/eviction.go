@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"time"
+
+	"github.com/awcjack/cloudbet/application/logging"
+	"github.com/awcjack/cloudbet/domain/change"
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+type EventEvictor struct {
+	eventRepo  event.Repository
+	changeRepo change.Repository
+	logger     logger
+}
+
+func NewEventEvictor(eventRepo event.Repository, changeRepo change.Repository, logger logger) EventEvictor {
+	return EventEvictor{
+		eventRepo:  eventRepo,
+		changeRepo: changeRepo,
+		logger:     logger,
+	}
+}
+
+// Evict delete events inactive for longer than retention, and drop their tombstones once they are older than retention as well
+// so change feed consumers have a full retention period to observe the deletion
+func (e EventEvictor) Evict(ctx context.Context, retention time.Duration) error {
+	before := time.Now().Add(-retention)
+	events, err := e.eventRepo.ListInactiveEvents(ctx, before)
+	if err != nil {
+		e.logger.WithError(err).Errorf("List inactive events error")
+		return err
+	}
+
+	for _, inactive := range events {
+		if err := e.eventRepo.DeleteEvent(ctx, inactive.Key()); err != nil {
+			e.logger.WithField(logging.EventField, inactive.Key()).WithError(err).Errorf("Delete inactive event error")
+		}
+	}
+
+	if len(events) > 0 {
+		e.logger.Infof("Evicted %d inactive events", len(events))
+	}
+	return e.PruneTombstones(ctx, retention)
+}
+
+// PruneTombstones drop deletion records older than retention from the change feed, replicas receive their deletions from
+// the peer and only prune
+func (e EventEvictor) PruneTombstones(ctx context.Context, retention time.Duration) error {
+	err := e.changeRepo.PruneTombstones(ctx, time.Now().Add(-retention))
+	if err != nil {
+		e.logger.WithError(err).Errorf("Prune tombstones error")
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/infrastructure"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestEvent(t *testing.T, key string, active bool, cutOffTime time.Time) event.Event {
+	t.Helper()
+	sport, _ := event.NewIdentifier("Soccer", "soccer")
+	category, _ := event.NewIdentifier("England", "england")
+	competition, _ := event.NewIdentifier("Premier League", "soccer-england-premier-league")
+	e, err := event.NewEvent(&sport, &competition, &category, event.TeamIdentifier{}, event.TeamIdentifier{}, active, false, map[string]event.Market{}, key, key, cutOffTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *e
+}
+
+// TestEvictDuringCutOffCheck run the eviction concurrently with the cutoff check like the crawl scheduler and the
+// eviction ticker do, run with -race
+func TestEvictDuringCutOffCheck(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	repo := infrastructure.NewMemoryRepository()
+	// far from cutoff, so the check never calls Cloudbet
+	cutOffTime := time.Now().Add(time.Hour)
+	for i := 0; i < 20; i++ {
+		if err := repo.Save(ctx, newTestEvent(t, fmt.Sprintf("active-%d", i), true, cutOffTime)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	evictor := NewEventEvictor(repo, repo, logger)
+	crawler := NewCloudbetHander(repo, logger, "test")
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			for j := 0; j < 5; j++ {
+				_ = repo.Save(ctx, newTestEvent(t, fmt.Sprintf("inactive-%d", j), false, cutOffTime))
+			}
+			_ = evictor.Evict(ctx, 0)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			crawler.CheckEventsCloseToCutOff(ctx)
+		}
+	}()
+	wg.Wait()
+
+	active, err := repo.ListActiveEvents(ctx)
+	if err != nil || len(active) != 20 {
+		t.Errorf("ListActiveEvents() = %d events, %v, want the 20 active events kept", len(active), err)
+	}
+}
@@ -9,11 +9,22 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/awcjack/cloudbet/application/command"
+	"github.com/awcjack/cloudbet/application/logging"
 	"github.com/awcjack/cloudbet/application/query"
+	"github.com/awcjack/cloudbet/domain/apikey"
+	"github.com/awcjack/cloudbet/domain/arbitrage"
 	"github.com/awcjack/cloudbet/domain/category"
+	"github.com/awcjack/cloudbet/domain/change"
 	"github.com/awcjack/cloudbet/domain/competition"
+	"github.com/awcjack/cloudbet/domain/crawl"
 	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/replication"
+	"github.com/awcjack/cloudbet/domain/search"
 	"github.com/awcjack/cloudbet/domain/sport"
+	"github.com/awcjack/cloudbet/domain/team"
+	"github.com/awcjack/cloudbet/domain/valuebet"
+	"github.com/sirupsen/logrus"
 )
 
 type Error struct {
@@ -47,6 +58,30 @@ type Sports struct {
 	Sports []Sport `json:"sports"`
 }
 
+// CloudbetError is a non 200 response of the Cloudbet API
+type CloudbetError struct {
+	StatusCode int
+	Message    string
+}
+
+func newCloudbetError(resp *http.Response, body []byte) CloudbetError {
+	var response Error
+	if err := json.Unmarshal(body, &response); err == nil && len(response.Error) > 0 {
+		return CloudbetError{
+			StatusCode: resp.StatusCode,
+			Message:    fmt.Sprintf("[%s] %s", response.Status, response.Error),
+		}
+	}
+	return CloudbetError{
+		StatusCode: resp.StatusCode,
+		Message:    resp.Status,
+	}
+}
+
+func (e CloudbetError) Error() string {
+	return e.Message
+}
+
 var (
 	ErrMissingToken          = errors.New("missing access token")
 	ErrMissingSportKey       = errors.New("missing Sport key")
@@ -54,10 +89,10 @@ var (
 	ErrNoSports              = errors.New("no sport found")
 )
 
-func fetchAllSports(apiKey string) (*Sports, error) {
+func fetchAllSports(ctx context.Context, apiKey string) (*Sports, error) {
 	client := &http.Client{}
 
-	req, err := http.NewRequest("GET", "https://sports-api-stg.cloudbet.com/pub/v2/odds/sports", nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://sports-api-stg.cloudbet.com/pub/v2/odds/sports", nil)
 	if err != nil {
 		return nil, err
 	}
@@ -65,7 +100,7 @@ func fetchAllSports(apiKey string) (*Sports, error) {
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("X-API-Key", apiKey)
 
-	resp, err := client.Do(req)
+	resp, err := doCloudbet(client, req, "sports")
 	if err != nil {
 		return nil, err
 	}
@@ -84,16 +119,7 @@ func fetchAllSports(apiKey string) (*Sports, error) {
 		}
 		return &response, nil
 	} else {
-		var response Error
-		err = json.Unmarshal(body, &response)
-		if err != nil {
-			return nil, err
-		}
-		if len(response.Error) > 0 {
-			return nil, fmt.Errorf("[%s] %s", response.Status, response.Error)
-		} else {
-			return nil, errors.New(resp.Status)
-		}
+		return nil, newCloudbetError(resp, body)
 	}
 }
 
@@ -138,13 +164,13 @@ type SportWithCategory struct {
 	Categories []Category `json:"categories"`
 }
 
-func fetchAllCompetitionsUnderSport(apiKey string, sportKey string) (*SportWithCategory, error) {
+func fetchAllCompetitionsUnderSport(ctx context.Context, apiKey string, sportKey string) (*SportWithCategory, error) {
 	if len(sportKey) == 0 {
 		return nil, ErrMissingSportKey
 	}
 	client := &http.Client{}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://sports-api-stg.cloudbet.com/pub/v2/odds/sports/%s", sportKey), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://sports-api-stg.cloudbet.com/pub/v2/odds/sports/%s", sportKey), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -152,7 +178,7 @@ func fetchAllCompetitionsUnderSport(apiKey string, sportKey string) (*SportWithC
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("X-API-Key", apiKey)
 
-	resp, err := client.Do(req)
+	resp, err := doCloudbet(client, req, "sport")
 	if err != nil {
 		return nil, err
 	}
@@ -171,16 +197,7 @@ func fetchAllCompetitionsUnderSport(apiKey string, sportKey string) (*SportWithC
 		}
 		return &response, nil
 	} else {
-		var response Error
-		err = json.Unmarshal(body, &response)
-		if err != nil {
-			return nil, err
-		}
-		if len(response.Error) > 0 {
-			return nil, fmt.Errorf("[%s] %s", response.Status, response.Error)
-		} else {
-			return nil, errors.New(resp.Status)
-		}
+		return nil, newCloudbetError(resp, body)
 	}
 }
 
@@ -304,13 +321,13 @@ type Competition struct {
 	Category Identifier `json:"category"`
 }
 
-func fetchAllEventsUnderCompetition(apiKey string, competitionKey string) (*Competition, error) {
+func fetchAllEventsUnderCompetition(ctx context.Context, apiKey string, competitionKey string) (*Competition, error) {
 	if len(competitionKey) == 0 {
 		return nil, ErrMissingCompetitionKey
 	}
 	client := &http.Client{}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://sports-api-stg.cloudbet.com/pub/v2/odds/competitions/%s", competitionKey), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://sports-api-stg.cloudbet.com/pub/v2/odds/competitions/%s", competitionKey), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -318,7 +335,7 @@ func fetchAllEventsUnderCompetition(apiKey string, competitionKey string) (*Comp
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("X-API-Key", apiKey)
 
-	resp, err := client.Do(req)
+	resp, err := doCloudbet(client, req, "competition")
 	if err != nil {
 		return nil, err
 	}
@@ -333,30 +350,17 @@ func fetchAllEventsUnderCompetition(apiKey string, competitionKey string) (*Comp
 		var response Competition
 		err = json.Unmarshal(body, &response)
 		if err != nil {
-			fmt.Println(string(body))
-			return nil, err
+			return nil, fmt.Errorf("decode competition %s: %w", competitionKey, err)
 		}
 		return &response, nil
 	} else {
-		var response Error
-		err = json.Unmarshal(body, &response)
-		if err != nil {
-			return nil, err
-		}
-		if len(response.Error) > 0 {
-			return nil, fmt.Errorf("[%s] %s", response.Status, response.Error)
-		} else {
-			return nil, errors.New(resp.Status)
-		}
+		return nil, newCloudbetError(resp, body)
 	}
 }
 
+// logger is the structured logger of the application, logging.FromContext add the fields of the crawl or request to it
 type logger interface {
-	Panicf(format string, v ...interface{})
-	Errorf(format string, v ...interface{})
-	Warningf(format string, v ...interface{})
-	Infof(format string, v ...interface{})
-	Debugf(format string, v ...interface{})
+	logrus.FieldLogger
 }
 
 type CloudbetHandler struct {
@@ -373,122 +377,107 @@ func NewCloudbetHander(eventRepo event.Repository, logger logger, apiKey string)
 	}
 }
 
-func (h CloudbetHandler) StoreAllEvents(ctx context.Context) error {
+// crawlProgress is told about the progress of a crawl as it goes
+type crawlProgress interface {
+	sportFetched()
+	competitionsFound(count int)
+	competitionCrawled(events int)
+	eventStored(result string)
+	failed(kind string, key string, err error)
+}
+
+// StoreAllEvents crawl the events of every competition of every active sport
+func (h CloudbetHandler) StoreAllEvents(ctx context.Context, progress crawlProgress) error {
 	if len(h.apiKey) == 0 {
 		h.logger.Panicf("Missing cloudbet access token")
 	}
-	allSports, err := fetchAllSports(h.apiKey)
+	log := logging.FromContext(ctx, h.logger)
+	allSports, err := fetchAllSports(ctx, h.apiKey)
 	if err != nil {
-		h.logger.Errorf("Fetch All Sports Error %s", err)
+		log.WithError(err).Errorf("Fetch all sports error")
+		progress.failed(crawl.ItemSport, "", err)
+		return err
 	}
 
 	if len(allSports.Sports) == 0 {
-		h.logger.Warningf("No sports found")
+		log.Warningf("No sports found")
 		return ErrNoSports
 	}
 
-	h.logger.Debugf("Fetched All Sports: %v", allSports.Sports)
+	log.Debugf("Fetched %d sports", len(allSports.Sports))
 
 	for _, sport := range allSports.Sports {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if sport.CompetitionCount != 0 && sport.EventCount != 0 {
 			// only check active sport
 			if len(sport.Key) == 0 {
-				h.logger.Errorf("Missing Sport key for querying competition")
+				log.Errorf("Missing sport key of %s for querying competition", sport.Name)
 				return ErrMissingSportKey
 			}
 
-			categorizedSport, err := fetchAllCompetitionsUnderSport(h.apiKey, sport.Key)
+			sportCtx := logging.WithField(ctx, logging.SportField, sport.Key)
+			log := logging.FromContext(sportCtx, h.logger)
+			categorizedSport, err := fetchAllCompetitionsUnderSport(sportCtx, h.apiKey, sport.Key)
 			if err != nil {
-				h.logger.Errorf("Fetch competitions under sport %s Error %s", sport.Name, err)
+				log.WithError(err).Errorf("Fetch competitions of sport error")
+				progress.failed(crawl.ItemSport, sport.Key, err)
 				continue
 			}
+			progress.sportFetched()
 
-			h.logger.Debugf("Fetched All categories of sport %s: %v", sport.Name, categorizedSport.Categories)
+			log.Debugf("Fetched %d categories", len(categorizedSport.Categories))
 			if len(categorizedSport.Categories) == 0 {
-				h.logger.Warningf("No categories found under sport %s", sport.Key)
+				log.Warningf("No categories found")
 				continue
 			}
 
 			sportIdentity, err := event.NewIdentifier(sport.Name, sport.Key)
 			if err != nil {
-				h.logger.Errorf("Cannot create sport identity %s", sport.Name)
+				log.WithError(err).Errorf("Cannot create sport identity")
 				continue
 			}
+			for _, category := range categorizedSport.Categories {
+				progress.competitionsFound(len(category.Competitions))
+			}
 			for _, category := range categorizedSport.Categories {
 				categoryIdentity, err := event.NewIdentifier(category.Name, category.Key)
 				if err != nil {
-					h.logger.Errorf("Cannot create category identity %s", category.Name)
+					log.WithError(err).Errorf("Cannot create category identity of %s", category.Name)
 					continue
 				}
 				for _, competition := range category.Competitions {
+					if ctx.Err() != nil {
+						return ctx.Err()
+					}
 					if len(competition.Key) == 0 {
-						h.logger.Errorf("Missing competition key for querying event")
+						log.Errorf("Missing competition key of %s for querying event", competition.Name)
+						progress.competitionCrawled(0)
 						continue
 					}
+					competitionCtx := logging.WithField(sportCtx, logging.CompetitionField, competition.Key)
+					log := logging.FromContext(competitionCtx, h.logger)
 					if competition.EventCount == 0 {
-						h.logger.Warningf("Event not found under competition %s", competition.Name)
+						log.Warningf("No events found")
+						progress.competitionCrawled(0)
 						continue
 					}
 
 					competitionIdentity, err := event.NewIdentifier(competition.Name, competition.Key)
 					if err != nil {
-						h.logger.Errorf("Cannot create competition identity %s", competition.Name)
+						log.WithError(err).Errorf("Cannot create competition identity")
+						progress.competitionCrawled(0)
 						continue
 					}
-					competitionInfo, err := fetchAllEventsUnderCompetition(h.apiKey, competition.Key)
+					competitionInfo, err := fetchAllEventsUnderCompetition(competitionCtx, h.apiKey, competition.Key)
 					if err != nil {
-						h.logger.Errorf("Fetch event under sport %s competition %s Error %s", sport.Name, competition.Name, err)
+						log.WithError(err).Errorf("Fetch events of competition error")
+						progress.failed(crawl.ItemCompetition, competition.Key, err)
+						progress.competitionCrawled(0)
 						continue
 					}
-					for _, competitionEvent := range competitionInfo.Events {
-						var homeIdentity event.TeamIdentifier
-						if competitionEvent.Home != nil {
-							homeIdentity = event.NewTeamIdentifier(competitionEvent.Home.Name, competitionEvent.Home.Key, competitionEvent.Home.Abbreviation, competitionEvent.Home.Nationality)
-						} else {
-							homeIdentity = event.NewTeamIdentifier("", "", "", "")
-						}
-						var awayIdentity event.TeamIdentifier
-						if competitionEvent.Home != nil {
-							awayIdentity = event.NewTeamIdentifier(competitionEvent.Away.Name, competitionEvent.Away.Key, competitionEvent.Away.Abbreviation, competitionEvent.Away.Nationality)
-						} else {
-							awayIdentity = event.NewTeamIdentifier("", "", "", "")
-						}
-
-						var active bool
-						if competitionEvent.Status == "TRADING" || competitionEvent.Status == "TRADING_LIVE" {
-							active = true
-						}
-
-						marketValue := make(map[string]event.Market)
-						for key, market := range competitionEvent.Markets {
-							subMarketValue := make(map[string][]event.Selection)
-							for subMarketKey, subMarket := range market.Submarkets {
-								selections := make([]event.Selection, len(subMarket.Selections))
-								for index, selection := range subMarket.Selections {
-									selections[index] = event.NewSelection(selection.Outcome, selection.Params, selection.Price, selection.MaxStake, selection.Probability, selection.Status, selection.Side)
-								}
-								subMarketValue[subMarketKey] = selections
-							}
-							marketValue[key] = event.NewMarket(subMarketValue)
-						}
-
-						cutOffTime, err := time.Parse(time.RFC3339, competitionEvent.CutoffTime)
-						if err != nil {
-							h.logger.Errorf("Parse Cut off time error %s", err)
-							continue
-						}
-
-						e, err := event.NewEvent(&sportIdentity, &competitionIdentity, &categoryIdentity, homeIdentity, awayIdentity, active, marketValue, competitionEvent.Name, competitionEvent.Key, cutOffTime)
-						if err != nil {
-							h.logger.Errorf("Parse Cut off time error %s", err)
-							continue
-						}
-
-						err = h.eventRepo.Save(ctx, *e)
-						if err != nil {
-							h.logger.Errorf("Store data error %s", err)
-						}
-					}
+					h.storeCompetitionEvents(competitionCtx, sportIdentity, categoryIdentity, competitionIdentity, competitionInfo.Events, progress)
 				}
 
 			}
@@ -497,18 +486,151 @@ func (h CloudbetHandler) StoreAllEvents(ctx context.Context) error {
 		}
 	}
 
-	h.logger.Infof("Finished Fetch Events from Cloudbet and store to DB")
+	log.Infof("Finished fetching events from Cloudbet")
+	return nil
+}
+
+// StoreCompetitionEvents crawl the events of a single competition
+func (h CloudbetHandler) StoreCompetitionEvents(ctx context.Context, competitionKey string, progress crawlProgress) error {
+	if len(h.apiKey) == 0 {
+		h.logger.Panicf("Missing cloudbet access token")
+	}
+	ctx = logging.WithField(ctx, logging.CompetitionField, competitionKey)
+	log := logging.FromContext(ctx, h.logger)
+	progress.competitionsFound(1)
+	competitionInfo, err := fetchAllEventsUnderCompetition(ctx, h.apiKey, competitionKey)
+	if err != nil {
+		log.WithError(err).Errorf("Fetch events of competition error")
+		progress.failed(crawl.ItemCompetition, competitionKey, err)
+		progress.competitionCrawled(0)
+		return err
+	}
+
+	sportIdentity, err := event.NewIdentifier(competitionInfo.Sport.Name, competitionInfo.Sport.Key)
+	if err != nil {
+		progress.failed(crawl.ItemCompetition, competitionKey, err)
+		progress.competitionCrawled(0)
+		return err
+	}
+	categoryIdentity, err := event.NewIdentifier(competitionInfo.Category.Name, competitionInfo.Category.Key)
+	if err != nil {
+		progress.failed(crawl.ItemCompetition, competitionKey, err)
+		progress.competitionCrawled(0)
+		return err
+	}
+	competitionIdentity, err := event.NewIdentifier(competitionInfo.Name, competitionInfo.Key)
+	if err != nil {
+		progress.failed(crawl.ItemCompetition, competitionKey, err)
+		progress.competitionCrawled(0)
+		return err
+	}
+	h.storeCompetitionEvents(logging.WithField(ctx, logging.SportField, sportIdentity.Key()), sportIdentity, categoryIdentity, competitionIdentity, competitionInfo.Events, progress)
+
+	log.Infof("Finished fetching events of competition from Cloudbet")
 	return nil
 }
 
-func fetchEventInfo(apiKey string, eventKey string) (*Event, error) {
+func (h CloudbetHandler) storeCompetitionEvents(ctx context.Context, sportIdentity event.Identifier, categoryIdentity event.Identifier, competitionIdentity event.Identifier, events []Event, progress crawlProgress) {
+	for _, competitionEvent := range events {
+		log := logging.FromContext(logging.WithField(ctx, logging.EventField, competitionEvent.Key), h.logger)
+		var homeIdentity event.TeamIdentifier
+		if competitionEvent.Home != nil {
+			homeIdentity = event.NewTeamIdentifier(competitionEvent.Home.Name, competitionEvent.Home.Key, competitionEvent.Home.Abbreviation, competitionEvent.Home.Nationality)
+		} else {
+			homeIdentity = event.NewTeamIdentifier("", "", "", "")
+		}
+		var awayIdentity event.TeamIdentifier
+		if competitionEvent.Away != nil {
+			awayIdentity = event.NewTeamIdentifier(competitionEvent.Away.Name, competitionEvent.Away.Key, competitionEvent.Away.Abbreviation, competitionEvent.Away.Nationality)
+		} else {
+			awayIdentity = event.NewTeamIdentifier("", "", "", "")
+		}
+
+		var active bool
+		if competitionEvent.Status == "TRADING" || competitionEvent.Status == "TRADING_LIVE" {
+			active = true
+		}
+		live := competitionEvent.Status == "TRADING_LIVE"
+
+		marketValue := make(map[string]event.Market)
+		for key, market := range competitionEvent.Markets {
+			subMarketValue := make(map[string][]event.Selection)
+			for subMarketKey, subMarket := range market.Submarkets {
+				selections := make([]event.Selection, len(subMarket.Selections))
+				for index, selection := range subMarket.Selections {
+					selections[index] = event.NewSelection(selection.Outcome, selection.Params, selection.Price, selection.MaxStake, selection.Probability, selection.Status, selection.Side)
+				}
+				subMarketValue[subMarketKey] = selections
+			}
+			marketValue[key] = event.NewMarket(subMarketValue)
+		}
+
+		cutOffTime, err := time.Parse(time.RFC3339, competitionEvent.CutoffTime)
+		if err != nil {
+			if logging.EventSampled("parse cutoff time") {
+				log.WithError(err).Errorf("Parse cutoff time error")
+			}
+			progress.failed(crawl.ItemEvent, competitionEvent.Key, err)
+			continue
+		}
+
+		e, err := event.NewEvent(&sportIdentity, &competitionIdentity, &categoryIdentity, homeIdentity, awayIdentity, active, live, marketValue, competitionEvent.Name, competitionEvent.Key, cutOffTime)
+		if err != nil {
+			if logging.EventSampled("create event") {
+				log.WithError(err).Errorf("Create event error")
+			}
+			progress.failed(crawl.ItemEvent, competitionEvent.Key, err)
+			continue
+		}
+
+		result, err := h.saveEvent(ctx, *e)
+		if err != nil {
+			if logging.EventSampled("store event") {
+				log.WithError(err).Errorf("Store event error")
+			}
+			progress.failed(crawl.ItemEvent, competitionEvent.Key, err)
+			continue
+		}
+		if logging.EventSampled("event stored") {
+			log.Debugf("Event %s", result)
+		}
+		progress.eventStored(result)
+		eventsStored.WithLabelValues(sportIdentity.Key(), result).Inc()
+	}
+	progress.competitionCrawled(len(events))
+}
+
+// saveEvent store e and tell whether it was created, updated or unchanged, by the version the repository gave it
+func (h CloudbetHandler) saveEvent(ctx context.Context, e event.Event) (string, error) {
+	previous, err := h.eventRepo.GetEvent(ctx, e.Key())
+	exists := err == nil
+	if err != nil && !errors.Is(err, event.ErrEventNotFound) {
+		return "", err
+	}
+	if err := h.eventRepo.Save(ctx, e); err != nil {
+		return "", err
+	}
+	if !exists {
+		return crawl.EventCreated, nil
+	}
+	stored, err := h.eventRepo.GetEvent(ctx, e.Key())
+	if err != nil {
+		return "", err
+	}
+	if stored.Version() == previous.Version() {
+		return crawl.EventUnchanged, nil
+	}
+	return crawl.EventUpdated, nil
+}
+
+func fetchEventInfo(ctx context.Context, apiKey string, eventKey string) (*Event, error) {
 	if eventKey == "" {
 		return nil, ErrMissingCompetitionKey
 	}
 
 	client := &http.Client{}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://sports-api-stg.cloudbet.com/pub/v2/odds/events/%s", eventKey), nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://sports-api-stg.cloudbet.com/pub/v2/odds/events/%s", eventKey), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -516,7 +638,7 @@ func fetchEventInfo(apiKey string, eventKey string) (*Event, error) {
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("X-API-Key", apiKey)
 
-	resp, err := client.Do(req)
+	resp, err := doCloudbet(client, req, "event")
 	if err != nil {
 		return nil, err
 	}
@@ -531,21 +653,11 @@ func fetchEventInfo(apiKey string, eventKey string) (*Event, error) {
 		var response Event
 		err = json.Unmarshal(body, &response)
 		if err != nil {
-			fmt.Println(string(body))
-			return nil, err
+			return nil, fmt.Errorf("decode event %s: %w", eventKey, err)
 		}
 		return &response, nil
 	} else {
-		var response Error
-		err = json.Unmarshal(body, &response)
-		if err != nil {
-			return nil, err
-		}
-		if len(response.Error) > 0 {
-			return nil, fmt.Errorf("[%s] %s", response.Status, response.Error)
-		} else {
-			return nil, errors.New(resp.Status)
-		}
+		return nil, newCloudbetError(resp, body)
 	}
 }
 
@@ -553,40 +665,84 @@ func (h CloudbetHandler) CheckEventsCloseToCutOff(ctx context.Context) {
 	if len(h.apiKey) == 0 {
 		h.logger.Panicf("Missing cloudbet access token")
 	}
+	ctx, span := tracer.Start(ctx, "check cutoffs")
+	defer span.End()
 	events, err := h.eventRepo.ListEventsCutOffSoon(ctx)
 	if err != nil {
-		h.logger.Errorf(err.Error())
+		logging.FromContext(ctx, h.logger).WithError(err).Errorf("List events close to cutoff error")
 	}
 
 	for _, event := range events {
-		latestEvent, err := fetchEventInfo(h.apiKey, event.Key())
+		if ctx.Err() != nil {
+			return
+		}
+		eventCtx := logging.WithField(ctx, logging.EventField, event.Key())
+		latestEvent, err := fetchEventInfo(eventCtx, h.apiKey, event.Key())
 		if err != nil {
-			h.logger.Errorf(err.Error())
+			if logging.EventSampled("fetch event") {
+				logging.FromContext(eventCtx, h.logger).WithError(err).Errorf("Fetch event error")
+			}
 			continue
 		}
 
 		if latestEvent.Status != "TRADING" && latestEvent.Status != "TRADING_LIVE" {
 			event.Inactivate()
+			if err := h.eventRepo.Save(eventCtx, event); err != nil {
+				logging.FromContext(eventCtx, h.logger).WithError(err).Errorf("Store event error")
+			}
 		}
 	}
 }
 
 type Queries struct {
-	ListSports       *query.ListSportsHandler
-	GetSport         *query.GetSportHandler
-	ListCategories   *query.ListCategoriesHandler
-	GetCategory      *query.GetCategoryHandler
-	ListCompetitions *query.ListCompetitionsHandler
-	GetCompetition   *query.GetCompetitionHandler
-	ListEvents       *query.ListEventsHandler
-	GetEvent         *query.GetEventHandler
+	ListSports           *query.ListSportsHandler
+	GetSport             *query.GetSportHandler
+	ListCategories       *query.ListCategoriesHandler
+	GetCategory          *query.GetCategoryHandler
+	ListCompetitions     *query.ListCompetitionsHandler
+	GetCompetition       *query.GetCompetitionHandler
+	ListEvents           *query.ListEventsHandler
+	GetEvent             *query.GetEventHandler
+	GetEvents            *query.GetEventsHandler
+	ListTeams            *query.ListTeamsHandler
+	GetTeam              *query.GetTeamHandler
+	GetEventLadder       *query.GetEventLadderHandler
+	ListArbitrage        *query.ListArbitrageHandler
+	ListValueBets        *query.ListValueBetsHandler
+	CalculateKelly       *query.CalculateKellyHandler
+	CalculateHedge       *query.CalculateHedgeHandler
+	CalculateDutching    *query.CalculateDutchingHandler
+	CalculateParlay      *query.CalculateParlayHandler
+	GetEventLiquidity    *query.GetEventLiquidityHandler
+	RankLiquidity        *query.RankEventsByLiquidityHandler
+	Search               *query.SearchHandler
+	ListChanges          *query.ListChangesHandler
+	GetReplicationStatus *query.GetReplicationStatusHandler
+	ListKeys             *query.ListKeysHandler
+	GetKey               *query.GetKeyHandler
+	GetCrawlState        *query.GetCrawlStateHandler
+	GetCrawlInterval     *query.GetCrawlIntervalHandler
+	ListCrawlRuns        *query.ListCrawlRunsHandler
+	GetCrawlRun          *query.GetCrawlRunHandler
+	GetReadiness         *query.GetReadinessHandler
+}
+
+type Commands struct {
+	UploadModelProbabilities *command.UploadModelProbabilitiesHandler
+	CreateKey                *command.CreateKeyHandler
+	RevokeKey                *command.RevokeKeyHandler
+	Authenticate             *command.AuthenticateHandler
+	TriggerCrawl             *command.TriggerCrawlHandler
+	PauseCrawl               *command.PauseCrawlHandler
+	SetCrawlIntervals        *command.SetCrawlIntervalsHandler
 }
 
 type Application struct {
-	Query Queries
+	Query   Queries
+	Command Commands
 }
 
-func NewApplication(sportRepo sport.Repository, categoryRepo category.Repository, competitionRepo competition.Repository, eventRepo event.Repository, logger logger) *Application {
+func NewApplication(sportRepo sport.Repository, categoryRepo category.Repository, competitionRepo competition.Repository, eventRepo event.Repository, teamRepo team.Repository, arbitrageRepo arbitrage.Repository, valuebetRepo valuebet.Repository, searchRepo search.Repository, changeRepo change.Repository, replicationRepo replication.Repository, apiKeyRepo apikey.Repository, crawlRunRepo crawl.Repository, crawlScheduler *CrawlScheduler, maxDataAge time.Duration, logger logger) *Application {
 	listSportsHandler := query.NewListSportHandler(sportRepo, logger)
 	getSportHandler := query.NewGetSportHandler(sportRepo, logger)
 	listCategoriesHandler := query.NewListCategoriesHandler(categoryRepo, logger)
@@ -595,17 +751,78 @@ func NewApplication(sportRepo sport.Repository, categoryRepo category.Repository
 	getCompetitionHandler := query.NewGetCompetitionHandler(competitionRepo, logger)
 	listEventsHandler := query.NewListEventsHandler(eventRepo, logger)
 	getEventHandler := query.NewGetEventHandler(eventRepo, logger)
+	getEventsHandler := query.NewGetEventsHandler(eventRepo, logger)
+	listTeamsHandler := query.NewListTeamsHandler(teamRepo, logger)
+	getTeamHandler := query.NewGetTeamHandler(teamRepo, logger)
+	getEventLadderHandler := query.NewGetEventLadderHandler(eventRepo, logger)
+	listArbitrageHandler := query.NewListArbitrageHandler(arbitrageRepo, logger)
+	listValueBetsHandler := query.NewListValueBetsHandler(eventRepo, valuebetRepo, logger)
+	calculateKellyHandler := query.NewCalculateKellyHandler(eventRepo, logger)
+	calculateHedgeHandler := query.NewCalculateHedgeHandler(eventRepo, logger)
+	calculateDutchingHandler := query.NewCalculateDutchingHandler(eventRepo, logger)
+	calculateParlayHandler := query.NewCalculateParlayHandler(eventRepo, logger)
+	getEventLiquidityHandler := query.NewGetEventLiquidityHandler(eventRepo, logger)
+	rankLiquidityHandler := query.NewRankEventsByLiquidityHandler(eventRepo, logger)
+	searchHandler := query.NewSearchHandler(searchRepo, logger)
+	listChangesHandler := query.NewListChangesHandler(changeRepo, logger)
+	getReplicationStatusHandler := query.NewGetReplicationStatusHandler(replicationRepo, logger)
+	listKeysHandler := query.NewListKeysHandler(apiKeyRepo, logger)
+	getKeyHandler := query.NewGetKeyHandler(apiKeyRepo, logger)
+	getCrawlStateHandler := query.NewGetCrawlStateHandler(crawlScheduler, logger)
+	getCrawlIntervalHandler := query.NewGetCrawlIntervalHandler(crawlScheduler, logger)
+	listCrawlRunsHandler := query.NewListCrawlRunsHandler(crawlRunRepo, logger)
+	getCrawlRunHandler := query.NewGetCrawlRunHandler(crawlRunRepo, logger)
+	getReadinessHandler := query.NewGetReadinessHandler(freshness, cloudbetCircuit, maxDataAge, logger)
+
+	uploadModelProbabilitiesHandler := command.NewUploadModelProbabilitiesHandler(valuebetRepo, logger)
+	createKeyHandler := command.NewCreateKeyHandler(apiKeyRepo, logger)
+	revokeKeyHandler := command.NewRevokeKeyHandler(apiKeyRepo, logger)
+	authenticateHandler := command.NewAuthenticateHandler(apiKeyRepo, logger)
+	triggerCrawlHandler := command.NewTriggerCrawlHandler(crawlScheduler, logger)
+	pauseCrawlHandler := command.NewPauseCrawlHandler(crawlScheduler, logger)
+	setCrawlIntervalsHandler := command.NewSetCrawlIntervalsHandler(crawlScheduler, logger)
 
 	return &Application{
 		Query: Queries{
-			ListSports:       listSportsHandler,
-			GetSport:         getSportHandler,
-			ListCategories:   listCategoriesHandler,
-			GetCategory:      getCategoryHandler,
-			ListCompetitions: listCompetitionsHandler,
-			GetCompetition:   getCompetitionHandler,
-			ListEvents:       listEventsHandler,
-			GetEvent:         getEventHandler,
+			ListSports:           listSportsHandler,
+			GetSport:             getSportHandler,
+			ListCategories:       listCategoriesHandler,
+			GetCategory:          getCategoryHandler,
+			ListCompetitions:     listCompetitionsHandler,
+			GetCompetition:       getCompetitionHandler,
+			ListEvents:           listEventsHandler,
+			GetEvent:             getEventHandler,
+			GetEvents:            getEventsHandler,
+			ListTeams:            listTeamsHandler,
+			GetTeam:              getTeamHandler,
+			GetEventLadder:       getEventLadderHandler,
+			ListArbitrage:        listArbitrageHandler,
+			ListValueBets:        listValueBetsHandler,
+			CalculateKelly:       calculateKellyHandler,
+			CalculateHedge:       calculateHedgeHandler,
+			CalculateDutching:    calculateDutchingHandler,
+			CalculateParlay:      calculateParlayHandler,
+			GetEventLiquidity:    getEventLiquidityHandler,
+			RankLiquidity:        rankLiquidityHandler,
+			Search:               searchHandler,
+			ListChanges:          listChangesHandler,
+			GetReplicationStatus: getReplicationStatusHandler,
+			ListKeys:             listKeysHandler,
+			GetKey:               getKeyHandler,
+			GetCrawlState:        getCrawlStateHandler,
+			GetCrawlInterval:     getCrawlIntervalHandler,
+			ListCrawlRuns:        listCrawlRunsHandler,
+			GetCrawlRun:          getCrawlRunHandler,
+			GetReadiness:         getReadinessHandler,
+		},
+		Command: Commands{
+			UploadModelProbabilities: uploadModelProbabilitiesHandler,
+			CreateKey:                createKeyHandler,
+			RevokeKey:                revokeKeyHandler,
+			Authenticate:             authenticateHandler,
+			TriggerCrawl:             triggerCrawlHandler,
+			PauseCrawl:               pauseCrawlHandler,
+			SetCrawlIntervals:        setCrawlIntervalsHandler,
 		},
 	}
 }
@@ -0,0 +1,39 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/infrastructure"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/sirupsen/logrus"
+)
+
+// TestCheckEventsCloseToCutOffCancelled stop checking the events on shutdown instead of fetching them one by one
+func TestCheckEventsCloseToCutOffCancelled(t *testing.T) {
+	previous := cloudbetCircuit
+	cloudbetCircuit = newCircuitBreaker(circuitThreshold, circuitCooldown)
+	defer func() { cloudbetCircuit = previous }()
+
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	repo := infrastructure.NewMemoryRepository()
+	for _, key := range []string{"a", "b", "c"} {
+		if err := repo.Save(context.Background(), newTestEvent(t, key, true, time.Now().Add(time.Minute))); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// a request with a cancelled context fails without a response
+	failed := func() float64 {
+		return testutil.ToFloat64(upstreamRequests.WithLabelValues("event", "error"))
+	}
+	before := failed()
+	NewCloudbetHander(repo, logger, "test").CheckEventsCloseToCutOff(ctx)
+	if after := failed(); after != before {
+		t.Errorf("%v event requests sent, want none after cancellation", after-before)
+	}
+}
@@ -0,0 +1,28 @@
+package application
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// freshness of the data served by this instance
+var freshness = &dataFreshness{}
+
+// dataFreshness track when the data was last refreshed from its source, by a successful full crawl or replica sync
+type dataFreshness struct {
+	// unix nanoseconds, 0 before the first refresh
+	lastRefresh int64
+}
+
+func (d *dataFreshness) mark(t time.Time) {
+	atomic.StoreInt64(&d.lastRefresh, t.UnixNano())
+}
+
+// LastRefresh return the time of the last refresh, zero before the first one
+func (d *dataFreshness) LastRefresh() time.Time {
+	refreshed := atomic.LoadInt64(&d.lastRefresh)
+	if refreshed == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, refreshed)
+}
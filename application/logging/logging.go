@@ -0,0 +1,50 @@
+// Package logging carry structured log fields through contexts, so everything logged on behalf of an HTTP request or
+// a crawl can be correlated
+package logging
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// field names shared by every log line
+const (
+	RequestIDField   = "request_id"
+	CrawlRunIDField  = "crawl_run_id"
+	SportField       = "sport"
+	CompetitionField = "competition"
+	EventField       = "event_key"
+	TraceIDField     = "trace_id"
+)
+
+type fieldsKey struct{}
+
+// WithField return a copy of ctx carrying key with value on top of the fields of ctx
+func WithField(ctx context.Context, key string, value interface{}) context.Context {
+	parent := Fields(ctx)
+	fields := make(logrus.Fields, len(parent)+1)
+	for k, v := range parent {
+		fields[k] = v
+	}
+	fields[key] = value
+	return context.WithValue(ctx, fieldsKey{}, fields)
+}
+
+// Fields return the fields carried by ctx, which must not be modified
+func Fields(ctx context.Context) logrus.Fields {
+	fields, _ := ctx.Value(fieldsKey{}).(logrus.Fields)
+	return fields
+}
+
+// FromContext return logger with the fields carried by ctx and the trace ID of its span if sampled
+func FromContext(ctx context.Context, logger logrus.FieldLogger) logrus.FieldLogger {
+	if fields := Fields(ctx); len(fields) > 0 {
+		logger = logger.WithFields(fields)
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsSampled() {
+		logger = logger.WithField(TraceIDField, spanContext.TraceID().String())
+	}
+	return logger
+}
@@ -0,0 +1,49 @@
+package logging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestFromContext(t *testing.T) {
+	parent := WithField(context.Background(), RequestIDField, "r1")
+	child := WithField(parent, CrawlRunIDField, 7)
+	overridden := WithField(child, RequestIDField, "r2")
+	traceID := trace.TraceID{0x01}
+	spanContext := func(flags trace.TraceFlags) trace.SpanContext {
+		return trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: trace.SpanID{0x02}, TraceFlags: flags})
+	}
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want logrus.Fields
+	}{
+		{name: "no fields", ctx: context.Background(), want: logrus.Fields{}},
+		{name: "parent fields untouched by children", ctx: parent, want: logrus.Fields{RequestIDField: "r1"}},
+		{name: "inherited fields", ctx: child, want: logrus.Fields{RequestIDField: "r1", CrawlRunIDField: 7}},
+		{name: "overridden field", ctx: overridden, want: logrus.Fields{RequestIDField: "r2", CrawlRunIDField: 7}},
+		{name: "sampled trace", ctx: trace.ContextWithSpanContext(parent, spanContext(trace.FlagsSampled)), want: logrus.Fields{RequestIDField: "r1", TraceIDField: traceID.String()}},
+		{name: "unsampled trace", ctx: trace.ContextWithSpanContext(parent, spanContext(0)), want: logrus.Fields{RequestIDField: "r1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger, hook := test.NewNullLogger()
+			FromContext(tt.ctx, logger).Info("message")
+			got := hook.LastEntry().Data
+			if len(got) != len(tt.want) {
+				t.Fatalf("fields = %v, want %v", got, tt.want)
+			}
+			for k, v := range tt.want {
+				if got[k] != v {
+					t.Errorf("fields = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package logging
+
+import (
+	"sync"
+	"time"
+)
+
+// Sampler thin out repetitive messages, like the ones logged for every crawled event. Per message kind and tick, the
+// first messages are let through then every thereafter-th one, so bursts stay visible without flooding the logs.
+type Sampler struct {
+	lock       *sync.Mutex
+	tick       time.Duration
+	first      int
+	thereafter int
+	counts     map[string]*sampleCount
+}
+
+type sampleCount struct {
+	start time.Time
+	count int
+}
+
+func NewSampler(tick time.Duration, first int, thereafter int) *Sampler {
+	return &Sampler{
+		lock:       &sync.Mutex{},
+		tick:       tick,
+		first:      first,
+		thereafter: thereafter,
+		counts:     make(map[string]*sampleCount),
+	}
+}
+
+// Allow report whether a message of kind logged at now should be written
+func (s *Sampler) Allow(kind string, now time.Time) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	c, ok := s.counts[kind]
+	if !ok || now.Sub(c.start) >= s.tick {
+		c = &sampleCount{start: now}
+		s.counts[kind] = c
+	}
+	c.count++
+	if c.count <= s.first {
+		return true
+	}
+	return s.thereafter > 0 && (c.count-s.first)%s.thereafter == 0
+}
+
+// sampler of the messages logged for every event
+var events = NewSampler(time.Second, 10, 100)
+
+// EventSampled report whether a per event message of kind should be written now
+func EventSampled(kind string) bool {
+	return events.Allow(kind, time.Now())
+}
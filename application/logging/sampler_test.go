@@ -0,0 +1,57 @@
+package logging
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAllow(t *testing.T) {
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		first      int
+		thereafter int
+		kind       string
+		// offsets of the messages from start
+		at   []time.Duration
+		want []bool
+	}{
+		{
+			name: "first then every thereafter-th", first: 2, thereafter: 3,
+			at:   []time.Duration{0, 0, 0, 0, 0, 0, 0, 0},
+			want: []bool{true, true, false, false, true, false, false, true},
+		},
+		{
+			name: "new tick starts over", first: 1, thereafter: 0,
+			at:   []time.Duration{0, 500 * time.Millisecond, time.Second, 1500 * time.Millisecond},
+			want: []bool{true, false, true, false},
+		},
+		{
+			name: "nothing thereafter", first: 1, thereafter: 0,
+			at:   []time.Duration{0, 0, 0},
+			want: []bool{true, false, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSampler(time.Second, tt.first, tt.thereafter)
+			got := make([]bool, 0, len(tt.at))
+			for _, at := range tt.at {
+				got = append(got, s.Allow("stored", start.Add(at)))
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("Allow() = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+
+	// kinds are counted apart
+	s := NewSampler(time.Second, 1, 0)
+	if !s.Allow("stored", start) || !s.Allow("failed", start) || s.Allow("stored", start) {
+		t.Errorf("Allow() shared counts between kinds")
+	}
+}
@@ -0,0 +1,64 @@
+package application
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	upstreamRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "cloudbet",
+		Name:      "upstream_requests_total",
+		Help:      "Requests to the Cloudbet API by endpoint and HTTP status code, error when no response was received and circuit_open when not sent.",
+	}, []string{"endpoint", "status"})
+	upstreamDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "cloudbet",
+		Name:      "upstream_request_duration_seconds",
+		Help:      "Duration of requests to the Cloudbet API by endpoint, until the response headers are received.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"endpoint"})
+	crawlDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: "cloudbet",
+		Name:      "crawl_duration_seconds",
+		Help:      "Duration of crawls by kind, full or competition.",
+		Buckets:   prometheus.ExponentialBuckets(0.5, 2, 12),
+	}, []string{"kind"})
+	crawlErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "cloudbet",
+		Name:      "crawl_errors_total",
+		Help:      "Items a crawl failed to fetch or store by kind, sport, competition or event.",
+	}, []string{"kind"})
+	eventsStored = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: "cloudbet",
+		Name:      "events_stored_total",
+		Help:      "Crawled events stored by sport and result, created, updated or unchanged.",
+	}, []string{"sport", "result"})
+	replicaLag = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: "cloudbet",
+		Name:      "replica_lag_changes",
+		Help:      "Peer changes not applied yet as of the last replica sync.",
+	})
+	_ = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "cloudbet",
+		Name:      "data_age_seconds",
+		Help:      "Seconds since the data was last refreshed by a successful crawl or replica sync, -1 before the first one.",
+	}, func() float64 {
+		refreshed := freshness.LastRefresh()
+		if refreshed.IsZero() {
+			return -1
+		}
+		return time.Since(refreshed).Seconds()
+	})
+	_ = promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: "cloudbet",
+		Name:      "upstream_circuit_open",
+		Help:      "1 while the circuit to the Cloudbet API is open, until a trial request succeeds, 0 otherwise.",
+	}, func() float64 {
+		if open, _ := cloudbetCircuit.Open(time.Now()); open {
+			return 1
+		}
+		return 0
+	})
+)
@@ -0,0 +1,58 @@
+package application
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestUpstreamMetrics(t *testing.T) {
+	previous := cloudbetCircuit
+	cloudbetCircuit = newCircuitBreaker(2, time.Hour)
+	defer func() { cloudbetCircuit = previous }()
+
+	statuses := []int{http.StatusOK, http.StatusNotFound, http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(statuses[requests])
+		requests++
+	}))
+	defer srv.Close()
+
+	const endpoint = "test_metrics"
+	counted := func(status string) float64 {
+		return testutil.ToFloat64(upstreamRequests.WithLabelValues(endpoint, status))
+	}
+	// the last request is not sent, the two failures opened the circuit
+	for i := 0; i < len(statuses)+1; i++ {
+		req, _ := http.NewRequest("GET", srv.URL, nil)
+		if resp, err := doCloudbet(srv.Client(), req, endpoint); err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	tests := []struct {
+		status string
+		want   float64
+	}{
+		{status: strconv.Itoa(http.StatusOK), want: 1},
+		{status: strconv.Itoa(http.StatusNotFound), want: 1},
+		{status: strconv.Itoa(http.StatusServiceUnavailable), want: 2},
+		{status: "circuit_open", want: 1},
+		{status: "error", want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			if got := counted(tt.status); got != tt.want {
+				t.Errorf("upstream requests with status %s = %v, want %v", tt.status, got, tt.want)
+			}
+		})
+	}
+	if requests != len(statuses) {
+		t.Errorf("%d requests sent, want %d", requests, len(statuses))
+	}
+}
@@ -0,0 +1,39 @@
+package query
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/domain/apikey"
+)
+
+type ListKeysHandler struct {
+	apiKeyRepo apikey.Repository
+	logger     logger
+}
+
+func NewListKeysHandler(apiKeyRepo apikey.Repository, logger logger) *ListKeysHandler {
+	return &ListKeysHandler{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+func (h ListKeysHandler) Handle(ctx context.Context) ([]apikey.Key, error) {
+	return h.apiKeyRepo.ListKeys(ctx)
+}
+
+type GetKeyHandler struct {
+	apiKeyRepo apikey.Repository
+	logger     logger
+}
+
+func NewGetKeyHandler(apiKeyRepo apikey.Repository, logger logger) *GetKeyHandler {
+	return &GetKeyHandler{
+		apiKeyRepo: apiKeyRepo,
+		logger:     logger,
+	}
+}
+
+func (h GetKeyHandler) Handle(ctx context.Context, id string) (apikey.Key, error) {
+	return h.apiKeyRepo.GetKey(ctx, id)
+}
@@ -0,0 +1,23 @@
+package query
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/domain/arbitrage"
+)
+
+type ListArbitrageHandler struct {
+	arbitrageRepo arbitrage.Repository
+	logger        logger
+}
+
+func NewListArbitrageHandler(arbitrageRepo arbitrage.Repository, logger logger) *ListArbitrageHandler {
+	return &ListArbitrageHandler{
+		arbitrageRepo: arbitrageRepo,
+		logger:        logger,
+	}
+}
+
+func (l ListArbitrageHandler) Handle(ctx context.Context, first int, page int) ([]arbitrage.Opportunity, error) {
+	return l.arbitrageRepo.ListOpportunities(ctx, first, page)
+}
@@ -0,0 +1,115 @@
+package query
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/awcjack/cloudbet/domain/calculator"
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+// resolveLegs price references with the live cached selections
+func resolveLegs(ctx context.Context, eventRepo event.Repository, references []event.Reference) ([]calculator.Leg, error) {
+	legs := make([]calculator.Leg, len(references))
+	for i, reference := range references {
+		e, err := eventRepo.GetEvent(ctx, reference.EventKey())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", reference.EventKey(), err)
+		}
+		selection, _, err := e.Selection(reference.MarketKey(), reference.Outcome(), reference.Params())
+		if err != nil {
+			return nil, err
+		}
+		leg, err := calculator.NewLeg(reference, e, selection)
+		if err != nil {
+			return nil, err
+		}
+		legs[i] = leg
+	}
+
+	return legs, nil
+}
+
+type CalculateKellyHandler struct {
+	eventRepo event.Repository
+	logger    logger
+}
+
+func NewCalculateKellyHandler(eventRepo event.Repository, logger logger) *CalculateKellyHandler {
+	return &CalculateKellyHandler{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func (c CalculateKellyHandler) Handle(ctx context.Context, reference event.Reference, probability float64, bankroll float64, multiplier float64) (calculator.Kelly, error) {
+	legs, err := resolveLegs(ctx, c.eventRepo, []event.Reference{reference})
+	if err != nil {
+		return calculator.Kelly{}, err
+	}
+
+	return calculator.NewKelly(legs[0], probability, bankroll, multiplier)
+}
+
+type CalculateHedgeHandler struct {
+	eventRepo event.Repository
+	logger    logger
+}
+
+func NewCalculateHedgeHandler(eventRepo event.Repository, logger logger) *CalculateHedgeHandler {
+	return &CalculateHedgeHandler{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func (c CalculateHedgeHandler) Handle(ctx context.Context, stake float64, price float64, reference event.Reference) (calculator.Hedge, error) {
+	legs, err := resolveLegs(ctx, c.eventRepo, []event.Reference{reference})
+	if err != nil {
+		return calculator.Hedge{}, err
+	}
+
+	return calculator.NewHedge(stake, price, legs[0])
+}
+
+type CalculateDutchingHandler struct {
+	eventRepo event.Repository
+	logger    logger
+}
+
+func NewCalculateDutchingHandler(eventRepo event.Repository, logger logger) *CalculateDutchingHandler {
+	return &CalculateDutchingHandler{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func (c CalculateDutchingHandler) Handle(ctx context.Context, references []event.Reference, totalStake float64) (calculator.Dutching, error) {
+	legs, err := resolveLegs(ctx, c.eventRepo, references)
+	if err != nil {
+		return calculator.Dutching{}, err
+	}
+
+	return calculator.NewDutching(legs, totalStake)
+}
+
+type CalculateParlayHandler struct {
+	eventRepo event.Repository
+	logger    logger
+}
+
+func NewCalculateParlayHandler(eventRepo event.Repository, logger logger) *CalculateParlayHandler {
+	return &CalculateParlayHandler{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func (c CalculateParlayHandler) Handle(ctx context.Context, references []event.Reference, stake float64) (calculator.Parlay, error) {
+	legs, err := resolveLegs(ctx, c.eventRepo, references)
+	if err != nil {
+		return calculator.Parlay{}, err
+	}
+
+	return calculator.NewParlay(legs, stake)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/awcjack/cloudbet/domain/category"
+	"github.com/awcjack/cloudbet/domain/pagination"
 )
 
 type ListCategoriesHandler struct {
@@ -18,8 +19,8 @@ func NewListCategoriesHandler(categoryRepo category.Repository, logger logger) *
 	}
 }
 
-func (l ListCategoriesHandler) Handle(ctx context.Context, first int, page int, sportKey string) ([]category.Category, error) {
-	return l.categoryRepo.ListCategories(ctx, first, page, sportKey)
+func (l ListCategoriesHandler) Handle(ctx context.Context, request pagination.Request, sportKey string) ([]category.Category, pagination.Info, error) {
+	return l.categoryRepo.ListCategories(ctx, request, sportKey)
 }
 
 type GetCategoryHandler struct {
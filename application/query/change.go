@@ -0,0 +1,27 @@
+package query
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/domain/change"
+)
+
+type ListChangesHandler struct {
+	changeRepo change.Repository
+	logger     logger
+}
+
+func NewListChangesHandler(changeRepo change.Repository, logger logger) *ListChangesHandler {
+	return &ListChangesHandler{
+		changeRepo: changeRepo,
+		logger:     logger,
+	}
+}
+
+func (l ListChangesHandler) Handle(ctx context.Context, since uint64, limit int) (change.List, error) {
+	if limit < 1 || limit > change.MaxLimit {
+		return change.List{}, change.ErrInvalidLimit
+	}
+
+	return l.changeRepo.ListChanges(ctx, since, limit)
+}
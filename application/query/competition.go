@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/awcjack/cloudbet/domain/competition"
+	"github.com/awcjack/cloudbet/domain/pagination"
 )
 
 type ListCompetitionsHandler struct {
@@ -18,8 +19,8 @@ func NewListCompetitionHandler(competitionRepo competition.Repository, logger lo
 	}
 }
 
-func (l ListCompetitionsHandler) Handle(ctx context.Context, first int, page int, sportKey string) ([]competition.Competition, error) {
-	return l.competitionRepo.ListCompetitions(ctx, first, page, sportKey)
+func (l ListCompetitionsHandler) Handle(ctx context.Context, request pagination.Request, filter competition.Filter) ([]competition.Competition, pagination.Info, error) {
+	return l.competitionRepo.ListCompetitions(ctx, request, filter)
 }
 
 type GetCompetitionHandler struct {
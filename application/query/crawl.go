@@ -0,0 +1,92 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/crawl"
+)
+
+type crawlScheduler interface {
+	State(ctx context.Context, count int) (crawl.State, error)
+	Interval() time.Duration
+}
+
+type GetCrawlStateHandler struct {
+	scheduler crawlScheduler
+	logger    logger
+}
+
+func NewGetCrawlStateHandler(scheduler crawlScheduler, logger logger) *GetCrawlStateHandler {
+	return &GetCrawlStateHandler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Handle return the crawl schedule with up to runs latest finished runs
+func (h GetCrawlStateHandler) Handle(ctx context.Context, runs int) (crawl.State, error) {
+	if runs < 1 || runs > crawl.MaxRuns {
+		return crawl.State{}, crawl.ErrInvalidRunCount
+	}
+	return h.scheduler.State(ctx, runs)
+}
+
+type GetCrawlIntervalHandler struct {
+	scheduler crawlScheduler
+	logger    logger
+}
+
+func NewGetCrawlIntervalHandler(scheduler crawlScheduler, logger logger) *GetCrawlIntervalHandler {
+	return &GetCrawlIntervalHandler{
+		scheduler: scheduler,
+		logger:    logger,
+	}
+}
+
+// Handle return the current interval between two scheduled crawls
+func (h GetCrawlIntervalHandler) Handle(_ context.Context) time.Duration {
+	return h.scheduler.Interval()
+}
+
+type ListCrawlRunsHandler struct {
+	runRepo crawl.Repository
+	logger  logger
+}
+
+func NewListCrawlRunsHandler(runRepo crawl.Repository, logger logger) *ListCrawlRunsHandler {
+	return &ListCrawlRunsHandler{
+		runRepo: runRepo,
+		logger:  logger,
+	}
+}
+
+// Handle return up to first runs matching filter, latest first
+func (h ListCrawlRunsHandler) Handle(ctx context.Context, filter crawl.Filter, first int) ([]crawl.Run, error) {
+	if first < 1 || first > crawl.MaxRuns {
+		return nil, crawl.ErrInvalidRunCount
+	}
+	if filter.Kind != "" && filter.Kind != crawl.KindFull && filter.Kind != crawl.KindCompetition {
+		return nil, crawl.ErrInvalidFilter
+	}
+	if filter.Trigger != "" && filter.Trigger != crawl.TriggerScheduled && filter.Trigger != crawl.TriggerManual {
+		return nil, crawl.ErrInvalidFilter
+	}
+	return h.runRepo.ListRuns(ctx, filter, first)
+}
+
+type GetCrawlRunHandler struct {
+	runRepo crawl.Repository
+	logger  logger
+}
+
+func NewGetCrawlRunHandler(runRepo crawl.Repository, logger logger) *GetCrawlRunHandler {
+	return &GetCrawlRunHandler{
+		runRepo: runRepo,
+		logger:  logger,
+	}
+}
+
+func (h GetCrawlRunHandler) Handle(ctx context.Context, id uint64) (crawl.Run, error) {
+	return h.runRepo.GetRun(ctx, id)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/pagination"
 )
 
 type ListEventsHandler struct {
@@ -18,8 +19,11 @@ func NewListEventsHandler(eventRepo event.Repository, logger logger) *ListEvents
 	}
 }
 
-func (l ListEventsHandler) Handle(ctx context.Context, first int, page int, sportKey string, categoryKey string, competitionKey string) ([]event.Event, error) {
-	return l.eventRepo.ListEvents(ctx, first, page, sportKey, categoryKey, competitionKey)
+func (l ListEventsHandler) Handle(ctx context.Context, request pagination.Request, filter event.Filter, order event.Order) ([]event.Event, pagination.Info, error) {
+	if !filter.CutOffFrom.IsZero() && !filter.CutOffTo.IsZero() && !filter.CutOffFrom.Before(filter.CutOffTo) {
+		return nil, pagination.Info{}, event.ErrInvalidCutOffWindow
+	}
+	return l.eventRepo.ListEvents(ctx, request, filter, order)
 }
 
 type GetEventHandler struct {
@@ -37,3 +41,27 @@ func NewGetEventHandler(eventRepo event.Repository, logger logger) *GetEventHand
 func (g GetEventHandler) Handle(ctx context.Context, eventKey string) (event.Event, error) {
 	return g.eventRepo.GetEvent(ctx, eventKey)
 }
+
+type GetEventsHandler struct {
+	eventRepo event.Repository
+	logger    logger
+}
+
+func NewGetEventsHandler(eventRepo event.Repository, logger logger) *GetEventsHandler {
+	return &GetEventsHandler{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func (g GetEventsHandler) Handle(ctx context.Context, eventKeys []string) ([]event.Event, []string, error) {
+	if len(eventKeys) < 1 || len(eventKeys) > event.MaxBatchSize {
+		return nil, nil, event.ErrInvalidKeyCount
+	}
+	for _, eventKey := range eventKeys {
+		if eventKey == "" {
+			return nil, nil, event.ErrMissingKey
+		}
+	}
+	return g.eventRepo.GetEvents(ctx, eventKeys)
+}
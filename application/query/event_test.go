@@ -0,0 +1,62 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strconv"
+	"testing"
+
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/infrastructure"
+	"github.com/sirupsen/logrus"
+)
+
+func TestGetEvents(t *testing.T) {
+	ctx := context.Background()
+	repo := infrastructure.NewMemoryRepository()
+	for _, key := range []string{"a", "b", "c"} {
+		if err := repo.Save(ctx, newTestEvent(t, "tennis", key, true, 100)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := NewGetEventsHandler(repo, logrus.New())
+	tooMany := make([]string, event.MaxBatchSize+1)
+	for i := range tooMany {
+		tooMany[i] = strconv.Itoa(i)
+	}
+
+	tests := []struct {
+		name        string
+		keys        []string
+		wantFound   []string
+		wantMissing []string
+		wantErr     error
+	}{
+		{name: "in request order", keys: []string{"c", "a"}, wantFound: []string{"c", "a"}, wantMissing: []string{}},
+		{name: "missing keys reported", keys: []string{"x", "b", "y"}, wantFound: []string{"b"}, wantMissing: []string{"x", "y"}},
+		{name: "duplicates looked up once", keys: []string{"a", "x", "a", "x"}, wantFound: []string{"a"}, wantMissing: []string{"x"}},
+		{name: "no keys", keys: []string{}, wantErr: event.ErrInvalidKeyCount},
+		{name: "too many keys", keys: tooMany, wantErr: event.ErrInvalidKeyCount},
+		{name: "empty key", keys: []string{"a", ""}, wantErr: event.ErrMissingKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, missing, err := handler.Handle(ctx, tt.keys)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Handle() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			keys := make([]string, 0, len(found))
+			for _, v := range found {
+				keys = append(keys, v.Key())
+			}
+			if !reflect.DeepEqual(keys, tt.wantFound) || !reflect.DeepEqual(missing, tt.wantMissing) {
+				t.Errorf("Handle() = %v, %v, want %v, %v", keys, missing, tt.wantFound, tt.wantMissing)
+			}
+		})
+	}
+}
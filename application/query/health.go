@@ -0,0 +1,65 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/health"
+)
+
+type dataFreshness interface {
+	LastRefresh() time.Time
+}
+
+type circuit interface {
+	Open(now time.Time) (bool, time.Time)
+}
+
+type GetReadinessHandler struct {
+	freshness dataFreshness
+	circuit   circuit
+	// data older than this is not served as ready
+	maxDataAge time.Duration
+	logger     logger
+}
+
+func NewGetReadinessHandler(freshness dataFreshness, circuit circuit, maxDataAge time.Duration, logger logger) *GetReadinessHandler {
+	return &GetReadinessHandler{
+		freshness:  freshness,
+		circuit:    circuit,
+		maxDataAge: maxDataAge,
+		logger:     logger,
+	}
+}
+
+// Handle check the instance has loaded data, the data is fresh and Cloudbet is reachable
+func (h GetReadinessHandler) Handle(_ context.Context) health.Report {
+	now := time.Now()
+	lastRefresh := h.freshness.LastRefresh()
+
+	loaded := health.NewCheck("data_loaded", !lastRefresh.IsZero(), "no successful crawl or replica bootstrap yet")
+	if loaded.Passed() {
+		loaded = health.NewCheck("data_loaded", true, "data loaded at "+lastRefresh.Format(time.RFC3339))
+	}
+
+	var fresh health.Check
+	age := now.Sub(lastRefresh).Round(time.Second)
+	switch {
+	case lastRefresh.IsZero():
+		fresh = health.NewCheck("data_freshness", false, "no data loaded")
+	case age > h.maxDataAge:
+		fresh = health.NewCheck("data_freshness", false, fmt.Sprintf("data refreshed %s ago, older than %s", age, h.maxDataAge))
+	default:
+		fresh = health.NewCheck("data_freshness", true, fmt.Sprintf("data refreshed %s ago", age))
+	}
+
+	var circuit health.Check
+	if open, since := h.circuit.Open(now); open {
+		circuit = health.NewCheck("cloudbet_circuit", false, "circuit open since "+since.Format(time.RFC3339)+" after consecutive Cloudbet API failures")
+	} else {
+		circuit = health.NewCheck("cloudbet_circuit", true, "circuit closed")
+	}
+
+	return health.NewReport([]health.Check{loaded, fresh, circuit})
+}
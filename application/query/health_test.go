@@ -0,0 +1,61 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/health"
+	"github.com/sirupsen/logrus"
+)
+
+type stubFreshness time.Time
+
+func (s stubFreshness) LastRefresh() time.Time {
+	return time.Time(s)
+}
+
+type stubCircuit bool
+
+func (s stubCircuit) Open(now time.Time) (bool, time.Time) {
+	if s {
+		return true, now.Add(-time.Minute)
+	}
+	return false, time.Time{}
+}
+
+func TestGetReadiness(t *testing.T) {
+	tests := []struct {
+		name        string
+		lastRefresh time.Time
+		open        bool
+		// status of data_loaded, data_freshness and cloudbet_circuit
+		want []string
+	}{
+		{name: "ready", lastRefresh: time.Now().Add(-time.Minute), want: []string{health.StatusPass, health.StatusPass, health.StatusPass}},
+		{name: "nothing loaded", want: []string{health.StatusFail, health.StatusFail, health.StatusPass}},
+		{name: "stale data", lastRefresh: time.Now().Add(-time.Hour), want: []string{health.StatusPass, health.StatusFail, health.StatusPass}},
+		{name: "circuit open", lastRefresh: time.Now(), open: true, want: []string{health.StatusPass, health.StatusPass, health.StatusFail}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := NewGetReadinessHandler(stubFreshness(tt.lastRefresh), stubCircuit(tt.open), 10*time.Minute, logrus.New()).Handle(context.Background())
+			got := make([]string, 0, len(report.Checks()))
+			for _, check := range report.Checks() {
+				got = append(got, check.Status())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("check statuses = %v, want %v", got, tt.want)
+			}
+			ready := true
+			for _, status := range tt.want {
+				ready = ready && status == health.StatusPass
+			}
+			if report.Ready() != ready {
+				t.Errorf("Ready() = %t, want %t", report.Ready(), ready)
+			}
+		})
+	}
+}
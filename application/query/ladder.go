@@ -0,0 +1,28 @@
+package query
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+type GetEventLadderHandler struct {
+	eventRepo event.Repository
+	logger    logger
+}
+
+func NewGetEventLadderHandler(eventRepo event.Repository, logger logger) *GetEventLadderHandler {
+	return &GetEventLadderHandler{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func (g GetEventLadderHandler) Handle(ctx context.Context, eventKey string, marketKey string, submarketKey string) ([]event.Ladder, error) {
+	e, err := g.eventRepo.GetEvent(ctx, eventKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.Ladders(marketKey, submarketKey)
+}
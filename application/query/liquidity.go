@@ -0,0 +1,79 @@
+package query
+
+import (
+	"context"
+	"sort"
+
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+type GetEventLiquidityHandler struct {
+	eventRepo event.Repository
+	logger    logger
+}
+
+func NewGetEventLiquidityHandler(eventRepo event.Repository, logger logger) *GetEventLiquidityHandler {
+	return &GetEventLiquidityHandler{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+func (g GetEventLiquidityHandler) Handle(ctx context.Context, eventKey string) (event.Liquidity, error) {
+	e, err := g.eventRepo.GetEvent(ctx, eventKey)
+	if err != nil {
+		return event.Liquidity{}, err
+	}
+
+	return e.Liquidity(), nil
+}
+
+type RankedLiquidity struct {
+	Event     event.Event
+	Liquidity event.Liquidity
+}
+
+type RankEventsByLiquidityHandler struct {
+	eventRepo event.Repository
+	logger    logger
+}
+
+func NewRankEventsByLiquidityHandler(eventRepo event.Repository, logger logger) *RankEventsByLiquidityHandler {
+	return &RankEventsByLiquidityHandler{
+		eventRepo: eventRepo,
+		logger:    logger,
+	}
+}
+
+// Handle rank active events of a sport by the total max stake they offer, largest first
+func (r RankEventsByLiquidityHandler) Handle(ctx context.Context, first int, page int, sportKey string) ([]RankedLiquidity, error) {
+	events, err := r.eventRepo.ListActiveEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]RankedLiquidity, 0, len(events))
+	for _, e := range events {
+		if sportKey != "" && e.Sport().Key() != sportKey {
+			continue
+		}
+		result = append(result, RankedLiquidity{
+			Event:     e,
+			Liquidity: e.Liquidity(),
+		})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Liquidity.TotalMaxStake() == result[j].Liquidity.TotalMaxStake() {
+			return result[i].Event.Key() < result[j].Event.Key()
+		}
+		return result[i].Liquidity.TotalMaxStake() > result[j].Liquidity.TotalMaxStake()
+	})
+
+	if len(result) <= (page-1)*first {
+		return []RankedLiquidity{}, nil
+	}
+	if len(result) <= (page)*first {
+		return result[(page-1)*first:], nil
+	}
+	return result[(page-1)*first : (page)*first], nil
+}
@@ -0,0 +1,76 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/infrastructure"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestEvent(t *testing.T, sportKey string, key string, active bool, maxStake float64) event.Event {
+	t.Helper()
+	sport, _ := event.NewIdentifier(sportKey, sportKey)
+	category, _ := event.NewIdentifier("International", "international")
+	competition, _ := event.NewIdentifier(sportKey+" cup", sportKey+"-international-cup")
+	markets := map[string]event.Market{
+		sportKey + ".winner": event.NewMarket(map[string][]event.Selection{"period=ft": {
+			event.NewSelection("home", "", 1.9, maxStake, 0.5, "SELECTION_ENABLED", "BACK"),
+		}}),
+	}
+	e, err := event.NewEvent(&sport, &competition, &category, event.TeamIdentifier{}, event.TeamIdentifier{}, active, false, markets, key, key, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *e
+}
+
+func TestRankEventsByLiquidity(t *testing.T) {
+	ctx := context.Background()
+	repo := infrastructure.NewMemoryRepository()
+	for _, e := range []event.Event{
+		newTestEvent(t, "tennis", "small", true, 50),
+		newTestEvent(t, "tennis", "large", true, 500),
+		newTestEvent(t, "tennis", "tied-b", true, 100),
+		newTestEvent(t, "tennis", "tied-a", true, 100),
+		newTestEvent(t, "tennis", "inactive", false, 1000),
+		newTestEvent(t, "soccer", "other-sport", true, 800),
+	} {
+		if err := repo.Save(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	handler := NewRankEventsByLiquidityHandler(repo, logrus.New())
+
+	tests := []struct {
+		name     string
+		first    int
+		page     int
+		sportKey string
+		want     []string
+	}{
+		{name: "every sport", first: 10, page: 1, want: []string{"other-sport", "large", "tied-a", "tied-b", "small"}},
+		{name: "one sport", first: 10, page: 1, sportKey: "tennis", want: []string{"large", "tied-a", "tied-b", "small"}},
+		{name: "second page", first: 2, page: 2, sportKey: "tennis", want: []string{"tied-b", "small"}},
+		{name: "past the last page", first: 2, page: 3, sportKey: "tennis", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked, err := handler.Handle(ctx, tt.first, tt.page, tt.sportKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]string, 0, len(ranked))
+			for _, v := range ranked {
+				got = append(got, v.Event.Key())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Handle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package query
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/domain/replication"
+)
+
+type GetReplicationStatusHandler struct {
+	replicationRepo replication.Repository
+	logger          logger
+}
+
+func NewGetReplicationStatusHandler(replicationRepo replication.Repository, logger logger) *GetReplicationStatusHandler {
+	return &GetReplicationStatusHandler{
+		replicationRepo: replicationRepo,
+		logger:          logger,
+	}
+}
+
+func (g GetReplicationStatusHandler) Handle(ctx context.Context) (replication.Status, error) {
+	return g.replicationRepo.GetStatus(ctx)
+}
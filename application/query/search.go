@@ -0,0 +1,36 @@
+package query
+
+import (
+	"context"
+	"strings"
+
+	"github.com/awcjack/cloudbet/domain/search"
+)
+
+type SearchHandler struct {
+	searchRepo search.Repository
+	logger     logger
+}
+
+func NewSearchHandler(searchRepo search.Repository, logger logger) *SearchHandler {
+	return &SearchHandler{
+		searchRepo: searchRepo,
+		logger:     logger,
+	}
+}
+
+func (s SearchHandler) Handle(ctx context.Context, query string, kinds []string, limit int) ([]search.Result, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, search.ErrEmptyQuery
+	}
+	if limit <= 0 || limit > 50 {
+		return nil, search.ErrInvalidLimit
+	}
+	for _, kind := range kinds {
+		if !search.ValidKind(kind) {
+			return nil, search.ErrInvalidKind
+		}
+	}
+
+	return s.searchRepo.Search(ctx, query, kinds, limit)
+}
@@ -3,6 +3,7 @@ package query
 import (
 	"context"
 
+	"github.com/awcjack/cloudbet/domain/pagination"
 	"github.com/awcjack/cloudbet/domain/sport"
 )
 
@@ -18,8 +19,8 @@ func NewListSportHandler(sportRepo sport.Repository, logger logger) *ListSportsH
 	}
 }
 
-func (l ListSportsHandler) Handle(ctx context.Context, first int, page int) ([]sport.Sport, error) {
-	return l.sportRepo.ListSports(ctx, first, page)
+func (l ListSportsHandler) Handle(ctx context.Context, request pagination.Request) ([]sport.Sport, pagination.Info, error) {
+	return l.sportRepo.ListSports(ctx, request)
 }
 
 type GetSportHandler struct {
@@ -0,0 +1,40 @@
+package query
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/domain/pagination"
+	"github.com/awcjack/cloudbet/domain/team"
+)
+
+type ListTeamsHandler struct {
+	teamRepo team.Repository
+	logger   logger
+}
+
+func NewListTeamsHandler(teamRepo team.Repository, logger logger) *ListTeamsHandler {
+	return &ListTeamsHandler{
+		teamRepo: teamRepo,
+		logger:   logger,
+	}
+}
+
+func (l ListTeamsHandler) Handle(ctx context.Context, request pagination.Request, nationality string) ([]team.Team, pagination.Info, error) {
+	return l.teamRepo.ListTeams(ctx, request, nationality)
+}
+
+type GetTeamHandler struct {
+	teamRepo team.Repository
+	logger   logger
+}
+
+func NewGetTeamHandler(teamRepo team.Repository, logger logger) *GetTeamHandler {
+	return &GetTeamHandler{
+		teamRepo: teamRepo,
+		logger:   logger,
+	}
+}
+
+func (g GetTeamHandler) Handle(ctx context.Context, teamKey string) (team.Team, error) {
+	return g.teamRepo.GetTeam(ctx, teamKey)
+}
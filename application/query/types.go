@@ -1,9 +1,8 @@
 package query
 
+import "github.com/sirupsen/logrus"
+
+// logger is the structured logger of the handlers, logging.FromContext add the fields of the request to it
 type logger interface {
-	Panicf(format string, v ...interface{})
-	Errorf(format string, v ...interface{})
-	Warningf(format string, v ...interface{})
-	Infof(format string, v ...interface{})
-	Debugf(format string, v ...interface{})
+	logrus.FieldLogger
 }
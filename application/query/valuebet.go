@@ -0,0 +1,69 @@
+package query
+
+import (
+	"context"
+	"sort"
+
+	"github.com/awcjack/cloudbet/application/logging"
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/valuebet"
+)
+
+type ListValueBetsHandler struct {
+	eventRepo    event.Repository
+	valuebetRepo valuebet.Repository
+	logger       logger
+}
+
+func NewListValueBetsHandler(eventRepo event.Repository, valuebetRepo valuebet.Repository, logger logger) *ListValueBetsHandler {
+	return &ListValueBetsHandler{
+		eventRepo:    eventRepo,
+		valuebetRepo: valuebetRepo,
+		logger:       logger,
+	}
+}
+
+func (l ListValueBetsHandler) Handle(ctx context.Context, first int, page int, minEdge float64, bankroll float64) ([]valuebet.ValueBet, error) {
+	if minEdge < 0 {
+		return nil, valuebet.ErrInvalidEdge
+	}
+	if bankroll < 0 {
+		return nil, valuebet.ErrInvalidBankroll
+	}
+
+	probabilities, err := l.valuebetRepo.ListProbabilities(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	eventsProbabilities := make(map[string][]valuebet.Probability)
+	for _, probability := range probabilities {
+		eventsProbabilities[probability.EventKey()] = append(eventsProbabilities[probability.EventKey()], probability)
+	}
+
+	result := make([]valuebet.ValueBet, 0)
+	for eventKey, eventProbabilities := range eventsProbabilities {
+		e, err := l.eventRepo.GetEvent(ctx, eventKey)
+		if err != nil {
+			if logging.EventSampled("skip probabilities") {
+				logging.FromContext(ctx, l.logger).WithField(logging.EventField, eventKey).WithError(err).Debugf("Skip model probabilities")
+			}
+			continue
+		}
+		result = append(result, valuebet.Find(e, eventProbabilities, minEdge, bankroll)...)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Edge() == result[j].Edge() {
+			return result[i].EventKey() < result[j].EventKey()
+		}
+		return result[i].Edge() > result[j].Edge()
+	})
+
+	if len(result) <= (page-1)*first {
+		return []valuebet.ValueBet{}, nil
+	}
+	if len(result) <= (page)*first {
+		return result[(page-1)*first:], nil
+	}
+	return result[(page-1)*first : (page)*first], nil
+}
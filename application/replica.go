@@ -0,0 +1,351 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/awcjack/cloudbet/application/logging"
+	"github.com/awcjack/cloudbet/domain/change"
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/replication"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// number of changes requested from the peer at once, the largest limit the change feed accepts
+const replicaPageSize = change.MaxLimit
+
+var (
+	ErrPeerSequenceExpired = errors.New("peer compacted changes since the replicated sequence")
+	ErrPeerRestarted       = errors.New("peer restarted its change log")
+)
+
+type PeerChangeList struct {
+	// changes in sequence order
+	Changes []PeerChange `json:"changes"`
+	// latest sequence of the peer when the changes were listed
+	LatestSequence uint64 `json:"latestSequence"`
+	// ID of the peer change log, changed when the peer restarts
+	Epoch string `json:"epoch"`
+}
+
+type PeerChange struct {
+	// global sequence of the change on the peer
+	Sequence uint64 `json:"sequence"`
+	// created, updated, inactivated or deleted
+	Type string `json:"type"`
+	// slug of the changed event
+	EventKey string `json:"eventKey"`
+	// time the change happened on the peer in RFC3339
+	Time string `json:"time"`
+	// current state of the event, nil for deleted
+	Event *PeerEvent `json:"event"`
+}
+
+type PeerEvent struct {
+	// slug for this Event
+	Key string `json:"key"`
+	// name of this Event
+	Name string `json:"name"`
+	// sport associated with this Event
+	Sport *Identifier `json:"sport"`
+	// competition associated with this Event
+	Competition *Identifier `json:"competition"`
+	// category associated with this Event
+	Category *Identifier `json:"category"`
+	// home team competitor of this Event
+	Home *TeamIdentifier `json:"home"`
+	// away team competitor of this Event
+	Away *TeamIdentifier `json:"away"`
+	// the event trading still active or not
+	Active bool `json:"active"`
+	// the event is in TRADING_LIVE status
+	Live bool `json:"live"`
+	// mapping between market key and all associated markets for this Event
+	Market map[string]PeerMarket `json:"market"`
+	// RFC3339 times, empty when not set
+	CutOffTime           string `json:"cutOffTime"`
+	StartTradingLiveTime string `json:"startTradingLiveTime"`
+	InactiveTime         string `json:"inactiveTime"`
+}
+
+type PeerMarket struct {
+	// mapping between submarket key and its selections
+	Submarkets map[string][]Selection `json:"submarkets"`
+}
+
+// Replica follow the change feed of a peer instance into the local repository instead of crawling Cloudbet
+type Replica struct {
+	eventRepo       event.Repository
+	replicationRepo replication.Repository
+	logger          logger
+	// base URL of the peer, e.g. http://primary:8080
+	peer string
+	// secret of an API key granted the stream scope on the peer
+	apiKey string
+	client *http.Client
+	// last applied peer sequence, 0 until bootstrapped
+	sequence uint64
+	// epoch of the peer change log the sequence belongs to
+	epoch        string
+	bootstrapped bool
+	lastChange   time.Time
+	resyncs      int
+}
+
+func NewReplica(eventRepo event.Repository, replicationRepo replication.Repository, logger logger, peer string, apiKey string) *Replica {
+	return &Replica{
+		eventRepo:       eventRepo,
+		replicationRepo: replicationRepo,
+		logger:          logger,
+		peer:            peer,
+		apiKey:          apiKey,
+		client:          &http.Client{},
+	}
+}
+
+// Sync apply the peer changes since the last sync, bootstrapping from a full snapshot first and again whenever the
+// replicated sequence cannot be continued, i.e. the peer compacted it away or restarted with a fresh change log.
+// A change failing to apply stops the sync, so it is retried by the next one.
+func (r *Replica) Sync(ctx context.Context) error {
+	if !r.bootstrapped {
+		return r.resync(ctx)
+	}
+
+	peerSequence, err := r.pull(ctx, nil)
+	if errors.Is(err, ErrPeerSequenceExpired) {
+		r.logger.Warningf("Replicated sequence %d expired on peer, resync", r.sequence)
+		return r.resync(ctx)
+	}
+	if errors.Is(err, ErrPeerRestarted) {
+		r.logger.Warningf("Peer change log epoch changed from %s, peer restarted, resync", r.epoch)
+		return r.resync(ctx)
+	}
+	if err != nil {
+		r.logger.WithError(err).Errorf("Follow peer change feed error")
+		return err
+	}
+	if peerSequence < r.sequence {
+		r.logger.Warningf("Peer sequence %d behind replicated sequence %d, peer restarted, resync", peerSequence, r.sequence)
+		return r.resync(ctx)
+	}
+
+	return r.saveStatus(ctx, peerSequence)
+}
+
+// resync rebuild the local events from the peer snapshot, deleting local events the peer no longer has
+func (r *Replica) resync(ctx context.Context) error {
+	r.sequence = 0
+	r.epoch = ""
+	r.bootstrapped = false
+	r.resyncs++
+
+	seen := make(map[string]bool)
+	peerSequence, err := r.pull(ctx, seen)
+	if err != nil {
+		r.logger.WithError(err).Errorf("Bootstrap from peer error")
+		return err
+	}
+
+	active, err := r.eventRepo.ListActiveEvents(ctx)
+	if err != nil {
+		return err
+	}
+	inactive, err := r.eventRepo.ListInactiveEvents(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+	deleted := 0
+	for _, e := range append(active, inactive...) {
+		if seen[e.Key()] {
+			continue
+		}
+		if err := r.eventRepo.DeleteEvent(ctx, e.Key()); err != nil && !errors.Is(err, event.ErrEventNotFound) {
+			r.logger.WithField(logging.EventField, e.Key()).WithError(err).Errorf("Delete stale event error")
+			continue
+		}
+		deleted++
+	}
+
+	r.bootstrapped = true
+	r.logger.Infof("Bootstrapped %d events from peer %s at sequence %d, deleted %d stale events", len(seen), r.peer, r.sequence, deleted)
+	return r.saveStatus(ctx, peerSequence)
+}
+
+// pull page through the peer change feed from the replicated sequence, recording applied event keys in seen if not nil
+func (r *Replica) pull(ctx context.Context, seen map[string]bool) (uint64, error) {
+	for {
+		changes, err := r.fetchChanges(ctx, r.sequence)
+		if err != nil {
+			return 0, err
+		}
+		// sequences of another epoch are unrelated to the replicated one, even when the peer already went past it
+		if r.epoch == "" {
+			r.epoch = changes.Epoch
+		} else if changes.Epoch != r.epoch {
+			return 0, ErrPeerRestarted
+		}
+		if r.bootstrapped && changes.LatestSequence < r.sequence {
+			return changes.LatestSequence, nil
+		}
+
+		for _, c := range changes.Changes {
+			// stay before the change, skipping it would lose it for good
+			if err := r.apply(ctx, c); err != nil {
+				return 0, fmt.Errorf("apply change %d of %s: %w", c.Sequence, c.EventKey, err)
+			}
+			if seen != nil && c.Type != change.TypeDeleted {
+				seen[c.EventKey] = true
+			}
+			r.sequence = c.Sequence
+			if t, err := time.Parse(time.RFC3339, c.Time); err == nil {
+				r.lastChange = t
+			}
+		}
+
+		if len(changes.Changes) < replicaPageSize {
+			// the listing is consistent, so nothing is left up to the latest sequence
+			if changes.LatestSequence > r.sequence {
+				r.sequence = changes.LatestSequence
+			}
+			return changes.LatestSequence, nil
+		}
+	}
+}
+
+func (r *Replica) apply(ctx context.Context, c PeerChange) error {
+	if c.Type == change.TypeDeleted {
+		err := r.eventRepo.DeleteEvent(ctx, c.EventKey)
+		if errors.Is(err, event.ErrEventNotFound) {
+			return nil
+		}
+		return err
+	}
+	if c.Event == nil {
+		return fmt.Errorf("missing event of %s change", c.Type)
+	}
+
+	e, err := c.Event.toEvent()
+	if err != nil {
+		return err
+	}
+	return r.eventRepo.Save(ctx, *e)
+}
+
+func (r *Replica) saveStatus(ctx context.Context, peerSequence uint64) error {
+	status := replication.NewStatus(r.peer, r.sequence, peerSequence, time.Now(), r.lastChange, r.resyncs)
+	replicaLag.Set(float64(status.Lag()))
+	freshness.mark(status.LastSync())
+	if status.Lag() > 0 {
+		r.logger.Warningf("Replica lagging %d changes behind peer", status.Lag())
+	}
+	return r.replicationRepo.SaveStatus(ctx, status)
+}
+
+func (r *Replica) fetchChanges(ctx context.Context, since uint64) (*PeerChangeList, error) {
+	query := url.Values{}
+	query.Set("since", strconv.FormatUint(since, 10))
+	query.Set("limit", strconv.Itoa(replicaPageSize))
+	ctx, span := tracer.Start(ctx, "replica pull", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+	req, err := http.NewRequestWithContext(ctx, "GET", r.peer+"/changes?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-API-Key", r.apiKey)
+	// continue the trace on the peer
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+	span.SetAttributes(semconv.HTTPClientAttributesFromHTTPRequest(req)...)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		failSpan(span, err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(resp.StatusCode)...)
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(resp.StatusCode, trace.SpanKindClient))
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var response PeerChangeList
+		err = json.Unmarshal(body, &response)
+		if err != nil {
+			return nil, err
+		}
+		return &response, nil
+	case http.StatusGone:
+		return nil, ErrPeerSequenceExpired
+	default:
+		return nil, fmt.Errorf("[%s] %s", resp.Status, body)
+	}
+}
+
+func (p PeerEvent) toEvent() (*event.Event, error) {
+	if p.Sport == nil || p.Competition == nil || p.Category == nil {
+		return nil, fmt.Errorf("%s: incomplete event", p.Key)
+	}
+	sportIdentity, err := event.NewIdentifier(p.Sport.Name, p.Sport.Key)
+	if err != nil {
+		return nil, err
+	}
+	competitionIdentity, err := event.NewIdentifier(p.Competition.Name, p.Competition.Key)
+	if err != nil {
+		return nil, err
+	}
+	categoryIdentity, err := event.NewIdentifier(p.Category.Name, p.Category.Key)
+	if err != nil {
+		return nil, err
+	}
+	var homeIdentity, awayIdentity event.TeamIdentifier
+	if p.Home != nil {
+		homeIdentity = event.NewTeamIdentifier(p.Home.Name, p.Home.Key, p.Home.Abbreviation, p.Home.Nationality)
+	}
+	if p.Away != nil {
+		awayIdentity = event.NewTeamIdentifier(p.Away.Name, p.Away.Key, p.Away.Abbreviation, p.Away.Nationality)
+	}
+
+	marketValue := make(map[string]event.Market, len(p.Market))
+	for marketKey, market := range p.Market {
+		submarkets := make(map[string][]event.Selection, len(market.Submarkets))
+		for submarketKey, selections := range market.Submarkets {
+			submarkets[submarketKey] = make([]event.Selection, len(selections))
+			for i, selection := range selections {
+				submarkets[submarketKey][i] = event.NewSelection(selection.Outcome, selection.Params, selection.Price, selection.MaxStake, selection.Probability, selection.Status, selection.Side)
+			}
+		}
+		marketValue[marketKey] = event.NewMarket(submarkets)
+	}
+
+	cutOffTime, err := time.Parse(time.RFC3339, p.CutOffTime)
+	if err != nil {
+		return nil, err
+	}
+
+	e, err := event.NewEvent(&sportIdentity, &competitionIdentity, &categoryIdentity, homeIdentity, awayIdentity, p.Active, p.Live, marketValue, p.Name, p.Key, cutOffTime)
+	if err != nil {
+		return nil, err
+	}
+	// keep the peer's times rather than the time of replication
+	if startTradingLiveTime, err := time.Parse(time.RFC3339, p.StartTradingLiveTime); err == nil {
+		e.SetstartTradingLiveTime(startTradingLiveTime)
+	}
+	if inactiveTime, err := time.Parse(time.RFC3339, p.InactiveTime); err == nil {
+		e.SetInactiveTime(inactiveTime)
+	}
+	return e, nil
+}
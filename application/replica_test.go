@@ -0,0 +1,299 @@
+package application_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/application"
+	"github.com/awcjack/cloudbet/domain/apikey"
+	"github.com/awcjack/cloudbet/domain/change"
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/infrastructure"
+	"github.com/awcjack/cloudbet/interfaces"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+func newReplicatedEvent(t *testing.T, key string, active bool, live bool, selections []event.Selection) event.Event {
+	t.Helper()
+	sport, _ := event.NewIdentifier("Soccer", "soccer")
+	category, _ := event.NewIdentifier("England", "england")
+	competition, _ := event.NewIdentifier("Premier League", "soccer-england-premier-league")
+	home := event.NewTeamIdentifier("Arsenal", "c1", "ARS", "ENG")
+	away := event.NewTeamIdentifier("Chelsea", "c2", "CHE", "ENG")
+	markets := map[string]event.Market{
+		"soccer.match_odds": event.NewMarket(map[string][]event.Selection{"period=ft": selections}),
+	}
+	e, err := event.NewEvent(&sport, &competition, &category, home, away, active, live, markets, key, key, time.Date(2022, 6, 1, 19, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *e
+}
+
+// newPeer serve repo the way a primary does, with a stream key for the replica
+func newPeer(t *testing.T, repo *infrastructure.MemoryRepository, logger *logrus.Logger) *httptest.Server {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	apiKeyRepo := infrastructure.NewApiKeyMemoryRepository()
+	key, err := apikey.NewKey("replica", "replica", apikey.Hash("replica-secret"), []string{apikey.ScopeStream}, 0, 0, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := apiKeyRepo.SaveKey(context.Background(), key); err != nil {
+		t.Fatal(err)
+	}
+	arbitrageRepo := infrastructure.NewArbitrageMemoryRepository()
+	crawlRunRepo := infrastructure.NewCrawlRunMemoryRepository(10)
+	scheduler := application.NewCrawlScheduler(application.NewCloudbetHander(repo, logger, "test"), application.NewArbitrageScanner(repo, arbitrageRepo, logger), crawlRunRepo, logger, time.Hour, time.Hour)
+	app := application.NewApplication(repo, repo, repo, repo, repo, arbitrageRepo, repo, repo, repo, infrastructure.NewReplicationMemoryRepository(), apiKeyRepo, crawlRunRepo, scheduler, time.Minute, logger)
+	srv := httptest.NewServer(interfaces.NewHandler(*interfaces.NewHttpServer(*app, logger)))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+// TestReplicaRoundTrip replicate events through the primary change feed and compare them with the primary ones
+func TestReplicaRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+	primary := infrastructure.NewMemoryRepository()
+	srv := newPeer(t, primary, logger)
+	local := infrastructure.NewMemoryRepository()
+	replica := application.NewReplica(local, infrastructure.NewReplicationMemoryRepository(), logger, srv.URL, "replica-secret")
+
+	selections := []event.Selection{
+		event.NewSelection("home", "", 2.1, 500, 0.45, "SELECTION_ENABLED", "BACK"),
+		event.NewSelection("draw", "", 3.4, 200, 0.28, "SELECTION_DISABLED", "BACK"),
+		event.NewSelection("away", "", 3.9, 0, 0.25, "", ""),
+		event.NewSelection("home", "", 2.2, 100, 0.45, "SELECTION_ENABLED", "LAY"),
+	}
+	startTradingLiveTime := time.Date(2022, 6, 1, 19, 0, 5, 0, time.UTC)
+	inactiveTime := time.Date(2022, 6, 1, 21, 0, 0, 0, time.UTC)
+
+	live := newReplicatedEvent(t, "arsenal-v-chelsea", true, true, selections)
+	live.SetstartTradingLiveTime(startTradingLiveTime)
+	ended := newReplicatedEvent(t, "chelsea-v-arsenal", true, false, selections)
+	for _, e := range []event.Event{live, ended} {
+		if err := primary.Save(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := replica.Sync(ctx); err != nil {
+		t.Fatalf("bootstrap Sync() error = %v", err)
+	}
+
+	// the ended event goes inactive after the replica bootstrapped, so it follows the change
+	ended = newReplicatedEvent(t, "chelsea-v-arsenal", false, false, selections)
+	ended.SetInactiveTime(inactiveTime)
+	if err := primary.Save(ctx, ended); err != nil {
+		t.Fatal(err)
+	}
+	if err := replica.Sync(ctx); err != nil {
+		t.Fatalf("follow Sync() error = %v", err)
+	}
+
+	for _, key := range []string{"arsenal-v-chelsea", "chelsea-v-arsenal"} {
+		want, err := primary.GetEvent(ctx, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := local.GetEvent(ctx, key)
+		if err != nil {
+			t.Fatalf("replicated GetEvent(%s) error = %v", key, err)
+		}
+		if !got.SameContent(want) {
+			t.Errorf("%s: replicated %+v, want %+v", key, got, want)
+		}
+		if !got.StartTradingLiveTime().Equal(want.StartTradingLiveTime()) || !got.InactiveTime().Equal(want.InactiveTime()) {
+			t.Errorf("%s: replicated live since %s inactive since %s, want %s and %s", key, got.StartTradingLiveTime(), got.InactiveTime(), want.StartTradingLiveTime(), want.InactiveTime())
+		}
+	}
+	if got, _ := local.GetEvent(ctx, "chelsea-v-arsenal"); !got.InactiveTime().Equal(inactiveTime) {
+		t.Errorf("replicated inactive time = %s, want the primary's %s", got.InactiveTime(), inactiveTime)
+	}
+}
+
+// peerResponse is one scripted answer of a fake peer change feed, a Gone status when list is nil
+type peerResponse struct {
+	since uint64
+	list  *application.PeerChangeList
+}
+
+func peerChanges(epoch string, latest uint64, changes ...application.PeerChange) *application.PeerChangeList {
+	return &application.PeerChangeList{Changes: changes, LatestSequence: latest, Epoch: epoch}
+}
+
+func savedChange(sequence uint64, key string) application.PeerChange {
+	identifier := &application.Identifier{Name: "Soccer", Key: "soccer"}
+	return application.PeerChange{
+		Sequence: sequence,
+		Type:     change.TypeCreated,
+		EventKey: key,
+		Time:     "2022-06-01T12:00:00Z",
+		Event:    &application.PeerEvent{Key: key, Name: key, Sport: identifier, Competition: identifier, Category: identifier, Active: true, CutOffTime: "2022-06-01T19:00:00Z"},
+	}
+}
+
+func deletedChange(sequence uint64, key string) application.PeerChange {
+	return application.PeerChange{Sequence: sequence, Type: change.TypeDeleted, EventKey: key, Time: "2022-06-01T12:00:00Z"}
+}
+
+// brokenChange carry no event, so applying it fails
+func brokenChange(sequence uint64, key string) application.PeerChange {
+	return application.PeerChange{Sequence: sequence, Type: change.TypeUpdated, EventKey: key, Time: "2022-06-01T12:00:00Z"}
+}
+
+func TestReplicaSync(t *testing.T) {
+	tests := []struct {
+		name string
+		// answers of the peer in request order, each checked against the requested since
+		responses   []peerResponse
+		syncs       int
+		wantErr     bool
+		wantKeys    []string
+		wantResyncs int
+		wantSeq     uint64
+	}{
+		{
+			name: "follow",
+			responses: []peerResponse{
+				{0, peerChanges("a", 2, savedChange(1, "x"), savedChange(2, "y"))},
+				{2, peerChanges("a", 4, deletedChange(3, "x"), savedChange(4, "z"))},
+			},
+			syncs:       2,
+			wantKeys:    []string{"y", "z"},
+			wantResyncs: 1,
+			wantSeq:     4,
+		},
+		{
+			name: "peer restarted",
+			responses: []peerResponse{
+				{0, peerChanges("a", 2, savedChange(1, "x"), savedChange(2, "y"))},
+				// a new epoch further ahead than the replicated sequence
+				{2, peerChanges("b", 5, savedChange(5, "y"))},
+				{0, peerChanges("b", 5, savedChange(5, "y"))},
+			},
+			syncs:       2,
+			wantKeys:    []string{"y"},
+			wantResyncs: 2,
+			wantSeq:     5,
+		},
+		{
+			name: "peer behind on the same epoch",
+			responses: []peerResponse{
+				{0, peerChanges("a", 2, savedChange(1, "x"), savedChange(2, "y"))},
+				{2, peerChanges("a", 1)},
+				{0, peerChanges("a", 1, savedChange(1, "x"))},
+			},
+			syncs:       2,
+			wantKeys:    []string{"x"},
+			wantResyncs: 2,
+			wantSeq:     1,
+		},
+		{
+			name: "sequence expired",
+			responses: []peerResponse{
+				{0, peerChanges("a", 2, savedChange(1, "x"), savedChange(2, "y"))},
+				{2, nil},
+				{0, peerChanges("a", 9, savedChange(9, "z"))},
+			},
+			syncs:       2,
+			wantKeys:    []string{"z"},
+			wantResyncs: 2,
+			wantSeq:     9,
+		},
+		{
+			name: "broken change stops the sync",
+			responses: []peerResponse{
+				{0, peerChanges("a", 1, savedChange(1, "x"))},
+				{1, peerChanges("a", 3, brokenChange(2, "x"), savedChange(3, "y"))},
+			},
+			syncs:       2,
+			wantErr:     true,
+			wantKeys:    []string{"x"},
+			wantResyncs: 1,
+			wantSeq:     1,
+		},
+		{
+			name: "broken change retried",
+			responses: []peerResponse{
+				{0, peerChanges("a", 1, savedChange(1, "x"))},
+				{1, peerChanges("a", 3, brokenChange(2, "x"), savedChange(3, "y"))},
+				{1, peerChanges("a", 3, savedChange(3, "y"))},
+			},
+			syncs:       3,
+			wantKeys:    []string{"x", "y"},
+			wantResyncs: 1,
+			wantSeq:     3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			requests := 0
+			peer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if requests >= len(tt.responses) {
+					t.Errorf("unexpected request %s", r.URL)
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				response := tt.responses[requests]
+				requests++
+				if since := r.URL.Query().Get("since"); since != strconv.FormatUint(response.since, 10) {
+					t.Errorf("request %d since %s, want %d", requests, since, response.since)
+				}
+				if response.list == nil {
+					w.WriteHeader(http.StatusGone)
+					return
+				}
+				_ = json.NewEncoder(w).Encode(response.list)
+			}))
+			defer peer.Close()
+
+			local := infrastructure.NewMemoryRepository()
+			replicationRepo := infrastructure.NewReplicationMemoryRepository()
+			replica := application.NewReplica(local, replicationRepo, logger, peer.URL, "replica-secret")
+			var err error
+			for i := 0; i < tt.syncs; i++ {
+				err = replica.Sync(ctx)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Sync() error = %v, want error %t", err, tt.wantErr)
+			}
+			if requests != len(tt.responses) {
+				t.Errorf("%d requests, want %d", requests, len(tt.responses))
+			}
+
+			active, err := local.ListActiveEvents(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			keys := make([]string, 0, len(active))
+			for _, e := range active {
+				keys = append(keys, e.Key())
+			}
+			sort.Strings(keys)
+			if !reflect.DeepEqual(keys, tt.wantKeys) {
+				t.Errorf("replicated %v, want %v", keys, tt.wantKeys)
+			}
+			status, err := replicationRepo.GetStatus(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if status.Resyncs() != tt.wantResyncs || status.Sequence() != tt.wantSeq {
+				t.Errorf("status %d resyncs at sequence %d, want %d at %d", status.Resyncs(), status.Sequence(), tt.wantResyncs, tt.wantSeq)
+			}
+		})
+	}
+}
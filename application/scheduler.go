@@ -0,0 +1,318 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/awcjack/cloudbet/application/logging"
+	"github.com/awcjack/cloudbet/domain/crawl"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// CrawlScheduler run the Cloudbet crawl and the cutoff checks on their intervals, and let admins trigger, pause and
+// reschedule them at runtime. Arbitrage opportunities are scanned again after every successful crawl.
+type CrawlScheduler struct {
+	crawler CloudbetHandler
+	scanner ArbitrageScanner
+	runRepo crawl.Repository
+	logger  logger
+	lock    *sync.Mutex
+	// set while Run is looping, triggers are rejected otherwise
+	started        bool
+	paused         bool
+	interval       time.Duration
+	cutOffInterval time.Duration
+	nextRun        time.Time
+	nextCutOff     time.Time
+	// crawl requested by an admin, waiting for the loop to pick it up
+	pending *crawl.Run
+	current *crawl.Run
+	lastID  uint64
+	// wake the loop up when a crawl is requested or the schedule changed
+	wake chan struct{}
+}
+
+func NewCrawlScheduler(crawler CloudbetHandler, scanner ArbitrageScanner, runRepo crawl.Repository, logger logger, interval time.Duration, cutOffInterval time.Duration) *CrawlScheduler {
+	return &CrawlScheduler{
+		crawler:        crawler,
+		scanner:        scanner,
+		runRepo:        runRepo,
+		logger:         logger,
+		lock:           &sync.Mutex{},
+		interval:       interval,
+		cutOffInterval: cutOffInterval,
+		wake:           make(chan struct{}, 1),
+	}
+}
+
+// Run crawl right away then on schedule until ctx is done
+func (s *CrawlScheduler) Run(ctx context.Context) {
+	// continue the IDs of the stored runs
+	latest, err := s.runRepo.ListRuns(ctx, crawl.Filter{}, 1)
+	if err != nil {
+		s.logger.WithError(err).Errorf("List crawl runs error")
+	}
+
+	s.lock.Lock()
+	if len(latest) > 0 && latest[0].ID() > s.lastID {
+		s.lastID = latest[0].ID()
+	}
+	s.started = true
+	s.nextRun = time.Now()
+	s.nextCutOff = time.Now().Add(s.cutOffInterval)
+	s.lock.Unlock()
+	defer func() {
+		s.lock.Lock()
+		s.started = false
+		s.lock.Unlock()
+	}()
+
+	for ctx.Err() == nil {
+		run, checkCutOff, wait := s.next(time.Now())
+		if run != nil {
+			s.crawl(ctx, *run)
+			continue
+		}
+		if checkCutOff {
+			s.crawler.CheckEventsCloseToCutOff(ctx)
+			continue
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-s.wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+// next return the crawl to run now, else whether to check cutoffs now, else how long to wait
+func (s *CrawlScheduler) next(now time.Time) (*crawl.Run, bool, time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.pending != nil {
+		run := s.pending
+		s.pending = nil
+		return run, false, 0
+	}
+	if s.paused {
+		// woken up on resume
+		return nil, false, crawl.MaxInterval
+	}
+	if !now.Before(s.nextRun) {
+		s.nextRun = now.Add(s.interval)
+		s.lastID++
+		run := crawl.NewRun(s.lastID, crawl.KindFull, "", crawl.TriggerScheduled, now)
+		return &run, false, 0
+	}
+	if !now.Before(s.nextCutOff) {
+		s.nextCutOff = now.Add(s.cutOffInterval)
+		return nil, true, 0
+	}
+
+	wait := s.nextRun.Sub(now)
+	if s.nextCutOff.Sub(now) < wait {
+		wait = s.nextCutOff.Sub(now)
+	}
+	return nil, false, wait
+}
+
+func (s *CrawlScheduler) crawl(ctx context.Context, run crawl.Run) {
+	s.lock.Lock()
+	s.current = &run
+	s.lock.Unlock()
+
+	ctx = logging.WithField(ctx, logging.CrawlRunIDField, run.ID())
+	ctx, span := tracer.Start(ctx, "crawl "+run.Kind(), trace.WithAttributes(crawlIDKey.Int64(int64(run.ID())), crawlKindKey.String(run.Kind()), crawlTriggerKey.String(run.Trigger())))
+	defer span.End()
+	if run.CompetitionKey() != "" {
+		span.SetAttributes(competitionKeyKey.String(run.CompetitionKey()))
+	}
+
+	var err error
+	if run.Kind() == crawl.KindCompetition {
+		err = s.crawler.StoreCompetitionEvents(ctx, run.CompetitionKey(), s)
+	} else {
+		err = s.crawler.StoreAllEvents(ctx, s)
+	}
+
+	s.lock.Lock()
+	s.current.Finish(time.Now())
+	run = *s.current
+	s.lock.Unlock()
+
+	crawlDuration.WithLabelValues(run.Kind()).Observe(run.FinishedAt().Sub(run.StartedAt()).Seconds())
+	span.SetAttributes(
+		attribute.Int("crawl.competitions", run.CompetitionsDone()),
+		attribute.Int("crawl.events", run.EventsFetched()),
+		attribute.Int("crawl.errors", run.ErrorCount()),
+	)
+	if err != nil {
+		failSpan(span, err)
+	}
+	// a crawl failing every competition leaves the data as old as it was
+	if err == nil && run.Kind() == crawl.KindFull && run.Refreshed() {
+		freshness.mark(run.FinishedAt())
+	}
+	if err == nil {
+		// errors are logged by the scanner, the previous opportunities are kept
+		_ = s.scanner.Scan(ctx)
+	}
+
+	log := logging.FromContext(ctx, s.logger)
+	log.WithFields(logrus.Fields{
+		"kind":         run.Kind(),
+		"duration":     run.FinishedAt().Sub(run.StartedAt()).String(),
+		"competitions": run.CompetitionsDone(),
+		"events":       run.EventsFetched(),
+		"created":      run.EventsCreated(),
+		"updated":      run.EventsUpdated(),
+		"unchanged":    run.EventsUnchanged(),
+		"errors":       run.ErrorCount(),
+	}).Infof("Crawl finished")
+	// keep the run current until stored, so it is never missing from the state
+	if err := s.runRepo.SaveRun(ctx, run); err != nil {
+		log.WithError(err).Errorf("Store crawl run error")
+	}
+
+	s.lock.Lock()
+	s.current = nil
+	s.lock.Unlock()
+}
+
+func (s *CrawlScheduler) sportFetched() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.current.SportFetched()
+}
+
+func (s *CrawlScheduler) competitionsFound(count int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.current.AddCompetitions(count)
+}
+
+func (s *CrawlScheduler) competitionCrawled(events int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.current.CompetitionDone(events)
+}
+
+func (s *CrawlScheduler) eventStored(result string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.current.EventStored(result)
+}
+
+func (s *CrawlScheduler) failed(kind string, key string, err error) {
+	statusCode := 0
+	var cloudbetErr CloudbetError
+	if errors.As(err, &cloudbetErr) {
+		statusCode = cloudbetErr.StatusCode
+	}
+
+	crawlErrors.WithLabelValues(kind).Inc()
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.current.Fail(crawl.NewItemError(time.Now(), kind, key, statusCode, err.Error()))
+}
+
+// Trigger request an immediate crawl of every competition, or of competitionKey only if not empty.
+// Manual crawls run while paused and do not move the schedule.
+func (s *CrawlScheduler) Trigger(competitionKey string) (crawl.Run, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if !s.started {
+		return crawl.Run{}, crawl.ErrCrawlerStopped
+	}
+	if s.pending != nil || s.current != nil {
+		return crawl.Run{}, crawl.ErrCrawlInProgress
+	}
+	kind := crawl.KindFull
+	if competitionKey != "" {
+		kind = crawl.KindCompetition
+	}
+	s.lastID++
+	run := crawl.NewRun(s.lastID, kind, competitionKey, crawl.TriggerManual, time.Now())
+	s.pending = &run
+	s.notify()
+	return run, nil
+}
+
+// SetPaused suspend or resume scheduled crawls and cutoff checks, resuming crawl right away if a crawl was missed
+func (s *CrawlScheduler) SetPaused(paused bool) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.paused != paused {
+		s.logger.Infof("Crawl schedule paused %t", paused)
+	}
+	s.paused = paused
+	s.notify()
+}
+
+// SetIntervals change the crawl and cutoff check intervals, zero keep the current one.
+// The next runs are rescheduled from the previous ones with the new intervals.
+func (s *CrawlScheduler) SetIntervals(interval time.Duration, cutOffInterval time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if interval != 0 {
+		s.nextRun = s.nextRun.Add(interval - s.interval)
+		s.interval = interval
+	}
+	if cutOffInterval != 0 {
+		s.nextCutOff = s.nextCutOff.Add(cutOffInterval - s.cutOffInterval)
+		s.cutOffInterval = cutOffInterval
+	}
+	s.logger.Infof("Crawl interval %s, cutoff check interval %s", s.interval, s.cutOffInterval)
+	s.notify()
+}
+
+// Interval return the current interval between two scheduled crawls
+func (s *CrawlScheduler) Interval() time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.interval
+}
+
+// State return the schedule with the current crawl and up to count latest finished runs
+func (s *CrawlScheduler) State(ctx context.Context, count int) (crawl.State, error) {
+	runs, err := s.runRepo.ListRuns(ctx, crawl.Filter{}, count)
+	if err != nil {
+		return crawl.State{}, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var current *crawl.Run
+	if s.current != nil {
+		run := *s.current
+		current = &run
+	}
+	var nextRun time.Time
+	if s.started && !s.paused {
+		nextRun = s.nextRun
+	}
+	return crawl.NewState(s.started, s.paused, s.interval, s.cutOffInterval, nextRun, current, runs), nil
+}
+
+// notify wake the loop up without blocking, a pending wake up is enough
+func (s *CrawlScheduler) notify() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
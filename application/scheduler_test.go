@@ -0,0 +1,174 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/crawl"
+	"github.com/awcjack/cloudbet/infrastructure"
+	"github.com/sirupsen/logrus"
+)
+
+// newTestScheduler return a started scheduler whose first crawl is due at start
+func newTestScheduler(start time.Time) *CrawlScheduler {
+	s := NewCrawlScheduler(CloudbetHandler{}, ArbitrageScanner{}, nil, logrus.New(), time.Minute, 10*time.Second)
+	s.started = true
+	s.nextRun = start
+	s.nextCutOff = start.Add(10 * time.Second)
+	return s
+}
+
+func TestNext(t *testing.T) {
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestScheduler(start)
+
+	tests := []struct {
+		name        string
+		now         time.Time
+		runID       uint64
+		checkCutOff bool
+		wait        time.Duration
+	}{
+		{name: "first crawl due", now: start, runID: 1},
+		{name: "wait for the cutoff check", now: start, wait: 10 * time.Second},
+		{name: "cutoff check due", now: start.Add(10 * time.Second), checkCutOff: true},
+		{name: "next cutoff check from the last one", now: start.Add(15 * time.Second), wait: 5 * time.Second},
+		{name: "crawl wins over a due cutoff check", now: start.Add(time.Minute), runID: 2},
+		{name: "late cutoff check", now: start.Add(time.Minute), checkCutOff: true},
+		{name: "cutoff check rescheduled from when it ran", now: start.Add(time.Minute + time.Second), wait: 9 * time.Second},
+	}
+
+	for _, tt := range tests {
+		run, checkCutOff, wait := s.next(tt.now)
+		var runID uint64
+		if run != nil {
+			runID = run.ID()
+			if run.Kind() != crawl.KindFull || run.Trigger() != crawl.TriggerScheduled {
+				t.Errorf("%s: run %s triggered %s, want a scheduled full crawl", tt.name, run.Kind(), run.Trigger())
+			}
+		}
+		if runID != tt.runID || checkCutOff != tt.checkCutOff || wait != tt.wait {
+			t.Errorf("%s: next() = run %d, check cutoff %t, wait %s, want run %d, check cutoff %t, wait %s", tt.name, runID, checkCutOff, wait, tt.runID, tt.checkCutOff, tt.wait)
+		}
+	}
+}
+
+func TestNextPaused(t *testing.T) {
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestScheduler(start)
+	s.SetPaused(true)
+
+	if run, checkCutOff, wait := s.next(start.Add(time.Hour)); run != nil || checkCutOff || wait != crawl.MaxInterval {
+		t.Errorf("next() while paused = %v, %t, %s, want to sleep until woken up", run, checkCutOff, wait)
+	}
+
+	// manual crawls run while paused
+	manual, err := s.Trigger("soccer-england-premier-league")
+	if err != nil {
+		t.Fatal(err)
+	}
+	run, _, _ := s.next(start.Add(time.Hour))
+	if run == nil || run.ID() != manual.ID() || run.Kind() != crawl.KindCompetition {
+		t.Fatalf("next() while paused = %v, want the triggered crawl %d", run, manual.ID())
+	}
+
+	// the missed crawl runs right away on resume
+	s.SetPaused(false)
+	if run, _, _ := s.next(start.Add(time.Hour)); run == nil || run.Trigger() != crawl.TriggerScheduled {
+		t.Errorf("next() on resume = %v, want the missed scheduled crawl", run)
+	}
+}
+
+func TestTrigger(t *testing.T) {
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestScheduler(start)
+
+	if _, err := s.Trigger(""); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Trigger(""); !errors.Is(err, crawl.ErrCrawlInProgress) {
+		t.Errorf("Trigger() with a pending crawl error = %v, want %v", err, crawl.ErrCrawlInProgress)
+	}
+	// the pending crawl goes first even when a scheduled one is due
+	if run, _, _ := s.next(start); run == nil || run.Trigger() != crawl.TriggerManual {
+		t.Errorf("next() = %v, want the triggered crawl", run)
+	}
+
+	s.started = false
+	if _, err := s.Trigger(""); !errors.Is(err, crawl.ErrCrawlerStopped) {
+		t.Errorf("Trigger() while stopped error = %v, want %v", err, crawl.ErrCrawlerStopped)
+	}
+}
+
+func TestSetIntervals(t *testing.T) {
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestScheduler(start)
+	s.next(start)
+
+	// the next crawl moves from start + 1m to start + 5m, the cutoff check interval is kept
+	s.SetIntervals(5*time.Minute, 0)
+	if s.Interval() != 5*time.Minute || !s.nextRun.Equal(start.Add(5*time.Minute)) || s.cutOffInterval != 10*time.Second {
+		t.Errorf("SetIntervals() = crawl every %s next at %s, cutoff check every %s", s.Interval(), s.nextRun, s.cutOffInterval)
+	}
+
+	s.SetIntervals(0, 30*time.Second)
+	if s.Interval() != 5*time.Minute || !s.nextCutOff.Equal(start.Add(30*time.Second)) {
+		t.Errorf("SetIntervals() = crawl every %s, cutoff check next at %s", s.Interval(), s.nextCutOff)
+	}
+}
+
+func TestRunStops(t *testing.T) {
+	tests := []struct {
+		name string
+		// cancel the context before Run starts rather than once it waits
+		cancelFirst bool
+	}{
+		{name: "cancelled before start", cancelFirst: true},
+		{name: "cancelled while waiting"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logger := logrus.New()
+			logger.SetLevel(logrus.PanicLevel)
+			runRepo := infrastructure.NewCrawlRunMemoryRepository(10)
+			s := NewCrawlScheduler(NewCloudbetHander(infrastructure.NewMemoryRepository(), logger, "test"), ArbitrageScanner{}, runRepo, logger, time.Hour, time.Hour)
+			// paused, so the loop waits instead of crawling Cloudbet
+			s.SetPaused(true)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelFirst {
+				cancel()
+			}
+			stopped := make(chan struct{})
+			go func() {
+				s.Run(ctx)
+				close(stopped)
+			}()
+			if !tt.cancelFirst {
+				for {
+					if state, _ := s.State(ctx, 1); state.Enabled() {
+						break
+					}
+					time.Sleep(time.Millisecond)
+				}
+				cancel()
+			}
+
+			select {
+			case <-stopped:
+			case <-time.After(time.Second):
+				t.Fatal("Run() still running after cancellation")
+			}
+			state, err := s.State(context.Background(), 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state.Enabled() || len(state.Runs()) != 0 {
+				t.Errorf("State() = enabled %t with %d runs, want stopped without crawling", state.Enabled(), len(state.Runs()))
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package application
+
+import (
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracer of the crawler and the replica, spans are dropped until main installs a tracer provider
+var tracer = otel.Tracer("github.com/awcjack/cloudbet/application")
+
+const (
+	endpointKey       = attribute.Key("cloudbet.endpoint")
+	crawlIDKey        = attribute.Key("crawl.id")
+	crawlKindKey      = attribute.Key("crawl.kind")
+	crawlTriggerKey   = attribute.Key("crawl.trigger")
+	competitionKeyKey = attribute.Key("crawl.competition")
+)
+
+// failSpan record err on span and mark it failed
+func failSpan(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
@@ -0,0 +1,62 @@
+package activity
+
+import (
+	"time"
+)
+
+// Activity summarize the cached events under a sport, category or competition
+type Activity struct {
+	// number of cached events, active or not
+	eventCount int
+	// number of events still trading
+	activeEventCount int
+	// number of events in TRADING_LIVE status
+	liveEventCount int
+	// earliest upcoming cutoff time of the active events, zero when none
+	nextCutOffTime time.Time
+	// time any of the events last changed or was evicted, zero when never
+	lastUpdated time.Time
+}
+
+func NewActivity(eventCount int, activeEventCount int, liveEventCount int, nextCutOffTime time.Time, lastUpdated time.Time) Activity {
+	return Activity{
+		eventCount:       eventCount,
+		activeEventCount: activeEventCount,
+		liveEventCount:   liveEventCount,
+		nextCutOffTime:   nextCutOffTime,
+		lastUpdated:      lastUpdated,
+	}
+}
+
+func (a Activity) EventCount() int {
+	return a.eventCount
+}
+
+func (a Activity) ActiveEventCount() int {
+	return a.activeEventCount
+}
+
+func (a Activity) LiveEventCount() int {
+	return a.liveEventCount
+}
+
+func (a Activity) NextCutOffTime() time.Time {
+	return a.nextCutOffTime
+}
+
+func (a Activity) LastUpdated() time.Time {
+	return a.lastUpdated
+}
+
+// Merge combine the activities of disjoint sets of events
+func (a Activity) Merge(other Activity) Activity {
+	nextCutOffTime := a.nextCutOffTime
+	if nextCutOffTime.IsZero() || (!other.nextCutOffTime.IsZero() && other.nextCutOffTime.Before(nextCutOffTime)) {
+		nextCutOffTime = other.nextCutOffTime
+	}
+	lastUpdated := a.lastUpdated
+	if other.lastUpdated.After(lastUpdated) {
+		lastUpdated = other.lastUpdated
+	}
+	return NewActivity(a.eventCount+other.eventCount, a.activeEventCount+other.activeEventCount, a.liveEventCount+other.liveEventCount, nextCutOffTime, lastUpdated)
+}
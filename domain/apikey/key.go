@@ -0,0 +1,135 @@
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+const (
+	// read cached data
+	ScopeRead = "read"
+	// subscribe to push streams
+	ScopeStream = "stream"
+	// manage keys and upload data, implies every other scope
+	ScopeAdmin = "admin"
+)
+
+// prefix of generated secrets, so leaked keys are easy to recognize
+const secretPrefix = "cbk_"
+
+// Key authenticate a client of the API. Only the hash of its secret is kept, the secret is shown once on creation.
+type Key struct {
+	// public identifier of this Key
+	id string
+	// description of the client using this Key
+	name string
+	// hex encoded SHA-256 of the secret
+	hash string
+	// granted scopes
+	scopes []string
+	// requests allowed per minute, 0 for unlimited
+	rateLimit int
+	// requests allowed per UTC day, 0 for unlimited
+	quota     int
+	createdAt time.Time
+	// zero until the Key is revoked
+	revokedAt time.Time
+}
+
+func NewKey(id string, name string, hash string, scopes []string, rateLimit int, quota int, createdAt time.Time) (Key, error) {
+	if len(scopes) == 0 {
+		return Key{}, ErrInvalidScope
+	}
+	for _, scope := range scopes {
+		if scope != ScopeRead && scope != ScopeStream && scope != ScopeAdmin {
+			return Key{}, ErrInvalidScope
+		}
+	}
+	if rateLimit < 0 || quota < 0 {
+		return Key{}, ErrInvalidLimit
+	}
+
+	return Key{
+		id:        id,
+		name:      name,
+		hash:      hash,
+		scopes:    scopes,
+		rateLimit: rateLimit,
+		quota:     quota,
+		createdAt: createdAt,
+	}, nil
+}
+
+// NewSecret generate a random secret and its public identifier
+func NewSecret() (id string, secret string, err error) {
+	idBytes := make([]byte, 8)
+	if _, err := rand.Read(idBytes); err != nil {
+		return "", "", err
+	}
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(secretBytes); err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(idBytes), secretPrefix + base64.RawURLEncoding.EncodeToString(secretBytes), nil
+}
+
+// Hash return how a secret is stored, secrets are random so a fast hash is enough
+func Hash(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func (k Key) ID() string {
+	return k.id
+}
+
+func (k Key) Name() string {
+	return k.name
+}
+
+func (k Key) Hash() string {
+	return k.hash
+}
+
+func (k Key) Scopes() []string {
+	return k.scopes
+}
+
+func (k Key) RateLimit() int {
+	return k.rateLimit
+}
+
+func (k Key) Quota() int {
+	return k.quota
+}
+
+func (k Key) CreatedAt() time.Time {
+	return k.createdAt
+}
+
+func (k Key) RevokedAt() time.Time {
+	return k.revokedAt
+}
+
+func (k Key) Revoked() bool {
+	return !k.revokedAt.IsZero()
+}
+
+func (k *Key) Revoke(at time.Time) {
+	if k.revokedAt.IsZero() {
+		k.revokedAt = at
+	}
+}
+
+// HasScope report whether the Key grants scope, admin grants every scope
+func (k Key) HasScope(scope string) bool {
+	for _, v := range k.scopes {
+		if v == scope || v == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+package apikey
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewKey(t *testing.T) {
+	tests := []struct {
+		name      string
+		scopes    []string
+		rateLimit int
+		quota     int
+		err       error
+	}{
+		{name: "valid", scopes: []string{ScopeRead, ScopeStream}, rateLimit: 60, quota: 1000},
+		{name: "no scope", scopes: nil, err: ErrInvalidScope},
+		{name: "unknown scope", scopes: []string{ScopeRead, "write"}, err: ErrInvalidScope},
+		{name: "negative rate limit", scopes: []string{ScopeRead}, rateLimit: -1, err: ErrInvalidLimit},
+		{name: "negative quota", scopes: []string{ScopeRead}, quota: -1, err: ErrInvalidLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewKey("id", "name", "hash", tt.scopes, tt.rateLimit, tt.quota, time.Now())
+			if !errors.Is(err, tt.err) {
+				t.Errorf("NewKey() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestHasScope(t *testing.T) {
+	tests := []struct {
+		scopes []string
+		scope  string
+		want   bool
+	}{
+		{[]string{ScopeRead}, ScopeRead, true},
+		{[]string{ScopeRead}, ScopeStream, false},
+		{[]string{ScopeRead}, ScopeAdmin, false},
+		{[]string{ScopeStream}, ScopeRead, false},
+		{[]string{ScopeRead, ScopeStream}, ScopeStream, true},
+		{[]string{ScopeAdmin}, ScopeRead, true},
+		{[]string{ScopeAdmin}, ScopeStream, true},
+		{[]string{ScopeAdmin}, ScopeAdmin, true},
+	}
+
+	for _, tt := range tests {
+		key, err := NewKey("id", "name", "hash", tt.scopes, 0, 0, time.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := key.HasScope(tt.scope); got != tt.want {
+			t.Errorf("%v HasScope(%q) = %t, want %t", tt.scopes, tt.scope, got, tt.want)
+		}
+	}
+}
+
+func TestSecret(t *testing.T) {
+	id, secret, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, other, err := NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id == "" || secret == other {
+		t.Errorf("NewSecret() = %q, %q, want a fresh secret with an ID", id, secret)
+	}
+	if Hash(secret) != Hash(secret) || Hash(secret) == Hash(other) {
+		t.Errorf("Hash() must be stable and tell secrets apart")
+	}
+}
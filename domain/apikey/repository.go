@@ -0,0 +1,30 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+var (
+	ErrKeyNotFound     = failure.New(failure.NotFound, "api_key_not_found", "api key not found")
+	ErrInvalidScope    = failure.New(failure.Invalid, "invalid_scope", "scopes must be a non empty list of read, stream, admin")
+	ErrInvalidLimit    = failure.New(failure.Invalid, "invalid_limit", "rate limit and quota cannot be negative")
+	ErrMissingKey      = failure.New(failure.Unauthenticated, "missing_api_key", "missing X-API-Key header")
+	ErrInvalidKey      = failure.New(failure.Unauthenticated, "invalid_api_key", "api key is unknown or revoked")
+	ErrScopeNotGranted = failure.New(failure.Forbidden, "scope_not_granted", "api key is not granted the scope required by this operation")
+	ErrRateLimited     = failure.New(failure.TooManyRequests, "rate_limited", "api key exceeded its rate limit or quota")
+)
+
+type Repository interface {
+	// SaveKey create or replace a Key
+	SaveKey(ctx context.Context, key Key) error
+	GetKey(ctx context.Context, id string) (Key, error)
+	// GetKeyByHash return ErrKeyNotFound when no Key has the hash, revoked keys are returned as well
+	GetKeyByHash(ctx context.Context, hash string) (Key, error)
+	// ListKeys return every Key ordered by creation time
+	ListKeys(ctx context.Context) ([]Key, error)
+	// RecordRequest count a request of the Key at now and return its usage including it
+	RecordRequest(ctx context.Context, id string, now time.Time) (Usage, error)
+}
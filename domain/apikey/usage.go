@@ -0,0 +1,113 @@
+package apikey
+
+import (
+	"time"
+)
+
+// Usage count the requests of a Key in the current fixed windows
+type Usage struct {
+	// requests in the current minute, including the one being served
+	minuteCount int
+	// end of the current minute window
+	minuteReset time.Time
+	// requests in the current UTC day, including the one being served
+	dayCount int
+	// end of the current day window
+	dayReset time.Time
+}
+
+func NewUsage(minuteCount int, minuteReset time.Time, dayCount int, dayReset time.Time) Usage {
+	return Usage{
+		minuteCount: minuteCount,
+		minuteReset: minuteReset,
+		dayCount:    dayCount,
+		dayReset:    dayReset,
+	}
+}
+
+func (u Usage) MinuteCount() int {
+	return u.minuteCount
+}
+
+func (u Usage) MinuteReset() time.Time {
+	return u.minuteReset
+}
+
+func (u Usage) DayCount() int {
+	return u.dayCount
+}
+
+func (u Usage) DayReset() time.Time {
+	return u.dayReset
+}
+
+// Limit is the state of the tightest limit of a Key after a request, as reported in RateLimit-* headers
+type Limit struct {
+	// allowed requests in the window, 0 when the Key is unlimited
+	limit int
+	// requests left in the window
+	remaining int
+	// end of the window
+	reset time.Time
+	// the request went over the limit
+	exceeded bool
+}
+
+func (l Limit) Limit() int {
+	return l.limit
+}
+
+func (l Limit) Remaining() int {
+	return l.remaining
+}
+
+func (l Limit) Reset() time.Time {
+	return l.reset
+}
+
+func (l Limit) Exceeded() bool {
+	return l.exceeded
+}
+
+// Unlimited report whether no limit applies, so there is nothing to report
+func (l Limit) Unlimited() bool {
+	return l.limit == 0
+}
+
+// Check apply the rate limit and quota of the Key to its usage and return the limit closest to exhaustion
+func (k Key) Check(usage Usage) Limit {
+	var result Limit
+	for _, window := range []struct {
+		limit int
+		count int
+		reset time.Time
+	}{
+		{k.rateLimit, usage.minuteCount, usage.minuteReset},
+		{k.quota, usage.dayCount, usage.dayReset},
+	} {
+		if window.limit == 0 {
+			continue
+		}
+		remaining := window.limit - window.count
+		exceeded := remaining < 0
+		if remaining < 0 {
+			remaining = 0
+		}
+		// an exceeded window wins, the client has to wait for the latest reset of the exceeded ones
+		replace := result.limit == 0 || (exceeded && !result.exceeded)
+		if exceeded && result.exceeded {
+			replace = window.reset.After(result.reset)
+		} else if !exceeded && !result.exceeded {
+			replace = replace || remaining < result.remaining
+		}
+		if replace {
+			result = Limit{
+				limit:     window.limit,
+				remaining: remaining,
+				reset:     window.reset,
+				exceeded:  exceeded,
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,91 @@
+package apikey
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCheck(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 30, 15, 0, time.UTC)
+	minuteReset := now.Truncate(time.Minute).Add(time.Minute)
+	dayReset := time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		rateLimit int
+		quota     int
+		minute    int
+		day       int
+		want      Limit
+	}{
+		{
+			name:   "unlimited",
+			minute: 1000,
+			day:    100000,
+			want:   Limit{},
+		},
+		{
+			name:      "rate limit only",
+			rateLimit: 10,
+			minute:    3,
+			day:       50,
+			want:      Limit{limit: 10, remaining: 7, reset: minuteReset},
+		},
+		{
+			name:   "quota only",
+			quota:  100,
+			minute: 3,
+			day:    50,
+			want:   Limit{limit: 100, remaining: 50, reset: dayReset},
+		},
+		{
+			name:      "last request of the minute",
+			rateLimit: 10,
+			minute:    10,
+			want:      Limit{limit: 10, remaining: 0, reset: minuteReset},
+		},
+		{
+			name:      "rate limit exceeded",
+			rateLimit: 10,
+			minute:    11,
+			want:      Limit{limit: 10, remaining: 0, reset: minuteReset, exceeded: true},
+		},
+		{
+			name:      "fewest remaining requests reported",
+			rateLimit: 10,
+			quota:     100,
+			minute:    2,
+			day:       95,
+			want:      Limit{limit: 100, remaining: 5, reset: dayReset},
+		},
+		{
+			name:      "exceeded window wins over remaining requests",
+			rateLimit: 10,
+			quota:     100,
+			minute:    2,
+			day:       101,
+			want:      Limit{limit: 100, remaining: 0, reset: dayReset, exceeded: true},
+		},
+		{
+			name:      "latest reset of the exceeded windows",
+			rateLimit: 10,
+			quota:     100,
+			minute:    11,
+			day:       101,
+			want:      Limit{limit: 100, remaining: 0, reset: dayReset, exceeded: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := NewKey("id", "name", "hash", []string{ScopeRead}, tt.rateLimit, tt.quota, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := key.Check(NewUsage(tt.minute, minuteReset, tt.day, dayReset))
+			if got != tt.want {
+				t.Errorf("Check() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,318 @@
+package arbitrage
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+const (
+	// back every outcome of a line with implied probabilities summing below 100%
+	KindSureBet = "SURE_BET"
+	// back an outcome at a price above the lay price of the same outcome
+	KindBackLay = "BACK_LAY"
+
+	// minimum stake is 0.01 EUR for all markets
+	MinStake = 0.01
+)
+
+type Leg struct {
+	// outcome of the selection to be placed
+	outcome string
+	// parameters of the selection to be placed
+	params string
+	// side of the selection (BACK/LAY)
+	side string
+	// price of the selection
+	price float64
+	// suggested stake in EUR for this Leg
+	stake float64
+	// maximum stake in EUR of the selection
+	maxStake float64
+}
+
+func (l Leg) Outcome() string {
+	return l.outcome
+}
+
+func (l Leg) Params() string {
+	return l.params
+}
+
+func (l Leg) Side() string {
+	return l.side
+}
+
+func (l Leg) Price() float64 {
+	return l.price
+}
+
+func (l Leg) Stake() float64 {
+	return l.stake
+}
+
+func (l Leg) MaxStake() float64 {
+	return l.maxStake
+}
+
+type Opportunity struct {
+	// slug of the event offering this Opportunity
+	eventKey string
+	// name of the event offering this Opportunity
+	eventName string
+	// market key of the selections
+	marketKey string
+	// submarket key of the selections
+	submarketKey string
+	// SURE_BET or BACK_LAY
+	kind string
+	// selections to be placed together
+	legs []Leg
+	// sum of implied probabilities for SURE_BET, lay price / back price for BACK_LAY
+	impliedProbability float64
+	// largest total stake in EUR respecting every leg's max stake
+	totalStake float64
+	// guaranteed profit in EUR when placing totalStake
+	profit float64
+	// time that this Opportunity was found
+	detectedAt time.Time
+}
+
+func (o Opportunity) EventKey() string {
+	return o.eventKey
+}
+
+func (o Opportunity) EventName() string {
+	return o.eventName
+}
+
+func (o Opportunity) MarketKey() string {
+	return o.marketKey
+}
+
+func (o Opportunity) SubmarketKey() string {
+	return o.submarketKey
+}
+
+func (o Opportunity) Kind() string {
+	return o.kind
+}
+
+func (o Opportunity) Legs() []Leg {
+	return o.legs
+}
+
+func (o Opportunity) ImpliedProbability() float64 {
+	return o.impliedProbability
+}
+
+func (o Opportunity) TotalStake() float64 {
+	return o.totalStake
+}
+
+func (o Opportunity) Profit() float64 {
+	return o.profit
+}
+
+// ProfitPercentage return the guaranteed profit relative to the total stake
+func (o Opportunity) ProfitPercentage() float64 {
+	if o.totalStake == 0 {
+		return 0
+	}
+	return o.profit / o.totalStake
+}
+
+func (o Opportunity) DetectedAt() time.Time {
+	return o.detectedAt
+}
+
+// outcomes making up the whole book of a market type, one of them always wins, by market key without the sport
+// prefix. Sure bets are only looked for in these markets.
+var bookOutcomes = map[string][]string{
+	"match_odds":          {"home", "draw", "away"},
+	"moneyline":           {"home", "away"},
+	"winner":              {"home", "away"},
+	"draw_no_bet":         {"home", "away"},
+	"asian_handicap":      {"home", "away"},
+	"handicap":            {"home", "away"},
+	"total_goals":         {"over", "under"},
+	"totals":              {"over", "under"},
+	"both_teams_to_score": {"yes", "no"},
+}
+
+// marketType return the market key without the sport prefix, e.g. match_odds for soccer.match_odds
+func marketType(marketKey string) string {
+	if i := strings.Index(marketKey, "."); i >= 0 {
+		return marketKey[i+1:]
+	}
+	return marketKey
+}
+
+// complete report whether outcomes are exactly the expected outcomes
+func complete(outcomes map[string]event.Selection, expected []string) bool {
+	if len(outcomes) != len(expected) {
+		return false
+	}
+	for _, outcome := range expected {
+		if _, ok := outcomes[outcome]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Find scan every submarket of an active event for sure bets and back/lay crossings
+func Find(e event.Event) []Opportunity {
+	if !e.Active() {
+		return nil
+	}
+
+	var result []Opportunity
+	for marketKey, market := range e.Market() {
+		for submarketKey, selections := range market.Submarkets() {
+			back := make(map[string]map[string]event.Selection)
+			lay := make(map[string]map[string]event.Selection)
+			for _, selection := range selections {
+				if selection.Status() == "SELECTION_DISABLED" || selection.Price() <= 1 || selection.MaxStake() < MinStake {
+					continue
+				}
+				target := back
+				if selection.Side() == "LAY" {
+					target = lay
+				}
+				if _, ok := target[selection.Params()]; !ok {
+					target[selection.Params()] = make(map[string]event.Selection)
+				}
+				best, ok := target[selection.Params()][selection.Outcome()]
+				// keep the best price for each side, highest to back and lowest to lay
+				if !ok || (selection.Side() != "LAY" && selection.Price() > best.Price()) || (selection.Side() == "LAY" && selection.Price() < best.Price()) {
+					target[selection.Params()][selection.Outcome()] = selection
+				}
+			}
+
+			expected, known := bookOutcomes[marketType(marketKey)]
+			for _, outcomes := range back {
+				// an outcome left out, e.g. a suspended or unlisted draw, would not be covered by the stakes
+				if !known || !complete(outcomes, expected) {
+					continue
+				}
+				if opportunity, ok := sureBet(outcomes); ok {
+					opportunity.eventKey = e.Key()
+					opportunity.eventName = e.Name()
+					opportunity.marketKey = marketKey
+					opportunity.submarketKey = submarketKey
+					result = append(result, opportunity)
+				}
+			}
+			for params, outcomes := range back {
+				for outcome, backSelection := range outcomes {
+					laySelection, ok := lay[params][outcome]
+					if !ok {
+						continue
+					}
+					if opportunity, ok := backLay(backSelection, laySelection); ok {
+						opportunity.eventKey = e.Key()
+						opportunity.eventName = e.Name()
+						opportunity.marketKey = marketKey
+						opportunity.submarketKey = submarketKey
+						result = append(result, opportunity)
+					}
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+func sureBet(outcomes map[string]event.Selection) (Opportunity, bool) {
+	if len(outcomes) < 2 {
+		return Opportunity{}, false
+	}
+
+	keys := make([]string, 0, len(outcomes))
+	var impliedProbability float64
+	for outcome, selection := range outcomes {
+		keys = append(keys, outcome)
+		impliedProbability += 1 / selection.Price()
+	}
+	if impliedProbability >= 1 {
+		return Opportunity{}, false
+	}
+	sort.Strings(keys)
+
+	// stake on each outcome is proportional to its implied probability so every outcome returns the same,
+	// the total stake is limited by the outcome reaching its max stake first
+	totalStake := math.Inf(1)
+	for _, selection := range outcomes {
+		totalStake = math.Min(totalStake, selection.MaxStake()*selection.Price()*impliedProbability)
+	}
+
+	legs := make([]Leg, 0, len(keys))
+	for _, outcome := range keys {
+		selection := outcomes[outcome]
+		stake := totalStake / (selection.Price() * impliedProbability)
+		if stake < MinStake {
+			return Opportunity{}, false
+		}
+		legs = append(legs, Leg{
+			outcome:  selection.Outcome(),
+			params:   selection.Params(),
+			side:     "BACK",
+			price:    selection.Price(),
+			stake:    stake,
+			maxStake: selection.MaxStake(),
+		})
+	}
+
+	return Opportunity{
+		kind:               KindSureBet,
+		legs:               legs,
+		impliedProbability: impliedProbability,
+		totalStake:         totalStake,
+		profit:             totalStake/impliedProbability - totalStake,
+		detectedAt:         time.Now(),
+	}, true
+}
+
+func backLay(backSelection event.Selection, laySelection event.Selection) (Opportunity, bool) {
+	if backSelection.Price() <= laySelection.Price() {
+		return Opportunity{}, false
+	}
+
+	// lay stake = back stake * back price / lay price returns the same whether the outcome wins or not
+	backStake := math.Min(backSelection.MaxStake(), laySelection.MaxStake()*laySelection.Price()/backSelection.Price())
+	layStake := backStake * backSelection.Price() / laySelection.Price()
+	if backStake < MinStake || layStake < MinStake {
+		return Opportunity{}, false
+	}
+
+	return Opportunity{
+		kind: KindBackLay,
+		legs: []Leg{
+			{
+				outcome:  backSelection.Outcome(),
+				params:   backSelection.Params(),
+				side:     "BACK",
+				price:    backSelection.Price(),
+				stake:    backStake,
+				maxStake: backSelection.MaxStake(),
+			},
+			{
+				outcome:  laySelection.Outcome(),
+				params:   laySelection.Params(),
+				side:     "LAY",
+				price:    laySelection.Price(),
+				stake:    layStake,
+				maxStake: laySelection.MaxStake(),
+			},
+		},
+		impliedProbability: laySelection.Price() / backSelection.Price(),
+		totalStake:         backStake,
+		profit:             layStake - backStake,
+		detectedAt:         time.Now(),
+	}, true
+}
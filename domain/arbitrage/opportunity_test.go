@@ -0,0 +1,178 @@
+package arbitrage
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+const (
+	testMarketKey         = "soccer.draw_no_bet"
+	testThreeWayMarketKey = "soccer.match_odds"
+	testSubmarketKey      = "period=ft"
+)
+
+func newTestEvent(t *testing.T, active bool, marketKey string, selections ...event.Selection) event.Event {
+	t.Helper()
+	sport, _ := event.NewIdentifier("Soccer", "soccer")
+	category, _ := event.NewIdentifier("England", "england")
+	competition, _ := event.NewIdentifier("Premier League", "soccer-england-premier-league")
+	markets := map[string]event.Market{
+		marketKey: event.NewMarket(map[string][]event.Selection{testSubmarketKey: selections}),
+	}
+	e, err := event.NewEvent(&sport, &competition, &category, event.NewTeamIdentifier("Arsenal", "arsenal", "ARS", "ENG"),
+		event.NewTeamIdentifier("Chelsea", "chelsea", "CHE", "ENG"), active, false, markets, "Arsenal V Chelsea", "arsenal-v-chelsea", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *e
+}
+
+func back(outcome string, price float64, maxStake float64) event.Selection {
+	return event.NewSelection(outcome, "", price, maxStake, 1/price, "SELECTION_ENABLED", "BACK")
+}
+
+func lay(outcome string, price float64, maxStake float64) event.Selection {
+	return event.NewSelection(outcome, "", price, maxStake, 1/price, "SELECTION_ENABLED", "LAY")
+}
+
+func disabled(outcome string, price float64, maxStake float64) event.Selection {
+	return event.NewSelection(outcome, "", price, maxStake, 1/price, "SELECTION_DISABLED", "BACK")
+}
+
+func almostEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestFindSureBet(t *testing.T) {
+	e := newTestEvent(t, true, testMarketKey, back("home", 2.1, 100), back("away", 2.1, 50), back("home", 2.0, 1000))
+
+	opportunities := Find(e)
+	if len(opportunities) != 1 {
+		t.Fatalf("Find() = %d opportunities, want 1", len(opportunities))
+	}
+	o := opportunities[0]
+	if o.Kind() != KindSureBet || o.EventKey() != e.Key() || o.MarketKey() != testMarketKey || o.SubmarketKey() != testSubmarketKey {
+		t.Errorf("Find() = %s on %s %s %s", o.Kind(), o.EventKey(), o.MarketKey(), o.SubmarketKey())
+	}
+	if !almostEqual(o.ImpliedProbability(), 2/2.1) {
+		t.Errorf("ImpliedProbability() = %f, want %f", o.ImpliedProbability(), 2/2.1)
+	}
+	// away reaches its max stake first, at half the total stake
+	if !almostEqual(o.TotalStake(), 100) || !almostEqual(o.Profit(), 5) {
+		t.Errorf("TotalStake() = %f, Profit() = %f, want 100 and 5", o.TotalStake(), o.Profit())
+	}
+	for _, leg := range o.Legs() {
+		// the best price of every outcome is backed, and every outcome returns the total stake plus the profit
+		if leg.Side() != "BACK" || leg.Price() != 2.1 || !almostEqual(leg.Stake()*leg.Price(), o.TotalStake()+o.Profit()) {
+			t.Errorf("leg %s %s at %f stake %f", leg.Side(), leg.Outcome(), leg.Price(), leg.Stake())
+		}
+	}
+}
+
+func TestFindBackLay(t *testing.T) {
+	e := newTestEvent(t, true, testMarketKey, back("home", 2.2, 100), lay("home", 2.0, 50), lay("home", 2.1, 1000))
+
+	opportunities := Find(e)
+	if len(opportunities) != 1 {
+		t.Fatalf("Find() = %d opportunities, want 1", len(opportunities))
+	}
+	o := opportunities[0]
+	if o.Kind() != KindBackLay || len(o.Legs()) != 2 {
+		t.Fatalf("Find() = %s with %d legs", o.Kind(), len(o.Legs()))
+	}
+	backLeg, layLeg := o.Legs()[0], o.Legs()[1]
+	// the lowest lay price is taken, its max stake limits the back stake
+	if layLeg.Side() != "LAY" || layLeg.Price() != 2.0 || !almostEqual(layLeg.Stake(), 50) {
+		t.Errorf("lay leg %s at %f stake %f", layLeg.Side(), layLeg.Price(), layLeg.Stake())
+	}
+	if backLeg.Side() != "BACK" || !almostEqual(backLeg.Stake(), 50*2.0/2.2) {
+		t.Errorf("back leg %s at %f stake %f", backLeg.Side(), backLeg.Price(), backLeg.Stake())
+	}
+	if !almostEqual(o.Profit(), layLeg.Stake()-backLeg.Stake()) || o.Profit() <= 0 {
+		t.Errorf("Profit() = %f", o.Profit())
+	}
+}
+
+func TestFindNothing(t *testing.T) {
+	tests := []struct {
+		name       string
+		active     bool
+		marketKey  string
+		selections []event.Selection
+	}{
+		{
+			name:       "implied probabilities over 100%",
+			marketKey:  testMarketKey,
+			active:     true,
+			selections: []event.Selection{back("home", 1.9, 100), back("away", 1.9, 100)},
+		},
+		{
+			name:       "inactive event",
+			marketKey:  testMarketKey,
+			active:     false,
+			selections: []event.Selection{back("home", 2.1, 100), back("away", 2.1, 100)},
+		},
+		{
+			name:       "suspended draw",
+			active:     true,
+			marketKey:  testThreeWayMarketKey,
+			selections: []event.Selection{back("home", 3.1, 100), disabled("draw", 3.1, 100), back("away", 3.1, 100)},
+		},
+		{
+			name:       "outcome without max stake",
+			marketKey:  testMarketKey,
+			active:     true,
+			selections: []event.Selection{back("home", 2.1, 100), back("away", 2.1, 0)},
+		},
+		{
+			name:       "single outcome",
+			marketKey:  testMarketKey,
+			active:     true,
+			selections: []event.Selection{back("home", 5, 100)},
+		},
+		{
+			name:       "three-way book without the draw",
+			active:     true,
+			marketKey:  testThreeWayMarketKey,
+			selections: []event.Selection{back("home", 2.1, 100), back("away", 2.1, 100)},
+		},
+		{
+			name:       "outcome of another market",
+			active:     true,
+			marketKey:  testMarketKey,
+			selections: []event.Selection{back("home", 3.1, 100), back("draw", 3.1, 100), back("away", 3.1, 100)},
+		},
+		{
+			name:       "unknown market type",
+			active:     true,
+			marketKey:  "soccer.correct_score",
+			selections: []event.Selection{back("1-0", 2.1, 100), back("0-1", 2.1, 100)},
+		},
+		{
+			name:       "lay above back",
+			marketKey:  testMarketKey,
+			active:     true,
+			selections: []event.Selection{back("home", 2.0, 100), lay("home", 2.1, 100)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if opportunities := Find(newTestEvent(t, tt.active, tt.marketKey, tt.selections...)); len(opportunities) != 0 {
+				t.Errorf("Find() = %d opportunities, want none", len(opportunities))
+			}
+		})
+	}
+}
+
+func TestFindCompleteThreeWay(t *testing.T) {
+	e := newTestEvent(t, true, testThreeWayMarketKey, back("home", 3.1, 100), back("draw", 3.1, 100), back("away", 3.1, 100))
+
+	opportunities := Find(e)
+	if len(opportunities) != 1 || len(opportunities[0].Legs()) != 3 {
+		t.Fatalf("Find() = %v, want a sure bet over the 3 outcomes", opportunities)
+	}
+}
@@ -0,0 +1,10 @@
+package arbitrage
+
+import (
+	"context"
+)
+
+type Repository interface {
+	ReplaceOpportunities(ctx context.Context, opportunities []Opportunity) error
+	ListOpportunities(ctx context.Context, first int, page int) ([]Opportunity, error)
+}
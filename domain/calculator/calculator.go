@@ -0,0 +1,287 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+// minimum stake is 0.01 EUR for all markets
+const MinStake = 0.01
+
+var (
+	ErrEventInactive       = failure.New(failure.Invalid, "event_inactive", "event is not trading")
+	ErrSelectionDisabled   = failure.New(failure.Invalid, "selection_disabled", "selection is disabled")
+	ErrInvalidPrice        = failure.New(failure.Invalid, "invalid_price", "price must be larger than 1")
+	ErrInvalidProbability  = failure.New(failure.Invalid, "invalid_probability", "probability must be larger than 0 and not larger than 1")
+	ErrInvalidBankroll     = failure.New(failure.Invalid, "invalid_bankroll", "bankroll must be larger than 0")
+	ErrInvalidFraction     = failure.New(failure.Invalid, "invalid_fraction", "kelly fraction must be larger than 0 and not larger than 1")
+	ErrStakeBelowMinimum   = failure.New(failure.Invalid, "stake_below_minimum", "stake is below the 0.01 EUR minimum")
+	ErrStakeAboveMaximum   = failure.New(failure.Invalid, "stake_above_maximum", "stake exceeds selection max stake")
+	ErrTooFewSelections    = failure.New(failure.Invalid, "too_few_selections", "at least 2 selections are required")
+	ErrDuplicateEvent      = failure.New(failure.Invalid, "duplicate_event", "parlay legs must belong to different events")
+	ErrDuplicateSelections = failure.New(failure.Invalid, "duplicate_selections", "dutching selections must be different")
+)
+
+type Leg struct {
+	// reference used to look up the selection
+	reference event.Reference
+	// cached selection priced by the calculator
+	selection event.Selection
+	// calculated stake in EUR on this Leg
+	stake float64
+}
+
+// NewLeg pair a reference with its resolved selection, rejecting selections which cannot be bet on
+func NewLeg(reference event.Reference, e event.Event, selection event.Selection) (Leg, error) {
+	if !e.Active() {
+		return Leg{}, fmt.Errorf("%s: %w", reference.EventKey(), ErrEventInactive)
+	}
+	if selection.Status() == "SELECTION_DISABLED" {
+		return Leg{}, fmt.Errorf("%s %s %s: %w", reference.EventKey(), reference.MarketKey(), reference.Outcome(), ErrSelectionDisabled)
+	}
+	if selection.Price() <= 1 {
+		return Leg{}, fmt.Errorf("%s %s %s: %w", reference.EventKey(), reference.MarketKey(), reference.Outcome(), ErrInvalidPrice)
+	}
+
+	return Leg{
+		reference: reference,
+		selection: selection,
+	}, nil
+}
+
+func (l Leg) Reference() event.Reference {
+	return l.reference
+}
+
+func (l Leg) Selection() event.Selection {
+	return l.selection
+}
+
+func (l Leg) Stake() float64 {
+	return l.stake
+}
+
+func (l Leg) withStake(stake float64) (Leg, error) {
+	if stake < MinStake {
+		return Leg{}, fmt.Errorf("%s %s %s: %w", l.reference.EventKey(), l.reference.MarketKey(), l.reference.Outcome(), ErrStakeBelowMinimum)
+	}
+	if stake > l.selection.MaxStake() {
+		return Leg{}, fmt.Errorf("%s %s %s: %w (%.2f > %.2f)", l.reference.EventKey(), l.reference.MarketKey(), l.reference.Outcome(), ErrStakeAboveMaximum, stake, l.selection.MaxStake())
+	}
+	l.stake = stake
+	return l, nil
+}
+
+type Kelly struct {
+	// selection with the suggested stake, 0 when there is no edge
+	leg Leg
+	// probability of the outcome supplied by the caller
+	probability float64
+	// expected return per unit staked, price * probability - 1
+	edge float64
+	// fraction of bankroll suggested by the (fractional) Kelly criterion
+	fraction float64
+}
+
+func (k Kelly) Leg() Leg {
+	return k.leg
+}
+
+func (k Kelly) Probability() float64 {
+	return k.probability
+}
+
+func (k Kelly) Edge() float64 {
+	return k.edge
+}
+
+func (k Kelly) Fraction() float64 {
+	return k.fraction
+}
+
+// NewKelly size a stake with the Kelly criterion scaled by multiplier, capped at the selection max stake
+func NewKelly(leg Leg, probability float64, bankroll float64, multiplier float64) (Kelly, error) {
+	if probability <= 0 || probability > 1 {
+		return Kelly{}, ErrInvalidProbability
+	}
+	if bankroll <= 0 {
+		return Kelly{}, ErrInvalidBankroll
+	}
+	if multiplier <= 0 || multiplier > 1 {
+		return Kelly{}, ErrInvalidFraction
+	}
+
+	price := leg.selection.Price()
+	edge := price*probability - 1
+	fraction := math.Max(0, edge/(price-1)*multiplier)
+	stake := math.Min(fraction*bankroll, leg.selection.MaxStake())
+	if stake < MinStake {
+		// no bet is placed when the suggested stake cannot reach the minimum
+		stake = 0
+	}
+	leg.stake = stake
+
+	return Kelly{
+		leg:         leg,
+		probability: probability,
+		edge:        edge,
+		fraction:    fraction,
+	}, nil
+}
+
+type Hedge struct {
+	// hedging selection with the stake equalising both results
+	leg Leg
+	// profit in EUR whichever way the event settles, negative for a loss
+	profit float64
+}
+
+func (h Hedge) Leg() Leg {
+	return h.leg
+}
+
+func (h Hedge) Profit() float64 {
+	return h.profit
+}
+
+// NewHedge size a stake on leg so that an existing bet of stake at price returns the same whatever the result.
+// Hedges are back only, leg must be on the outcome opposite to the existing bet, as references never resolve LAY selections.
+func NewHedge(stake float64, price float64, leg Leg) (Hedge, error) {
+	if stake < MinStake {
+		return Hedge{}, ErrStakeBelowMinimum
+	}
+	if price <= 1 {
+		return Hedge{}, ErrInvalidPrice
+	}
+
+	payout := stake * price
+	hedgeStake := payout / leg.selection.Price()
+	leg, err := leg.withStake(hedgeStake)
+	if err != nil {
+		return Hedge{}, err
+	}
+
+	return Hedge{
+		leg:    leg,
+		profit: payout - stake - hedgeStake,
+	}, nil
+}
+
+type Dutching struct {
+	// selections with stakes returning the same whichever wins
+	legs []Leg
+	// sum of all stakes in EUR
+	totalStake float64
+	// return in EUR if any of the selections wins
+	payout float64
+}
+
+func (d Dutching) Legs() []Leg {
+	return d.legs
+}
+
+func (d Dutching) TotalStake() float64 {
+	return d.totalStake
+}
+
+func (d Dutching) Payout() float64 {
+	return d.payout
+}
+
+func (d Dutching) Profit() float64 {
+	return d.payout - d.totalStake
+}
+
+// NewDutching split totalStake over mutually exclusive selections so that every selection returns the same
+func NewDutching(legs []Leg, totalStake float64) (Dutching, error) {
+	if len(legs) < 2 {
+		return Dutching{}, ErrTooFewSelections
+	}
+	if totalStake < MinStake {
+		return Dutching{}, ErrStakeBelowMinimum
+	}
+
+	var impliedProbability float64
+	seen := make(map[event.Reference]bool)
+	for _, leg := range legs {
+		if seen[leg.reference] {
+			return Dutching{}, ErrDuplicateSelections
+		}
+		seen[leg.reference] = true
+		impliedProbability += 1 / leg.selection.Price()
+	}
+
+	result := make([]Leg, len(legs))
+	for i, leg := range legs {
+		stake := totalStake / (leg.selection.Price() * impliedProbability)
+		leg, err := leg.withStake(stake)
+		if err != nil {
+			return Dutching{}, err
+		}
+		result[i] = leg
+	}
+
+	return Dutching{
+		legs:       result,
+		totalStake: totalStake,
+		payout:     totalStake / impliedProbability,
+	}, nil
+}
+
+type Parlay struct {
+	// legs of the accumulator, each carrying the accumulator stake
+	legs []Leg
+	// combined price, product of all leg prices
+	price float64
+	// stake in EUR on the accumulator
+	stake float64
+}
+
+func (p Parlay) Legs() []Leg {
+	return p.legs
+}
+
+func (p Parlay) Price() float64 {
+	return p.price
+}
+
+func (p Parlay) Stake() float64 {
+	return p.stake
+}
+
+func (p Parlay) Payout() float64 {
+	return p.stake * p.price
+}
+
+// NewParlay combine selections of different events into an accumulator, the stake is limited by the smallest leg max stake
+func NewParlay(legs []Leg, stake float64) (Parlay, error) {
+	if len(legs) < 2 {
+		return Parlay{}, ErrTooFewSelections
+	}
+
+	price := 1.0
+	seen := make(map[string]bool)
+	result := make([]Leg, len(legs))
+	for i, leg := range legs {
+		if seen[leg.reference.EventKey()] {
+			return Parlay{}, ErrDuplicateEvent
+		}
+		seen[leg.reference.EventKey()] = true
+
+		leg, err := leg.withStake(stake)
+		if err != nil {
+			return Parlay{}, err
+		}
+		result[i] = leg
+		price *= leg.selection.Price()
+	}
+
+	return Parlay{
+		legs:  result,
+		price: price,
+		stake: stake,
+	}, nil
+}
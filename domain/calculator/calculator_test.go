@@ -0,0 +1,217 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+const testMarketKey = "soccer.match_odds"
+
+// newTestLeg resolve a selection of outcome priced at price on a single selection event
+func newTestLeg(t *testing.T, eventKey string, outcome string, price float64, maxStake float64) Leg {
+	t.Helper()
+	leg, err := newLeg(eventKey, true, event.NewSelection(outcome, "", price, maxStake, 1/price, "SELECTION_ENABLED", "BACK"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return leg
+}
+
+func newLeg(eventKey string, active bool, selection event.Selection) (Leg, error) {
+	sport, _ := event.NewIdentifier("Soccer", "soccer")
+	category, _ := event.NewIdentifier("England", "england")
+	competition, _ := event.NewIdentifier("Premier League", "soccer-england-premier-league")
+	markets := map[string]event.Market{
+		testMarketKey: event.NewMarket(map[string][]event.Selection{"period=ft": {selection}}),
+	}
+	e, err := event.NewEvent(&sport, &competition, &category, event.TeamIdentifier{}, event.TeamIdentifier{}, active, false, markets, eventKey, eventKey, time.Now().Add(time.Hour))
+	if err != nil {
+		return Leg{}, err
+	}
+	reference, err := event.NewReference(eventKey, testMarketKey, selection.Outcome(), selection.Params())
+	if err != nil {
+		return Leg{}, err
+	}
+	return NewLeg(reference, *e, selection)
+}
+
+func almostEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestNewLeg(t *testing.T) {
+	tests := []struct {
+		name      string
+		active    bool
+		selection event.Selection
+		err       error
+	}{
+		{"valid", true, event.NewSelection("home", "", 2, 100, 0.5, "SELECTION_ENABLED", "BACK"), nil},
+		{"inactive event", false, event.NewSelection("home", "", 2, 100, 0.5, "SELECTION_ENABLED", "BACK"), ErrEventInactive},
+		{"disabled selection", true, event.NewSelection("home", "", 2, 100, 0.5, "SELECTION_DISABLED", "BACK"), ErrSelectionDisabled},
+		{"price of 1", true, event.NewSelection("home", "", 1, 100, 1, "SELECTION_ENABLED", "BACK"), ErrInvalidPrice},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := newLeg("event", tt.active, tt.selection); !errors.Is(err, tt.err) {
+				t.Errorf("NewLeg() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestNewKelly(t *testing.T) {
+	tests := []struct {
+		name        string
+		price       float64
+		maxStake    float64
+		probability float64
+		multiplier  float64
+		fraction    float64
+		stake       float64
+	}{
+		{"edge", 2.5, 1000, 0.5, 1, 0.25 / 1.5, 1000 * 0.25 / 1.5},
+		{"fractional", 2.5, 1000, 0.5, 0.5, 0.25 / 3, 1000 * 0.25 / 3},
+		{"capped at max stake", 2.5, 50, 0.5, 1, 0.25 / 1.5, 50},
+		{"no edge", 2, 1000, 0.4, 1, 0, 0},
+		{"below minimum stake", 2, 1000, 0.500001, 1, 0.000002, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kelly, err := NewKelly(newTestLeg(t, "event", "home", tt.price, tt.maxStake), tt.probability, 1000, tt.multiplier)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !almostEqual(kelly.Edge(), tt.price*tt.probability-1) || !almostEqual(kelly.Fraction(), tt.fraction) || !almostEqual(kelly.Leg().Stake(), tt.stake) {
+				t.Errorf("NewKelly() edge %f fraction %f stake %f, want fraction %f stake %f", kelly.Edge(), kelly.Fraction(), kelly.Leg().Stake(), tt.fraction, tt.stake)
+			}
+		})
+	}
+
+	leg := newTestLeg(t, "event", "home", 2, 100)
+	for _, invalid := range []struct {
+		probability float64
+		bankroll    float64
+		multiplier  float64
+		err         error
+	}{
+		{0, 1000, 1, ErrInvalidProbability},
+		{1.1, 1000, 1, ErrInvalidProbability},
+		{0.5, 0, 1, ErrInvalidBankroll},
+		{0.5, 1000, 0, ErrInvalidFraction},
+		{0.5, 1000, 1.5, ErrInvalidFraction},
+	} {
+		if _, err := NewKelly(leg, invalid.probability, invalid.bankroll, invalid.multiplier); !errors.Is(err, invalid.err) {
+			t.Errorf("NewKelly(%f, %f, %f) error = %v, want %v", invalid.probability, invalid.bankroll, invalid.multiplier, err, invalid.err)
+		}
+	}
+}
+
+func TestNewHedge(t *testing.T) {
+	hedge, err := NewHedge(10, 3.5, newTestLeg(t, "event", "away", 1.5, 100))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hedgeStake := hedge.Leg().Stake()
+	if !almostEqual(hedgeStake, 35/1.5) {
+		t.Errorf("hedge stake %f, want %f", hedgeStake, 35/1.5)
+	}
+	// the same profit whichever bet wins
+	existingWins := 10*3.5 - 10 - hedgeStake
+	hedgeWins := hedgeStake*1.5 - hedgeStake - 10
+	if !almostEqual(hedge.Profit(), existingWins) || !almostEqual(hedge.Profit(), hedgeWins) {
+		t.Errorf("Profit() = %f, want %f and %f", hedge.Profit(), existingWins, hedgeWins)
+	}
+
+	for _, invalid := range []struct {
+		stake float64
+		price float64
+		err   error
+	}{
+		{0.001, 3.5, ErrStakeBelowMinimum},
+		{10, 1, ErrInvalidPrice},
+		{1000, 3.5, ErrStakeAboveMaximum},
+	} {
+		if _, err := NewHedge(invalid.stake, invalid.price, newTestLeg(t, "event", "away", 1.5, 100)); !errors.Is(err, invalid.err) {
+			t.Errorf("NewHedge(%f, %f) error = %v, want %v", invalid.stake, invalid.price, err, invalid.err)
+		}
+	}
+}
+
+func TestNewDutching(t *testing.T) {
+	dutching, err := NewDutching([]Leg{
+		newTestLeg(t, "event", "home", 2, 100),
+		newTestLeg(t, "event", "draw", 4, 100),
+	}, 30)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !almostEqual(dutching.Payout(), 40) || !almostEqual(dutching.Profit(), 10) {
+		t.Errorf("Payout() = %f, Profit() = %f, want 40 and 10", dutching.Payout(), dutching.Profit())
+	}
+	total := 0.0
+	for _, leg := range dutching.Legs() {
+		total += leg.Stake()
+		if !almostEqual(leg.Stake()*leg.Selection().Price(), dutching.Payout()) {
+			t.Errorf("%s returns %f, want %f", leg.Selection().Outcome(), leg.Stake()*leg.Selection().Price(), dutching.Payout())
+		}
+	}
+	if !almostEqual(total, 30) {
+		t.Errorf("stakes sum to %f, want 30", total)
+	}
+
+	home := newTestLeg(t, "event", "home", 2, 100)
+	for _, invalid := range []struct {
+		name  string
+		legs  []Leg
+		stake float64
+		err   error
+	}{
+		{"single selection", []Leg{home}, 30, ErrTooFewSelections},
+		{"duplicate selection", []Leg{home, home}, 30, ErrDuplicateSelections},
+		{"stake below minimum", []Leg{home, newTestLeg(t, "event", "draw", 4, 100)}, 0, ErrStakeBelowMinimum},
+		{"stake above maximum", []Leg{home, newTestLeg(t, "event", "draw", 4, 5)}, 30, ErrStakeAboveMaximum},
+	} {
+		if _, err := NewDutching(invalid.legs, invalid.stake); !errors.Is(err, invalid.err) {
+			t.Errorf("%s: NewDutching() error = %v, want %v", invalid.name, err, invalid.err)
+		}
+	}
+}
+
+func TestNewParlay(t *testing.T) {
+	parlay, err := NewParlay([]Leg{
+		newTestLeg(t, "first", "home", 1.5, 100),
+		newTestLeg(t, "second", "away", 2, 100),
+		newTestLeg(t, "third", "home", 3, 100),
+	}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(parlay.Price(), 9) || !almostEqual(parlay.Payout(), 90) {
+		t.Errorf("Price() = %f, Payout() = %f, want 9 and 90", parlay.Price(), parlay.Payout())
+	}
+
+	first := newTestLeg(t, "first", "home", 1.5, 100)
+	for _, invalid := range []struct {
+		name  string
+		legs  []Leg
+		stake float64
+		err   error
+	}{
+		{"single leg", []Leg{first}, 10, ErrTooFewSelections},
+		{"same event", []Leg{first, newTestLeg(t, "first", "away", 2, 100)}, 10, ErrDuplicateEvent},
+		{"stake above a leg max stake", []Leg{first, newTestLeg(t, "second", "away", 2, 5)}, 10, ErrStakeAboveMaximum},
+	} {
+		if _, err := NewParlay(invalid.legs, invalid.stake); !errors.Is(err, invalid.err) {
+			t.Errorf("%s: NewParlay() error = %v, want %v", invalid.name, err, invalid.err)
+		}
+	}
+}
@@ -1,16 +1,31 @@
 package category
 
+import (
+	"github.com/awcjack/cloudbet/domain/activity"
+)
+
+// Category group competitions of a sport, usually by country. The same category key can appear under several sports,
+// e.g. international, so a Category listed under a sport only covers that sport.
 type Category struct {
 	// name of this Identifier
 	name string
 	// slug for this Identifier
 	key string
+	// sports having competitions in this Category
+	sportKeys []string
+	// number of competitions in this Category
+	competitionCount int
+	// events in this Category
+	activity activity.Activity
 }
 
-func NewCategory(name string, key string) Category {
+func NewCategory(name string, key string, sportKeys []string, competitionCount int, activity activity.Activity) Category {
 	return Category{
-		name: name,
-		key:  key,
+		name:             name,
+		key:              key,
+		sportKeys:        sportKeys,
+		competitionCount: competitionCount,
+		activity:         activity,
 	}
 }
 
@@ -21,3 +36,15 @@ func (c Category) Key() string {
 func (c Category) Name() string {
 	return c.name
 }
+
+func (c Category) SportKeys() []string {
+	return c.sportKeys
+}
+
+func (c Category) CompetitionCount() int {
+	return c.competitionCount
+}
+
+func (c Category) Activity() activity.Activity {
+	return c.activity
+}
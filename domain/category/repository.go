@@ -2,9 +2,15 @@ package category
 
 import (
 	"context"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+	"github.com/awcjack/cloudbet/domain/pagination"
 )
 
+var ErrCategoryNotFound = failure.New(failure.NotFound, "category_not_found", "category not found")
+
 type Repository interface {
-	ListCategories(ctx context.Context, first int, page int, sportKey string) ([]Category, error)
+	// ListCategories list every category, or with sportKey the categories of that sport counting only its competitions and events
+	ListCategories(ctx context.Context, request pagination.Request, sportKey string) ([]Category, pagination.Info, error)
 	GetCategory(ctx context.Context, categoryKey string) (Category, error)
 }
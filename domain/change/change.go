@@ -0,0 +1,92 @@
+package change
+
+import (
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+const (
+	TypeCreated     = "created"
+	TypeUpdated     = "updated"
+	TypeInactivated = "inactivated"
+	// the event was evicted from the repository, the Change carries no Event
+	TypeDeleted = "deleted"
+)
+
+// Change record the latest modification of an event. The log is compacted, so older changes of the same event are dropped
+// and the Event is its state as of the latest change.
+type Change struct {
+	// global sequence of this Change, strictly increasing across all events
+	sequence uint64
+	// one of TypeCreated, TypeUpdated, TypeInactivated, TypeDeleted
+	kind string
+	// slug of the changed event
+	eventKey string
+	// time the Change happened
+	time time.Time
+	// state of the event, nil for TypeDeleted
+	event *event.Event
+}
+
+func NewChange(sequence uint64, kind string, eventKey string, time time.Time, e *event.Event) Change {
+	return Change{
+		sequence: sequence,
+		kind:     kind,
+		eventKey: eventKey,
+		time:     time,
+		event:    e,
+	}
+}
+
+func (c Change) Sequence() uint64 {
+	return c.sequence
+}
+
+func (c Change) Type() string {
+	return c.kind
+}
+
+func (c Change) EventKey() string {
+	return c.eventKey
+}
+
+func (c Change) Time() time.Time {
+	return c.time
+}
+
+func (c Change) Event() (event.Event, bool) {
+	if c.event == nil {
+		return event.Event{}, false
+	}
+	return *c.event, true
+}
+
+// List is a page of the change feed
+type List struct {
+	changes []Change
+	// latest sequence assigned when the changes were listed
+	latestSequence uint64
+	// identify the change log the sequences belong to, sequences of different epochs are unrelated
+	epoch string
+}
+
+func NewList(changes []Change, latestSequence uint64, epoch string) List {
+	return List{
+		changes:        changes,
+		latestSequence: latestSequence,
+		epoch:          epoch,
+	}
+}
+
+func (l List) Changes() []Change {
+	return l.changes
+}
+
+func (l List) LatestSequence() uint64 {
+	return l.latestSequence
+}
+
+func (l List) Epoch() string {
+	return l.epoch
+}
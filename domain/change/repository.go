@@ -0,0 +1,24 @@
+package change
+
+import (
+	"context"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+var (
+	ErrSequenceExpired = failure.New(failure.Gone, "sequence_expired", "changes since this sequence were compacted away, resync from sequence 0")
+	ErrInvalidLimit    = failure.New(failure.Invalid, "invalid_limit", "limit cannot be smaller than 1 or larger than 1000")
+)
+
+// MaxLimit is the largest number of changes returned at once
+const MaxLimit = 1000
+
+type Repository interface {
+	// ListChanges return up to limit changes with sequence larger than since in sequence order, with the latest sequence
+	// assigned and the epoch of the log, which changes whenever sequences restart, e.g. after a restart
+	ListChanges(ctx context.Context, since uint64, limit int) (List, error)
+	// PruneTombstones drop deletion records older than before, later ListChanges since an older sequence other than 0 fail with ErrSequenceExpired
+	PruneTombstones(ctx context.Context, before time.Time) error
+}
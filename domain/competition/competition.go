@@ -1,16 +1,29 @@
 package competition
 
+import (
+	"github.com/awcjack/cloudbet/domain/activity"
+)
+
 type Competition struct {
 	// name of this Identifier
 	name string
 	// slug for this Identifier
 	key string
+	// sport this Competition belongs to
+	sportKey string
+	// category this Competition belongs to within its sport
+	categoryKey string
+	// events of this Competition
+	activity activity.Activity
 }
 
-func NewCompetition(name string, key string) Competition {
+func NewCompetition(name string, key string, sportKey string, categoryKey string, activity activity.Activity) Competition {
 	return Competition{
-		name: name,
-		key:  key,
+		name:        name,
+		key:         key,
+		sportKey:    sportKey,
+		categoryKey: categoryKey,
+		activity:    activity,
 	}
 }
 
@@ -21,3 +34,15 @@ func (c Competition) Key() string {
 func (c Competition) Name() string {
 	return c.name
 }
+
+func (c Competition) SportKey() string {
+	return c.sportKey
+}
+
+func (c Competition) CategoryKey() string {
+	return c.categoryKey
+}
+
+func (c Competition) Activity() activity.Activity {
+	return c.activity
+}
@@ -2,9 +2,20 @@ package competition
 
 import (
 	"context"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+	"github.com/awcjack/cloudbet/domain/pagination"
 )
 
+var ErrCompetitionNotFound = failure.New(failure.NotFound, "competition_not_found", "competition not found")
+
+// Filter narrow down the competitions returned by ListCompetitions, empty fields are ignored
+type Filter struct {
+	SportKey    string
+	CategoryKey string
+}
+
 type Repository interface {
-	ListCompetitions(ctx context.Context, first int, page int, sportKey string) ([]Competition, error)
+	ListCompetitions(ctx context.Context, request pagination.Request, filter Filter) ([]Competition, pagination.Info, error)
 	GetCompetition(ctx context.Context, competitionKey string) (Competition, error)
 }
@@ -0,0 +1,324 @@
+package crawl
+
+import (
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+const (
+	// crawl every sport, category and competition
+	KindFull = "full"
+	// crawl the events of a single competition
+	KindCompetition = "competition"
+
+	// started by the schedule
+	TriggerScheduled = "scheduled"
+	// requested by an admin
+	TriggerManual = "manual"
+
+	// the event did not exist before the crawl
+	EventCreated = "created"
+	// the crawl changed the content of the event
+	EventUpdated = "updated"
+	// the crawl fetched the event as it was already stored
+	EventUnchanged = "unchanged"
+
+	// item kinds of an ItemError
+	ItemSport       = "sport"
+	ItemCompetition = "competition"
+	ItemEvent       = "event"
+
+	// maximum number of runs listed at once
+	MaxRuns = 50
+	// number of ItemError kept per run, further errors are only counted
+	MaxRunErrors = 100
+	// bounds of the crawl and cutoff check intervals
+	MinInterval = time.Second
+	MaxInterval = 24 * time.Hour
+)
+
+var (
+	ErrCrawlInProgress = failure.New(failure.Conflict, "crawl_in_progress", "a crawl is already running or requested")
+	ErrCrawlerStopped  = failure.New(failure.Conflict, "crawler_stopped", "crawler is not running on this instance")
+	ErrInvalidInterval = failure.New(failure.Invalid, "invalid_interval", "interval cannot be shorter than 1 second or longer than 24 hours")
+	ErrInvalidRunCount = failure.New(failure.Invalid, "invalid_run_count", "run count cannot be smaller than 1 or larger than 50")
+	ErrRunNotFound     = failure.New(failure.NotFound, "crawl_run_not_found", "crawl run not found")
+	ErrInvalidFilter   = failure.New(failure.Invalid, "invalid_filter", "kind must be full or competition and trigger must be scheduled or manual")
+)
+
+// ItemError is the failure of a crawl to fetch or store an item
+type ItemError struct {
+	time time.Time
+	// sport, competition or event
+	kind string
+	// key of the failed item, empty when unknown e.g. listing the sports
+	key string
+	// HTTP status code returned by Cloudbet, 0 when no response was received or the item failed locally
+	statusCode int
+	message    string
+}
+
+func NewItemError(time time.Time, kind string, key string, statusCode int, message string) ItemError {
+	return ItemError{
+		time:       time,
+		kind:       kind,
+		key:        key,
+		statusCode: statusCode,
+		message:    message,
+	}
+}
+
+func (e ItemError) Time() time.Time {
+	return e.time
+}
+
+func (e ItemError) Kind() string {
+	return e.kind
+}
+
+func (e ItemError) Key() string {
+	return e.key
+}
+
+func (e ItemError) StatusCode() int {
+	return e.statusCode
+}
+
+func (e ItemError) Message() string {
+	return e.message
+}
+
+// Filter select runs, zero values select everything
+type Filter struct {
+	// list runs with a smaller ID only, for paging from the latest run backward
+	Before uint64
+	// full or competition
+	Kind string
+	// scheduled or manual
+	Trigger string
+	// list runs with at least one error only
+	Failed bool
+}
+
+// Run is a crawl, in progress until it is finished
+type Run struct {
+	// increasing identifier of this Run since start
+	id uint64
+	// full or competition
+	kind string
+	// crawled competition of a competition Run
+	competitionKey string
+	// scheduled or manual
+	trigger    string
+	startedAt  time.Time
+	finishedAt time.Time
+	// competitions found so far, grows while a full Run discovers sports
+	competitionsTotal int
+	competitionsDone  int
+	// competitions done whose events could not be fetched
+	competitionsFailed int
+	sportsFetched      int
+	eventsFetched      int
+	eventsCreated      int
+	eventsUpdated      int
+	eventsUnchanged    int
+	errorCount         int
+	// first errors of the run, up to MaxRunErrors
+	errors []ItemError
+}
+
+func NewRun(id uint64, kind string, competitionKey string, trigger string, startedAt time.Time) Run {
+	return Run{
+		id:             id,
+		kind:           kind,
+		competitionKey: competitionKey,
+		trigger:        trigger,
+		startedAt:      startedAt,
+	}
+}
+
+func (r Run) ID() uint64 {
+	return r.id
+}
+
+func (r Run) Kind() string {
+	return r.kind
+}
+
+func (r Run) CompetitionKey() string {
+	return r.competitionKey
+}
+
+func (r Run) Trigger() string {
+	return r.trigger
+}
+
+func (r Run) StartedAt() time.Time {
+	return r.startedAt
+}
+
+func (r Run) FinishedAt() time.Time {
+	return r.finishedAt
+}
+
+func (r Run) Finished() bool {
+	return !r.finishedAt.IsZero()
+}
+
+func (r Run) CompetitionsTotal() int {
+	return r.competitionsTotal
+}
+
+func (r Run) CompetitionsDone() int {
+	return r.competitionsDone
+}
+
+func (r Run) CompetitionsFailed() int {
+	return r.competitionsFailed
+}
+
+// Refreshed report whether the events of at least one competition were fetched
+func (r Run) Refreshed() bool {
+	return r.competitionsDone > r.competitionsFailed
+}
+
+func (r Run) EventsFetched() int {
+	return r.eventsFetched
+}
+
+func (r Run) ErrorCount() int {
+	return r.errorCount
+}
+
+func (r Run) SportsFetched() int {
+	return r.sportsFetched
+}
+
+func (r Run) EventsCreated() int {
+	return r.eventsCreated
+}
+
+func (r Run) EventsUpdated() int {
+	return r.eventsUpdated
+}
+
+func (r Run) EventsUnchanged() int {
+	return r.eventsUnchanged
+}
+
+func (r Run) Errors() []ItemError {
+	return r.errors
+}
+
+// Matches report whether the run is selected by filter
+func (r Run) Matches(filter Filter) bool {
+	if filter.Before != 0 && r.id >= filter.Before {
+		return false
+	}
+	if filter.Kind != "" && r.kind != filter.Kind {
+		return false
+	}
+	if filter.Trigger != "" && r.trigger != filter.Trigger {
+		return false
+	}
+	return !filter.Failed || r.errorCount > 0
+}
+
+func (r *Run) AddCompetitions(count int) {
+	r.competitionsTotal += count
+}
+
+// CompetitionDone count a crawled competition and its fetched events
+func (r *Run) CompetitionDone(events int) {
+	r.competitionsDone++
+	r.eventsFetched += events
+}
+
+func (r *Run) SportFetched() {
+	r.sportsFetched++
+}
+
+// EventStored count a stored event by the result of storing it, created, updated or unchanged
+func (r *Run) EventStored(result string) {
+	switch result {
+	case EventCreated:
+		r.eventsCreated++
+	case EventUpdated:
+		r.eventsUpdated++
+	case EventUnchanged:
+		r.eventsUnchanged++
+	}
+}
+
+func (r *Run) Fail(err ItemError) {
+	r.errorCount++
+	if err.Kind() == ItemCompetition {
+		r.competitionsFailed++
+	}
+	if len(r.errors) < MaxRunErrors {
+		r.errors = append(r.errors, err)
+	}
+}
+
+func (r *Run) Finish(at time.Time) {
+	r.finishedAt = at
+}
+
+// State is a snapshot of the crawl schedule
+type State struct {
+	// the crawler runs on this instance, false on replicas
+	enabled bool
+	// scheduled crawls and cutoff checks are suspended, manual crawls still run
+	paused bool
+	// interval between scheduled full crawls
+	interval time.Duration
+	// interval between checks of events close to their cutoff
+	cutOffInterval time.Duration
+	// time of the next scheduled crawl, zero when paused or disabled
+	nextRun time.Time
+	// nil when no crawl is in progress
+	current *Run
+	// latest finished runs, latest first
+	runs []Run
+}
+
+func NewState(enabled bool, paused bool, interval time.Duration, cutOffInterval time.Duration, nextRun time.Time, current *Run, runs []Run) State {
+	return State{
+		enabled:        enabled,
+		paused:         paused,
+		interval:       interval,
+		cutOffInterval: cutOffInterval,
+		nextRun:        nextRun,
+		current:        current,
+		runs:           runs,
+	}
+}
+
+func (s State) Enabled() bool {
+	return s.enabled
+}
+
+func (s State) Paused() bool {
+	return s.paused
+}
+
+func (s State) Interval() time.Duration {
+	return s.interval
+}
+
+func (s State) CutOffInterval() time.Duration {
+	return s.cutOffInterval
+}
+
+func (s State) NextRun() time.Time {
+	return s.nextRun
+}
+
+func (s State) Current() *Run {
+	return s.current
+}
+
+func (s State) Runs() []Run {
+	return s.runs
+}
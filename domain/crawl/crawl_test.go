@@ -0,0 +1,98 @@
+package crawl
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRefreshed(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	competitionError := NewItemError(now, ItemCompetition, "soccer-england-premier-league", 500, "internal error")
+
+	tests := []struct {
+		name   string
+		update func(r *Run)
+		want   bool
+	}{
+		{
+			name:   "nothing crawled",
+			update: func(r *Run) {},
+		},
+		{
+			name: "sports listing failed",
+			update: func(r *Run) {
+				r.Fail(NewItemError(now, ItemSport, "", 503, "unavailable"))
+			},
+		},
+		{
+			name: "every competition failed",
+			update: func(r *Run) {
+				r.AddCompetitions(2)
+				for i := 0; i < 2; i++ {
+					r.Fail(competitionError)
+					r.CompetitionDone(0)
+				}
+			},
+		},
+		{
+			name: "one competition fetched",
+			update: func(r *Run) {
+				r.AddCompetitions(2)
+				r.Fail(competitionError)
+				r.CompetitionDone(0)
+				r.CompetitionDone(12)
+			},
+			want: true,
+		},
+		{
+			name: "events failing to store",
+			update: func(r *Run) {
+				r.AddCompetitions(1)
+				r.Fail(NewItemError(now, ItemEvent, "arsenal-v-chelsea", 0, "invalid event"))
+				r.CompetitionDone(1)
+			},
+			want: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			run := NewRun(1, KindFull, "", TriggerScheduled, now)
+			tt.update(&run)
+			if got := run.Refreshed(); got != tt.want {
+				t.Errorf("Refreshed() = %t with %d of %d competitions failed, want %t", got, run.CompetitionsFailed(), run.CompetitionsDone(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunCounts(t *testing.T) {
+	now := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	r := NewRun(1, KindFull, "", TriggerScheduled, now)
+	for _, result := range []string{EventCreated, EventUpdated, EventUpdated, EventUnchanged, "unknown"} {
+		r.EventStored(result)
+	}
+	for i := 0; i < MaxRunErrors+5; i++ {
+		r.Fail(NewItemError(now, ItemEvent, "arsenal-v-chelsea", 0, "store failed"))
+	}
+
+	tests := []struct {
+		name string
+		got  int
+		want int
+	}{
+		{name: "created", got: r.EventsCreated(), want: 1},
+		{name: "updated", got: r.EventsUpdated(), want: 2},
+		{name: "unchanged", got: r.EventsUnchanged(), want: 1},
+		{name: "error count", got: r.ErrorCount(), want: MaxRunErrors + 5},
+		{name: "errors kept", got: len(r.Errors()), want: MaxRunErrors},
+		{name: "event errors not counted as failed competitions", got: r.CompetitionsFailed(), want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %d, want %d", tt.got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package crawl
+
+import (
+	"context"
+)
+
+type Repository interface {
+	// SaveRun store a finished run, dropping the oldest runs beyond the retention of the repository
+	SaveRun(ctx context.Context, run Run) error
+	GetRun(ctx context.Context, id uint64) (Run, error)
+	// ListRuns return up to first runs matching filter, latest first
+	ListRuns(ctx context.Context, filter Filter, first int) ([]Run, error)
+}
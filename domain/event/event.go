@@ -1,8 +1,10 @@
 package event
 
 import (
-	"errors"
+	"reflect"
 	"time"
+
+	"github.com/awcjack/cloudbet/domain/failure"
 )
 
 type Event struct {
@@ -18,8 +20,13 @@ type Event struct {
 	away TeamIdentifier
 	// the event trading still active or not
 	active bool
+	// the event is in TRADING_LIVE status
+	live bool
 	// mapping between market key and all associated markets for this Event
 	markets map[string]Market
+	// average margin over every priced line, only meaningful when priced
+	margin float64
+	priced bool
 	// name of this Event
 	name string
 	// slug for this Event
@@ -30,17 +37,23 @@ type Event struct {
 	startTradingLiveTime time.Time
 	// event inactive time
 	inactiveTime time.Time
+	// revision of the repository when this Event last changed, 0 until saved
+	version uint64
+	// identify the change log the version belongs to, versions restart with a new epoch
+	epoch string
+	// time when this Event last changed
+	lastUpdated time.Time
 }
 
 var (
-	ErrMissingKey         = errors.New("missing event key")
-	ErrMissingSport       = errors.New("missing sport info")
-	ErrMissingCompetition = errors.New("missing competition info")
-	ErrMissingCategory    = errors.New("missing category info")
-	ErrMissingCutOffTime  = errors.New("missing cut off time")
+	ErrMissingKey         = failure.New(failure.Invalid, "missing_event_key", "missing event key")
+	ErrMissingSport       = failure.New(failure.Invalid, "missing_sport", "missing sport info")
+	ErrMissingCompetition = failure.New(failure.Invalid, "missing_competition", "missing competition info")
+	ErrMissingCategory    = failure.New(failure.Invalid, "missing_category", "missing category info")
+	ErrMissingCutOffTime  = failure.New(failure.Invalid, "missing_cutoff_time", "missing cut off time")
 )
 
-func NewEvent(sport *Identifier, competition *Identifier, category *Identifier, home TeamIdentifier, away TeamIdentifier, active bool, markets map[string]Market, name string, key string, cutOffTime time.Time) (*Event, error) {
+func NewEvent(sport *Identifier, competition *Identifier, category *Identifier, home TeamIdentifier, away TeamIdentifier, active bool, live bool, markets map[string]Market, name string, key string, cutOffTime time.Time) (*Event, error) {
 	if key == "" {
 		return nil, ErrMissingKey
 	}
@@ -58,9 +71,10 @@ func NewEvent(sport *Identifier, competition *Identifier, category *Identifier,
 	}
 
 	var startTradingLiveTime time.Time
-	if active {
+	if live {
 		startTradingLiveTime = time.Now()
 	}
+	margin, priced := averageMargin(markets)
 
 	return &Event{
 		sport:                sport,
@@ -69,7 +83,10 @@ func NewEvent(sport *Identifier, competition *Identifier, category *Identifier,
 		home:                 home,
 		away:                 away,
 		active:               active,
+		live:                 active && live,
 		markets:              markets,
+		margin:               margin,
+		priced:               priced,
 		name:                 name,
 		key:                  key,
 		cutoffTime:           cutOffTime,
@@ -102,12 +119,17 @@ func (e Event) Active() bool {
 	return e.active
 }
 
+func (e Event) Live() bool {
+	return e.live
+}
+
 func (e Event) Market() map[string]Market {
 	return e.markets
 }
 
 func (e *Event) Inactivate() {
 	e.active = false
+	e.live = false
 	e.inactiveTime = time.Now()
 }
 
@@ -134,3 +156,41 @@ func (e *Event) SetstartTradingLiveTime(time time.Time) {
 func (e Event) InactiveTime() time.Time {
 	return e.inactiveTime
 }
+
+func (e *Event) SetInactiveTime(time time.Time) {
+	e.inactiveTime = time
+}
+
+func (e Event) Version() uint64 {
+	return e.version
+}
+
+func (e Event) Epoch() string {
+	return e.epoch
+}
+
+func (e Event) LastUpdated() time.Time {
+	return e.lastUpdated
+}
+
+// SetVersion is called by repositories when the content of this Event changed
+func (e *Event) SetVersion(epoch string, version uint64, lastUpdated time.Time) {
+	e.epoch = epoch
+	e.version = version
+	e.lastUpdated = lastUpdated
+}
+
+// SameContent report whether e and other carry the same crawled data, ignoring timestamps and version
+func (e Event) SameContent(other Event) bool {
+	return e.key == other.key &&
+		e.name == other.name &&
+		*e.sport == *other.sport &&
+		*e.competition == *other.competition &&
+		*e.category == *other.category &&
+		e.home == other.home &&
+		e.away == other.away &&
+		e.active == other.active &&
+		e.live == other.live &&
+		e.cutoffTime.Equal(other.cutoffTime) &&
+		reflect.DeepEqual(e.markets, other.markets)
+}
@@ -0,0 +1,232 @@
+package event
+
+import (
+	"errors"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+var (
+	ErrMarketNotFound    = failure.New(failure.NotFound, "market_not_found", "market not found")
+	ErrSubmarketNotFound = failure.New(failure.NotFound, "submarket_not_found", "submarket not found")
+	ErrNoLines           = failure.New(failure.NotFound, "no_lines", "market has no handicap or total lines")
+)
+
+// params keys which carry the line value of handicap/totals selections
+var lineParams = []string{"handicap", "total"}
+
+type Line struct {
+	// line value parsed from selection params, e.g. -1.5 for handicap=-1.5
+	value float64
+	// parameters shared by all selections on this Line
+	params string
+	// back side selections quoted on this Line
+	selections []Selection
+	// bookmaker margin of this Line, sum of implied probabilities - 1
+	margin float64
+}
+
+func (l Line) Value() float64 {
+	return l.value
+}
+
+func (l Line) Params() string {
+	return l.params
+}
+
+func (l Line) Selections() []Selection {
+	return l.selections
+}
+
+func (l Line) Margin() float64 {
+	return l.margin
+}
+
+type Ladder struct {
+	// submarket key of this Ladder
+	submarket string
+	// lines sorted by line value ascending
+	lines []Line
+	// index of the line where both sides' prices are closest to even, -1 if no line qualifies
+	mainLine int
+}
+
+func (l Ladder) Submarket() string {
+	return l.submarket
+}
+
+func (l Ladder) Lines() []Line {
+	return l.lines
+}
+
+// MainLine return the line where prices are closest to even
+func (l Ladder) MainLine() (Line, bool) {
+	if l.mainLine < 0 {
+		return Line{}, false
+	}
+	return l.lines[l.mainLine], true
+}
+
+// NewLadder group selections of a submarket by their handicap/total line
+func NewLadder(submarket string, selections []Selection) (Ladder, error) {
+	lines := make(map[string]*Line)
+	for _, selection := range selections {
+		if selection.Side() != "" && selection.Side() != "BACK" {
+			continue
+		}
+		value, ok := parseLine(selection.Params())
+		if !ok {
+			continue
+		}
+		line, ok := lines[selection.Params()]
+		if !ok {
+			line = &Line{
+				value:  value,
+				params: selection.Params(),
+			}
+			lines[selection.Params()] = line
+		}
+		line.selections = append(line.selections, selection)
+	}
+	if len(lines) == 0 {
+		return Ladder{}, ErrNoLines
+	}
+
+	ladder := Ladder{
+		submarket: submarket,
+		lines:     make([]Line, 0, len(lines)),
+		mainLine:  -1,
+	}
+	for _, line := range lines {
+		line.margin = Margin(line.selections)
+		ladder.lines = append(ladder.lines, *line)
+	}
+	sort.Slice(ladder.lines, func(i, j int) bool {
+		return ladder.lines[i].value < ladder.lines[j].value
+	})
+
+	bestDiff := math.Inf(1)
+	for i, line := range ladder.lines {
+		if len(line.selections) != 2 {
+			continue
+		}
+		first, second := line.selections[0], line.selections[1]
+		if first.Status() == "SELECTION_DISABLED" || second.Status() == "SELECTION_DISABLED" {
+			continue
+		}
+		diff := math.Abs(first.Price() - second.Price())
+		if diff < bestDiff {
+			bestDiff = diff
+			ladder.mainLine = i
+		}
+	}
+
+	return ladder, nil
+}
+
+// Ladders build the ladder of every submarket under marketKey, or only submarketKey when it is not empty
+func (e Event) Ladders(marketKey string, submarketKey string) ([]Ladder, error) {
+	market, ok := e.markets[marketKey]
+	if !ok {
+		return nil, ErrMarketNotFound
+	}
+
+	var keys []string
+	if submarketKey != "" {
+		if _, ok := market.Submarkets()[submarketKey]; !ok {
+			return nil, ErrSubmarketNotFound
+		}
+		keys = []string{submarketKey}
+	} else {
+		for key := range market.Submarkets() {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+	}
+
+	result := make([]Ladder, 0, len(keys))
+	for _, key := range keys {
+		ladder, err := NewLadder(key, market.Submarkets()[key])
+		if err != nil {
+			if errors.Is(err, ErrNoLines) && submarketKey == "" {
+				continue
+			}
+			return nil, err
+		}
+		result = append(result, ladder)
+	}
+	if len(result) == 0 {
+		return nil, ErrNoLines
+	}
+
+	return result, nil
+}
+
+// Margin return the bookmaker margin of a set of mutually exclusive selections, disabled selections are left out
+func Margin(selections []Selection) float64 {
+	var impliedProbability float64
+	for _, selection := range selections {
+		if selection.Status() != "SELECTION_DISABLED" && selection.Price() > 0 {
+			impliedProbability += 1 / selection.Price()
+		}
+	}
+	return impliedProbability - 1
+}
+
+// Margin return the average margin over every line of every submarket, false when nothing is priced
+func (e Event) Margin() (float64, bool) {
+	return e.margin, e.priced
+}
+
+// averageMargin is computed once in NewEvent, markets are not modified afterward
+func averageMargin(markets map[string]Market) (float64, bool) {
+	var total float64
+	var count int
+	for _, market := range markets {
+		for _, selections := range market.Submarkets() {
+			lines := make(map[string][]Selection)
+			for _, selection := range selections {
+				if selection.Side() != "" && selection.Side() != "BACK" {
+					continue
+				}
+				if selection.Status() == "SELECTION_DISABLED" || selection.Price() <= 0 {
+					continue
+				}
+				lines[selection.Params()] = append(lines[selection.Params()], selection)
+			}
+			for _, line := range lines {
+				// a single selection is not a complete book
+				if len(line) < 2 {
+					continue
+				}
+				total += Margin(line)
+				count++
+			}
+		}
+	}
+	if count == 0 {
+		return 0, false
+	}
+	return total / float64(count), true
+}
+
+func parseLine(params string) (float64, bool) {
+	values, err := url.ParseQuery(params)
+	if err != nil {
+		return 0, false
+	}
+	for _, key := range lineParams {
+		if v := values.Get(key); v != "" {
+			value, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return 0, false
+			}
+			return value, true
+		}
+	}
+	return 0, false
+}
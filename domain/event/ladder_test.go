@@ -0,0 +1,225 @@
+package event
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func handicap(outcome string, line string, price float64, status string, side string) Selection {
+	return NewSelection(outcome, "handicap="+line, price, 100, 1/price, status, side)
+}
+
+func newLadderEvent(t *testing.T, markets map[string]Market) Event {
+	t.Helper()
+	sport, _ := NewIdentifier("Basketball", "basketball")
+	category, _ := NewIdentifier("USA", "usa")
+	competition, _ := NewIdentifier("NBA", "basketball-usa-nba")
+	e, err := NewEvent(&sport, &competition, &category, TeamIdentifier{}, TeamIdentifier{}, true, false, markets, "Lakers V Celtics", "lakers-v-celtics", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *e
+}
+
+func TestNewLadder(t *testing.T) {
+	tests := []struct {
+		name       string
+		selections []Selection
+		wantLines  []float64
+		wantMain   float64
+		noMain     bool
+		wantErr    error
+	}{
+		{
+			name: "lines sorted with the closest to even as main",
+			selections: []Selection{
+				handicap("home", "-0.5", 1.6, "SELECTION_ENABLED", "BACK"), handicap("away", "-0.5", 2.4, "SELECTION_ENABLED", "BACK"),
+				handicap("home", "-2.5", 2.5, "SELECTION_ENABLED", "BACK"), handicap("away", "-2.5", 1.55, "SELECTION_ENABLED", "BACK"),
+				handicap("home", "-1.5", 1.95, "SELECTION_ENABLED", "BACK"), handicap("away", "-1.5", 1.9, "SELECTION_ENABLED", "BACK"),
+			},
+			wantLines: []float64{-2.5, -1.5, -0.5},
+			wantMain:  -1.5,
+		},
+		{
+			name: "lay and unparsable params left out",
+			selections: []Selection{
+				handicap("home", "-1.5", 1.9, "", ""), handicap("away", "-1.5", 1.9, "", ""),
+				handicap("home", "-1.5", 2.0, "SELECTION_ENABLED", "LAY"),
+				handicap("home", "x", 1.9, "", ""),
+				NewSelection("home", "period=ft", 1.5, 100, 0.6, "", ""),
+			},
+			wantLines: []float64{-1.5},
+			wantMain:  -1.5,
+		},
+		{
+			name: "disabled line not main",
+			selections: []Selection{
+				handicap("home", "-1.5", 1.9, "SELECTION_DISABLED", "BACK"), handicap("away", "-1.5", 1.9, "SELECTION_ENABLED", "BACK"),
+				handicap("home", "-0.5", 1.5, "SELECTION_ENABLED", "BACK"), handicap("away", "-0.5", 2.6, "SELECTION_ENABLED", "BACK"),
+			},
+			wantLines: []float64{-1.5, -0.5},
+			wantMain:  -0.5,
+		},
+		{
+			name:       "one sided line not main",
+			selections: []Selection{NewSelection("over", "total=210.5", 1.9, 100, 0.5, "", "")},
+			wantLines:  []float64{210.5},
+			noMain:     true,
+		},
+		{
+			name:       "no lines",
+			selections: []Selection{NewSelection("home", "", 1.9, 100, 0.5, "", ""), handicap("home", "-1.5", 1.9, "", "LAY")},
+			wantErr:    ErrNoLines,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ladder, err := NewLadder("period=ft", tt.selections)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewLadder() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			var lines []float64
+			for _, line := range ladder.Lines() {
+				lines = append(lines, line.Value())
+			}
+			if !reflect.DeepEqual(lines, tt.wantLines) {
+				t.Errorf("lines = %v, want %v", lines, tt.wantLines)
+			}
+			main, ok := ladder.MainLine()
+			if ok == tt.noMain || (ok && main.Value() != tt.wantMain) {
+				t.Errorf("MainLine() = %v, %t, want %v", main.Value(), ok, tt.wantMain)
+			}
+		})
+	}
+}
+
+func TestLadders(t *testing.T) {
+	e := newLadderEvent(t, map[string]Market{
+		"basketball.handicap": NewMarket(map[string][]Selection{
+			"period=ft": {handicap("home", "-1.5", 1.9, "", ""), handicap("away", "-1.5", 1.9, "", "")},
+			"period=q1": {handicap("home", "-0.5", 1.9, "", ""), handicap("away", "-0.5", 1.9, "", "")},
+			"period=ot": {NewSelection("home", "", 1.9, 100, 0.5, "", "")},
+		}),
+	})
+
+	tests := []struct {
+		name         string
+		marketKey    string
+		submarketKey string
+		want         []string
+		wantErr      error
+	}{
+		{name: "every submarket with lines", marketKey: "basketball.handicap", want: []string{"period=ft", "period=q1"}},
+		{name: "one submarket", marketKey: "basketball.handicap", submarketKey: "period=q1", want: []string{"period=q1"}},
+		{name: "submarket without lines", marketKey: "basketball.handicap", submarketKey: "period=ot", wantErr: ErrNoLines},
+		{name: "unknown submarket", marketKey: "basketball.handicap", submarketKey: "period=q5", wantErr: ErrSubmarketNotFound},
+		{name: "unknown market", marketKey: "basketball.totals", wantErr: ErrMarketNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ladders, err := e.Ladders(tt.marketKey, tt.submarketKey)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Ladders() error = %v, want %v", err, tt.wantErr)
+			}
+			var submarkets []string
+			for _, ladder := range ladders {
+				submarkets = append(submarkets, ladder.Submarket())
+			}
+			if !reflect.DeepEqual(submarkets, tt.want) {
+				t.Errorf("Ladders() = %v, want %v", submarkets, tt.want)
+			}
+		})
+	}
+}
+
+func TestEventMargin(t *testing.T) {
+	tests := []struct {
+		name       string
+		selections []Selection
+		want       float64
+		wantPriced bool
+	}{
+		{
+			name: "average over lines",
+			selections: []Selection{
+				handicap("home", "-1.5", 1.9, "", ""), handicap("away", "-1.5", 1.9, "", ""),
+				handicap("home", "-0.5", 2, "", ""), handicap("away", "-0.5", 2, "", ""),
+			},
+			want:       (2/1.9 - 1) / 2,
+			wantPriced: true,
+		},
+		{
+			name: "disabled, lay and single selections left out",
+			selections: []Selection{
+				handicap("home", "-1.5", 1.9, "", ""), handicap("away", "-1.5", 1.9, "", ""),
+				handicap("home", "-0.5", 2, "SELECTION_DISABLED", "BACK"), handicap("away", "-0.5", 2, "", ""),
+				handicap("home", "-2.5", 1.5, "", "LAY"), handicap("away", "-2.5", 1.5, "", "LAY"),
+			},
+			want:       2/1.9 - 1,
+			wantPriced: true,
+		},
+		{
+			name:       "nothing priced",
+			selections: []Selection{handicap("home", "-1.5", 0, "", ""), handicap("away", "-1.5", 0, "", "")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newLadderEvent(t, map[string]Market{"basketball.handicap": NewMarket(map[string][]Selection{"period=ft": tt.selections})})
+			got, priced := e.Margin()
+			if priced != tt.wantPriced || math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Margin() = %f, %t, want %f, %t", got, priced, tt.want, tt.wantPriced)
+			}
+		})
+	}
+}
+
+func TestMargin(t *testing.T) {
+	tests := []struct {
+		name       string
+		selections []Selection
+		want       float64
+	}{
+		{
+			name:       "fair book",
+			selections: []Selection{NewSelection("home", "", 2, 100, 0.5, "SELECTION_ENABLED", "BACK"), NewSelection("away", "", 2, 100, 0.5, "SELECTION_ENABLED", "BACK")},
+			want:       0,
+		},
+		{
+			name:       "overround",
+			selections: []Selection{NewSelection("over", "total=2.5", 1.8, 100, 0.5, "", ""), NewSelection("under", "total=2.5", 2, 100, 0.5, "", "")},
+			want:       1/1.8 + 1/2.0 - 1,
+		},
+		{
+			name: "disabled selection left out",
+			selections: []Selection{
+				NewSelection("home", "", 2.5, 100, 0.4, "SELECTION_ENABLED", "BACK"),
+				NewSelection("draw", "", 1.01, 100, 0.3, "SELECTION_DISABLED", "BACK"),
+				NewSelection("away", "", 2.5, 100, 0.4, "SELECTION_ENABLED", "BACK"),
+			},
+			want: 2/2.5 - 1,
+		},
+		{
+			name:       "unpriced selection left out",
+			selections: []Selection{NewSelection("home", "", 1.9, 100, 0.5, "", ""), NewSelection("away", "", 0, 100, 0.5, "", "")},
+			want:       1/1.9 - 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Margin(tt.selections); math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Margin() = %f, want %f", got, tt.want)
+			}
+		})
+	}
+}
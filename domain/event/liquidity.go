@@ -0,0 +1,134 @@
+package event
+
+import (
+	"sort"
+)
+
+type SelectionLiquidity struct {
+	// selection this liquidity is computed from
+	selection Selection
+	// market liability = selection max stake * (price - 1)
+	liability float64
+}
+
+func (s SelectionLiquidity) Selection() Selection {
+	return s.selection
+}
+
+func (s SelectionLiquidity) Liability() float64 {
+	return s.liability
+}
+
+type SubmarketLiquidity struct {
+	// market key of this submarket
+	marketKey string
+	// submarket key
+	submarketKey string
+	// enabled selections of this submarket
+	selections []SelectionLiquidity
+	// sum of max stake over enabled selections
+	totalMaxStake float64
+	// largest liability over enabled selections
+	maxLiability float64
+	// index of the selection with the smallest max stake, which limits staking across the submarket
+	binding int
+}
+
+func (s SubmarketLiquidity) MarketKey() string {
+	return s.marketKey
+}
+
+func (s SubmarketLiquidity) SubmarketKey() string {
+	return s.submarketKey
+}
+
+func (s SubmarketLiquidity) Selections() []SelectionLiquidity {
+	return s.selections
+}
+
+func (s SubmarketLiquidity) TotalMaxStake() float64 {
+	return s.totalMaxStake
+}
+
+func (s SubmarketLiquidity) MaxLiability() float64 {
+	return s.maxLiability
+}
+
+// Binding return the selection with the smallest max stake
+func (s SubmarketLiquidity) Binding() SelectionLiquidity {
+	return s.selections[s.binding]
+}
+
+type Liquidity struct {
+	// slug of the event
+	eventKey string
+	// submarkets with at least one enabled selection, sorted by market and submarket key
+	submarkets []SubmarketLiquidity
+	// sum of max stake over all enabled selections
+	totalMaxStake float64
+	// sum of the largest liability of every submarket
+	totalLiability float64
+}
+
+func (l Liquidity) EventKey() string {
+	return l.eventKey
+}
+
+func (l Liquidity) Submarkets() []SubmarketLiquidity {
+	return l.submarkets
+}
+
+func (l Liquidity) TotalMaxStake() float64 {
+	return l.totalMaxStake
+}
+
+func (l Liquidity) TotalLiability() float64 {
+	return l.totalLiability
+}
+
+// Liquidity summarise the max stake and liability offered by every submarket of this Event
+func (e Event) Liquidity() Liquidity {
+	liquidity := Liquidity{
+		eventKey:   e.key,
+		submarkets: make([]SubmarketLiquidity, 0),
+	}
+	for marketKey, market := range e.markets {
+		for submarketKey, selections := range market.Submarkets() {
+			submarket := SubmarketLiquidity{
+				marketKey:    marketKey,
+				submarketKey: submarketKey,
+			}
+			for _, selection := range selections {
+				if selection.Status() == "SELECTION_DISABLED" {
+					continue
+				}
+				liability := selection.MaxStake() * (selection.Price() - 1)
+				submarket.selections = append(submarket.selections, SelectionLiquidity{
+					selection: selection,
+					liability: liability,
+				})
+				submarket.totalMaxStake += selection.MaxStake()
+				if liability > submarket.maxLiability {
+					submarket.maxLiability = liability
+				}
+				if selection.MaxStake() < submarket.selections[submarket.binding].selection.MaxStake() {
+					submarket.binding = len(submarket.selections) - 1
+				}
+			}
+			if len(submarket.selections) == 0 {
+				continue
+			}
+			liquidity.submarkets = append(liquidity.submarkets, submarket)
+			liquidity.totalMaxStake += submarket.totalMaxStake
+			liquidity.totalLiability += submarket.maxLiability
+		}
+	}
+	sort.Slice(liquidity.submarkets, func(i, j int) bool {
+		if liquidity.submarkets[i].marketKey == liquidity.submarkets[j].marketKey {
+			return liquidity.submarkets[i].submarketKey < liquidity.submarkets[j].submarketKey
+		}
+		return liquidity.submarkets[i].marketKey < liquidity.submarkets[j].marketKey
+	})
+
+	return liquidity
+}
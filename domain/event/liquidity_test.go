@@ -0,0 +1,64 @@
+package event
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLiquidity(t *testing.T) {
+	e := newLadderEvent(t, map[string]Market{
+		"basketball.moneyline": NewMarket(map[string][]Selection{
+			"period=ft": {
+				NewSelection("home", "", 1.5, 300, 0.6, "SELECTION_ENABLED", "BACK"),
+				NewSelection("away", "", 3, 100, 0.3, "SELECTION_ENABLED", "BACK"),
+			},
+			"period=q1": {
+				NewSelection("home", "", 1.9, 50, 0.5, "SELECTION_DISABLED", "BACK"),
+			},
+		}),
+		"basketball.handicap": NewMarket(map[string][]Selection{
+			"period=ft": {
+				handicap("home", "-1.5", 2, "SELECTION_DISABLED", "BACK"),
+				handicap("away", "-1.5", 1.8, "", ""),
+			},
+		}),
+	})
+
+	liquidity := e.Liquidity()
+	submarkets := liquidity.Submarkets()
+	// the q1 submarket has no enabled selection, the others are sorted by market then submarket
+	if len(submarkets) != 2 || submarkets[0].MarketKey() != "basketball.handicap" || submarkets[1].MarketKey() != "basketball.moneyline" {
+		t.Fatalf("Submarkets() = %+v", submarkets)
+	}
+
+	tests := []struct {
+		name          string
+		submarket     SubmarketLiquidity
+		selections    int
+		totalMaxStake float64
+		maxLiability  float64
+		binding       string
+	}{
+		{name: "disabled selection left out", submarket: submarkets[0], selections: 1, totalMaxStake: 100, maxLiability: 80, binding: "away"},
+		{name: "two sided", submarket: submarkets[1], selections: 2, totalMaxStake: 400, maxLiability: 200, binding: "away"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := tt.submarket
+			if len(s.Selections()) != tt.selections || !almostEqual(s.TotalMaxStake(), tt.totalMaxStake) || !almostEqual(s.MaxLiability(), tt.maxLiability) {
+				t.Errorf("%d selections, total max stake %f, max liability %f, want %d, %f, %f", len(s.Selections()), s.TotalMaxStake(), s.MaxLiability(), tt.selections, tt.totalMaxStake, tt.maxLiability)
+			}
+			if s.Binding().Selection().Outcome() != tt.binding {
+				t.Errorf("Binding() = %s, want %s", s.Binding().Selection().Outcome(), tt.binding)
+			}
+		})
+	}
+
+	if liquidity.EventKey() != e.Key() || !almostEqual(liquidity.TotalMaxStake(), 500) || !almostEqual(liquidity.TotalLiability(), 280) {
+		t.Errorf("Liquidity() = %s total max stake %f, total liability %f, want 500 and 280", liquidity.EventKey(), liquidity.TotalMaxStake(), liquidity.TotalLiability())
+	}
+}
+
+func almostEqual(a float64, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
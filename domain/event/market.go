@@ -11,10 +11,6 @@ func NewMarket(submarkets map[string][]Selection) Market {
 	}
 }
 
-func (m Market) UpdateSelection(key string, selections []Selection) {
-	m.submarkets[key] = selections
-}
-
 func (m Market) Submarkets() map[string][]Selection {
 	return m.submarkets
 }
@@ -0,0 +1,80 @@
+package event
+
+import (
+	"sort"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+var (
+	ErrSelectionNotFound = failure.New(failure.NotFound, "selection_not_found", "selection not found")
+	ErrInvalidReference  = failure.New(failure.Invalid, "invalid_reference", "selection reference requires event key, market key and outcome")
+)
+
+// Selection return the back side selection of marketKey with the given outcome and params, searching submarkets in key order
+func (e Event) Selection(marketKey string, outcome string, params string) (Selection, string, error) {
+	market, ok := e.markets[marketKey]
+	if !ok {
+		return Selection{}, "", ErrMarketNotFound
+	}
+
+	keys := make([]string, 0, len(market.Submarkets()))
+	for key := range market.Submarkets() {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, selection := range market.Submarkets()[key] {
+			if selection.Side() == "LAY" {
+				continue
+			}
+			if selection.Outcome() == outcome && selection.Params() == params {
+				return selection, key, nil
+			}
+		}
+	}
+
+	return Selection{}, "", ErrSelectionNotFound
+}
+
+// Reference point to a selection by event, market, outcome and params
+type Reference struct {
+	// slug of the event
+	eventKey string
+	// market key of the selection
+	marketKey string
+	// outcome of the selection
+	outcome string
+	// parameters of the selection, such as handicap, period etc.
+	params string
+}
+
+func NewReference(eventKey string, marketKey string, outcome string, params string) (Reference, error) {
+	if eventKey == "" || marketKey == "" || outcome == "" {
+		return Reference{}, ErrInvalidReference
+	}
+
+	return Reference{
+		eventKey:  eventKey,
+		marketKey: marketKey,
+		outcome:   outcome,
+		params:    params,
+	}, nil
+}
+
+func (r Reference) EventKey() string {
+	return r.eventKey
+}
+
+func (r Reference) MarketKey() string {
+	return r.marketKey
+}
+
+func (r Reference) Outcome() string {
+	return r.outcome
+}
+
+func (r Reference) Params() string {
+	return r.params
+}
@@ -2,11 +2,152 @@ package event
 
 import (
 	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+	"github.com/awcjack/cloudbet/domain/pagination"
+)
+
+const (
+	SortCutOffTime = "cutoffTime"
+	SortName       = "name"
+	SortMargin     = "margin"
+)
+
+var (
+	ErrEventNotFound       = failure.New(failure.NotFound, "event_not_found", "event not found")
+	ErrInvalidSort         = failure.New(failure.Invalid, "invalid_sort", "sort must be one of cutoffTime, name, margin")
+	ErrInvalidCutOffWindow = failure.New(failure.Invalid, "invalid_cutoff_window", "cutoffFrom must be before cutoffTo")
+	ErrInvalidKeyCount     = failure.New(failure.Invalid, "invalid_key_count", "keys must contain between 1 and 50 event keys")
 )
 
+// MaxBatchSize is the largest number of events which can be looked up at once
+const MaxBatchSize = 50
+
+// Filter narrow down the events returned by ListEvents, empty fields are ignored
+type Filter struct {
+	SportKey       string
+	CategoryKey    string
+	CompetitionKey string
+	// events which the team plays either home or away
+	TeamKey string
+	// events with either team from this country code
+	Nationality string
+	// events with cutoff time not before CutOffFrom, ignored when zero
+	CutOffFrom time.Time
+	// events with cutoff time before CutOffTo, ignored when zero
+	CutOffTo time.Time
+	// only events still trading
+	ActiveOnly bool
+	// only events in TRADING_LIVE status
+	LiveOnly bool
+}
+
+// Order sort the events returned by ListEvents, ties are broken by event key so the ordering is total
+type Order struct {
+	// one of SortCutOffTime, SortName, SortMargin
+	By         string
+	Descending bool
+}
+
+// NewOrder validate the sort field, events are sorted by cutoff time when by is empty
+func NewOrder(by string, descending bool) (Order, error) {
+	switch by {
+	case "":
+		by = SortCutOffTime
+	case SortCutOffTime, SortName, SortMargin:
+	default:
+		return Order{}, ErrInvalidSort
+	}
+	return Order{
+		By:         by,
+		Descending: descending,
+	}, nil
+}
+
+// String identify the ordering in pagination cursors
+func (o Order) String() string {
+	if o.Descending {
+		return o.By + ":desc"
+	}
+	return o.By + ":asc"
+}
+
+// Position return the sort value of e under this Order, events without margin get an empty value
+func (o Order) Position(e Event) pagination.Position {
+	position := pagination.Position{
+		Key: e.Key(),
+	}
+	switch o.By {
+	case SortName:
+		position.Value = e.Name()
+	case SortMargin:
+		if margin, ok := e.Margin(); ok {
+			position.Value = strconv.FormatFloat(margin, 'g', -1, 64)
+		}
+	case SortCutOffTime:
+		position.Value = strconv.FormatInt(e.CutOffTime().UnixNano(), 10)
+	}
+	return position
+}
+
+// Compare order positions returned by Position, events without margin always sort last
+func (o Order) Compare(a pagination.Position, b pagination.Position) int {
+	result := 0
+	switch o.By {
+	case SortName:
+		result = strings.Compare(a.Value, b.Value)
+	case SortMargin:
+		if (a.Value == "") != (b.Value == "") {
+			if a.Value == "" {
+				return 1
+			}
+			return -1
+		}
+		aMargin, _ := strconv.ParseFloat(a.Value, 64)
+		bMargin, _ := strconv.ParseFloat(b.Value, 64)
+		result = compareFloat(aMargin, bMargin)
+	case SortCutOffTime:
+		aTime, _ := strconv.ParseInt(a.Value, 10, 64)
+		bTime, _ := strconv.ParseInt(b.Value, 10, 64)
+		switch {
+		case aTime < bTime:
+			result = -1
+		case aTime > bTime:
+			result = 1
+		}
+	}
+	if result == 0 {
+		result = pagination.CompareKeys(a, b)
+	}
+	if o.Descending {
+		return -result
+	}
+	return result
+}
+
+func compareFloat(a float64, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
 type Repository interface {
 	Save(ctx context.Context, event Event) error
-	ListEvents(ctx context.Context, first int, page int, sportKey string, categoryKey string, competitionKey string) ([]Event, error)
+	ListEvents(ctx context.Context, request pagination.Request, filter Filter, order Order) ([]Event, pagination.Info, error)
 	GetEvent(ctx context.Context, eventKey string) (Event, error)
+	// GetEvents return the stored events in the order of eventKeys and the keys which are not stored, duplicated keys are looked up once
+	GetEvents(ctx context.Context, eventKeys []string) ([]Event, []string, error)
 	ListEventsCutOffSoon(ctx context.Context) ([]Event, error)
+	ListActiveEvents(ctx context.Context) ([]Event, error)
+	// ListInactiveEvents return events which stopped trading before the given time
+	ListInactiveEvents(ctx context.Context, before time.Time) ([]Event, error)
+	// DeleteEvent evict an event, ErrEventNotFound when it is not stored
+	DeleteEvent(ctx context.Context, eventKey string) error
 }
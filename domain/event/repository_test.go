@@ -0,0 +1,33 @@
+package event
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		by         string
+		descending bool
+		want       string
+		wantErr    error
+	}{
+		{name: "cutoff time by default", want: "cutoffTime:asc"},
+		{name: "name descending", by: SortName, descending: true, want: "name:desc"},
+		{name: "margin", by: SortMargin, want: "margin:asc"},
+		{name: "unknown field", by: "price", wantErr: ErrInvalidSort},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := NewOrder(tt.by, tt.descending)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewOrder() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && order.String() != tt.want {
+				t.Errorf("String() = %s, want %s", order.String(), tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package failure
+
+import (
+	"errors"
+)
+
+type Kind int
+
+const (
+	// the caller supplied an invalid request
+	Invalid Kind = iota + 1
+	// the requested resource does not exist
+	NotFound
+	// a dependency cannot serve the request for now, the caller may retry later
+	Unavailable
+	// the requested resource existed but is no longer available
+	Gone
+	// the caller did not prove who it is
+	Unauthenticated
+	// the caller is known but not allowed to do this
+	Forbidden
+	// the caller made too many requests, it may retry later
+	TooManyRequests
+	// the request conflicts with the current state of the resource
+	Conflict
+)
+
+// Error is a domain error which knows how it should be reported to callers.
+// Values are comparable, so package level Error variables work as sentinels with errors.Is.
+type Error struct {
+	// how the error should be reported
+	kind Kind
+	// stable machine readable identifier, e.g. event_not_found
+	code string
+	// human readable description
+	message string
+}
+
+func New(kind Kind, code string, message string) Error {
+	return Error{
+		kind:    kind,
+		code:    code,
+		message: message,
+	}
+}
+
+func (e Error) Error() string {
+	return e.message
+}
+
+func (e Error) Kind() Kind {
+	return e.kind
+}
+
+func (e Error) Code() string {
+	return e.code
+}
+
+func (e Error) Message() string {
+	return e.message
+}
+
+// As return the first Error in the chain of err
+func As(err error) (Error, bool) {
+	var target Error
+	if errors.As(err, &target) {
+		return target, true
+	}
+	return Error{}, false
+}
@@ -0,0 +1,75 @@
+package health
+
+const (
+	StatusPass = "pass"
+	StatusFail = "fail"
+)
+
+// Check is the result of one readiness condition
+type Check struct {
+	// stable identifier, e.g. data_freshness
+	name string
+	// pass or fail
+	status string
+	// human readable explanation of the status
+	message string
+}
+
+func NewCheck(name string, pass bool, message string) Check {
+	status := StatusFail
+	if pass {
+		status = StatusPass
+	}
+	return Check{
+		name:    name,
+		status:  status,
+		message: message,
+	}
+}
+
+func (c Check) Name() string {
+	return c.name
+}
+
+func (c Check) Status() string {
+	return c.status
+}
+
+func (c Check) Message() string {
+	return c.message
+}
+
+func (c Check) Passed() bool {
+	return c.status == StatusPass
+}
+
+// Report is the readiness of the instance, ready only if every check passed
+type Report struct {
+	checks []Check
+}
+
+func NewReport(checks []Check) Report {
+	return Report{
+		checks: checks,
+	}
+}
+
+func (r Report) Checks() []Check {
+	return r.checks
+}
+
+func (r Report) Ready() bool {
+	for _, check := range r.checks {
+		if !check.Passed() {
+			return false
+		}
+	}
+	return true
+}
+
+func (r Report) Status() string {
+	if r.Ready() {
+		return StatusPass
+	}
+	return StatusFail
+}
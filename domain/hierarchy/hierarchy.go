@@ -0,0 +1,332 @@
+package hierarchy
+
+import (
+	"sort"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/activity"
+	"github.com/awcjack/cloudbet/domain/category"
+	"github.com/awcjack/cloudbet/domain/competition"
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/sport"
+)
+
+// Hierarchy index sport -> category -> competition -> event, not safe for concurrent use.
+// Nodes stay once created, so sports and competitions without events are still listed after their events are evicted.
+type Hierarchy struct {
+	sports map[string]*sportNode
+	// competition keys are unique across sports, so competitions are indexed directly as well
+	competitions map[string]*competitionNode
+}
+
+type sportNode struct {
+	name string
+	// categories of this sport only, the same category key under another sport is a different node
+	categories map[string]*categoryNode
+	activityStats
+}
+
+type categoryNode struct {
+	name         string
+	competitions map[string]*competitionNode
+	activityStats
+}
+
+type competitionNode struct {
+	name        string
+	sportKey    string
+	categoryKey string
+	activityStats
+}
+
+// activityStats track the events under a node, updated on every add and remove so reading the activity stays cheap
+type activityStats struct {
+	events map[string]struct{}
+	active int
+	live   int
+	// active events ordered by cutoff time then key
+	cutOffs     []cutOffEntry
+	lastUpdated time.Time
+}
+
+type cutOffEntry struct {
+	time time.Time
+	key  string
+}
+
+func (a *activityStats) add(e event.Event) {
+	if _, ok := a.events[e.Key()]; ok {
+		return
+	}
+	if a.events == nil {
+		a.events = make(map[string]struct{})
+	}
+	a.events[e.Key()] = struct{}{}
+	if e.Active() {
+		a.active++
+		entry := cutOffEntry{time: e.CutOffTime(), key: e.Key()}
+		i := a.cutOffPosition(entry)
+		a.cutOffs = append(a.cutOffs, cutOffEntry{})
+		copy(a.cutOffs[i+1:], a.cutOffs[i:])
+		a.cutOffs[i] = entry
+	}
+	if e.Live() {
+		a.live++
+	}
+	a.touch(e.LastUpdated())
+}
+
+// remove must be given the stored version of e, the one passed to add
+func (a *activityStats) remove(e event.Event) {
+	if _, ok := a.events[e.Key()]; !ok {
+		return
+	}
+	delete(a.events, e.Key())
+	if e.Active() {
+		a.active--
+		i := a.cutOffPosition(cutOffEntry{time: e.CutOffTime(), key: e.Key()})
+		if i < len(a.cutOffs) && a.cutOffs[i].key == e.Key() {
+			a.cutOffs = append(a.cutOffs[:i], a.cutOffs[i+1:]...)
+		}
+	}
+	if e.Live() {
+		a.live--
+	}
+}
+
+func (a *activityStats) touch(t time.Time) {
+	if t.After(a.lastUpdated) {
+		a.lastUpdated = t
+	}
+}
+
+func (a activityStats) eventKeys() []string {
+	keys := make([]string, 0, len(a.events))
+	for key := range a.events {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func (a activityStats) cutOffPosition(entry cutOffEntry) int {
+	return sort.Search(len(a.cutOffs), func(i int) bool {
+		if a.cutOffs[i].time.Equal(entry.time) {
+			return a.cutOffs[i].key >= entry.key
+		}
+		return a.cutOffs[i].time.After(entry.time)
+	})
+}
+
+func (a activityStats) activity(now time.Time) activity.Activity {
+	var nextCutOffTime time.Time
+	if i := sort.Search(len(a.cutOffs), func(i int) bool {
+		return !a.cutOffs[i].time.Before(now)
+	}); i < len(a.cutOffs) {
+		nextCutOffTime = a.cutOffs[i].time
+	}
+	return activity.NewActivity(len(a.events), a.active, a.live, nextCutOffTime, a.lastUpdated)
+}
+
+func NewHierarchy() *Hierarchy {
+	return &Hierarchy{
+		sports:       make(map[string]*sportNode),
+		competitions: make(map[string]*competitionNode),
+	}
+}
+
+// Add place e under its sport, category and competition, creating missing nodes and refreshing their names
+func (h *Hierarchy) Add(e event.Event) {
+	sportKey, categoryKey, competitionKey := e.Sport().Key(), e.Category().Key(), e.Competition().Key()
+
+	s, ok := h.sports[sportKey]
+	if !ok {
+		s = &sportNode{categories: make(map[string]*categoryNode)}
+		h.sports[sportKey] = s
+	}
+	s.name = e.Sport().Name()
+	c, ok := s.categories[categoryKey]
+	if !ok {
+		c = &categoryNode{competitions: make(map[string]*competitionNode)}
+		s.categories[categoryKey] = c
+	}
+	c.name = e.Category().Name()
+	co, ok := h.competitions[competitionKey]
+	if !ok {
+		co = &competitionNode{}
+		h.competitions[competitionKey] = co
+	} else if co.sportKey != sportKey || co.categoryKey != categoryKey {
+		// the competition moved, it only belongs to its latest category
+		if previous, ok := h.sports[co.sportKey]; ok {
+			if previousCategory, ok := previous.categories[co.categoryKey]; ok {
+				delete(previousCategory.competitions, competitionKey)
+			}
+		}
+	}
+	co.name = e.Competition().Name()
+	co.sportKey = sportKey
+	co.categoryKey = categoryKey
+	c.competitions[competitionKey] = co
+
+	s.add(e)
+	c.add(e)
+	co.add(e)
+}
+
+// Replace move the stored version previous of an event to e, only touching the nodes when neither the place of the
+// event in the hierarchy nor its activity changed, which is the common case of a crawl
+func (h *Hierarchy) Replace(previous event.Event, e event.Event) {
+	if !sameHierarchy(previous, e) || previous.Active() != e.Active() || previous.Live() != e.Live() ||
+		!previous.CutOffTime().Equal(e.CutOffTime()) {
+		h.Remove(previous, time.Time{})
+		h.Add(e)
+		return
+	}
+	s := h.sports[e.Sport().Key()]
+	s.touch(e.LastUpdated())
+	s.categories[e.Category().Key()].touch(e.LastUpdated())
+	h.competitions[e.Competition().Key()].touch(e.LastUpdated())
+}
+
+// sameHierarchy report whether e is placed under the same, identically named, nodes as previous
+func sameHierarchy(previous event.Event, e event.Event) bool {
+	return previous.Sport().Key() == e.Sport().Key() && previous.Sport().Name() == e.Sport().Name() &&
+		previous.Category().Key() == e.Category().Key() && previous.Category().Name() == e.Category().Name() &&
+		previous.Competition().Key() == e.Competition().Key() && previous.Competition().Name() == e.Competition().Name()
+}
+
+// Remove drop the stored version of e from its sport, category and competition.
+// A non zero evicted time counts as an update of the nodes, replaced events are re-added with their own time.
+func (h *Hierarchy) Remove(e event.Event, evicted time.Time) {
+	s, ok := h.sports[e.Sport().Key()]
+	if !ok {
+		return
+	}
+	s.remove(e)
+	s.touch(evicted)
+	if c, ok := s.categories[e.Category().Key()]; ok {
+		c.remove(e)
+		c.touch(evicted)
+	}
+	if co, ok := h.competitions[e.Competition().Key()]; ok {
+		co.remove(e)
+		co.touch(evicted)
+	}
+}
+
+func (h Hierarchy) SportEvents(sportKey string) []string {
+	if s, ok := h.sports[sportKey]; ok {
+		return s.eventKeys()
+	}
+	return nil
+}
+
+// CategoryEvents return the events of the category under every sport
+func (h Hierarchy) CategoryEvents(categoryKey string) []string {
+	var result []string
+	for _, s := range h.sports {
+		if c, ok := s.categories[categoryKey]; ok {
+			result = append(result, c.eventKeys()...)
+		}
+	}
+	return result
+}
+
+func (h Hierarchy) CompetitionEvents(competitionKey string) []string {
+	if co, ok := h.competitions[competitionKey]; ok {
+		return co.eventKeys()
+	}
+	return nil
+}
+
+func (h Hierarchy) Sport(sportKey string, liveTime float64) (sport.Sport, bool) {
+	s, ok := h.sports[sportKey]
+	if !ok {
+		return sport.Sport{}, false
+	}
+	competitionCount := 0
+	for _, c := range s.categories {
+		competitionCount += len(c.competitions)
+	}
+	return sport.NewSport(s.name, sportKey, liveTime, len(s.categories), competitionCount, s.activity(time.Now())), true
+}
+
+// Category merge the category nodes with the key under every sport, or only under sportKey if not empty
+func (h Hierarchy) Category(categoryKey string, sportKey string) (category.Category, bool) {
+	var name string
+	var merged activity.Activity
+	now := time.Now()
+	sportKeys := make([]string, 0)
+	competitionCount := 0
+	for key, s := range h.sports {
+		if sportKey != "" && key != sportKey {
+			continue
+		}
+		c, ok := s.categories[categoryKey]
+		if !ok {
+			continue
+		}
+		name = c.name
+		sportKeys = append(sportKeys, key)
+		competitionCount += len(c.competitions)
+		merged = merged.Merge(c.activity(now))
+	}
+	if len(sportKeys) == 0 {
+		return category.Category{}, false
+	}
+	sort.Strings(sportKeys)
+	return category.NewCategory(name, categoryKey, sportKeys, competitionCount, merged), true
+}
+
+func (h Hierarchy) Competition(competitionKey string) (competition.Competition, bool) {
+	co, ok := h.competitions[competitionKey]
+	if !ok {
+		return competition.Competition{}, false
+	}
+	return competition.NewCompetition(co.name, competitionKey, co.sportKey, co.categoryKey, co.activity(time.Now())), true
+}
+
+// CategoryKeys return the sorted keys of the categories under sportKey, or under every sport if empty
+func (h Hierarchy) CategoryKeys(sportKey string) []string {
+	selected := make(map[string]bool)
+	for key, s := range h.sports {
+		if sportKey != "" && key != sportKey {
+			continue
+		}
+		for categoryKey := range s.categories {
+			selected[categoryKey] = true
+		}
+	}
+	return sortedKeys(selected)
+}
+
+// CompetitionKeys return the sorted keys of the competitions matching filter
+func (h Hierarchy) CompetitionKeys(filter competition.Filter) []string {
+	selected := make(map[string]bool)
+	for key, co := range h.competitions {
+		if filter.SportKey != "" && co.sportKey != filter.SportKey {
+			continue
+		}
+		if filter.CategoryKey != "" && co.categoryKey != filter.CategoryKey {
+			continue
+		}
+		selected[key] = true
+	}
+	return sortedKeys(selected)
+}
+
+func (h Hierarchy) SportKeys() []string {
+	selected := make(map[string]bool, len(h.sports))
+	for key := range h.sports {
+		selected[key] = true
+	}
+	return sortedKeys(selected)
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,202 @@
+package hierarchy
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/activity"
+	"github.com/awcjack/cloudbet/domain/competition"
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+func newHierarchyEvent(t *testing.T, sportKey string, categoryKey string, competitionKey string, key string, active bool, live bool, cutOff time.Time) event.Event {
+	t.Helper()
+	sport, _ := event.NewIdentifier(sportKey, sportKey)
+	category, _ := event.NewIdentifier(categoryKey, categoryKey)
+	competition, _ := event.NewIdentifier(competitionKey, competitionKey)
+	e, err := event.NewEvent(&sport, &competition, &category, event.TeamIdentifier{}, event.TeamIdentifier{}, active, live, map[string]event.Market{}, key, key, cutOff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *e
+}
+
+func sorted(keys []string) []string {
+	sort.Strings(keys)
+	return keys
+}
+
+func TestHierarchy(t *testing.T) {
+	cutOff := time.Now().Add(time.Hour)
+	h := NewHierarchy()
+	for _, e := range []event.Event{
+		newHierarchyEvent(t, "soccer", "england", "premier-league", "e1", true, false, cutOff),
+		newHierarchyEvent(t, "soccer", "england", "championship", "e2", true, false, cutOff),
+		newHierarchyEvent(t, "soccer", "spain", "la-liga", "e3", true, false, cutOff),
+		newHierarchyEvent(t, "tennis", "england", "wimbledon", "e4", true, false, cutOff),
+	} {
+		h.Add(e)
+	}
+	// the championship moves to another category with its next event
+	moved := newHierarchyEvent(t, "soccer", "international", "championship", "e5", true, false, cutOff)
+	h.Add(moved)
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{name: "sport events", got: sorted(h.SportEvents("soccer")), want: []string{"e1", "e2", "e3", "e5"}},
+		{name: "category events under every sport", got: sorted(h.CategoryEvents("england")), want: []string{"e1", "e2", "e4"}},
+		{name: "competition events", got: sorted(h.CompetitionEvents("championship")), want: []string{"e2", "e5"}},
+		{name: "unknown sport", got: h.SportEvents("golf"), want: nil},
+		{name: "sports", got: h.SportKeys(), want: []string{"soccer", "tennis"}},
+		{name: "categories of every sport", got: h.CategoryKeys(""), want: []string{"england", "international", "spain"}},
+		{name: "categories of a sport", got: h.CategoryKeys("tennis"), want: []string{"england"}},
+		{name: "competitions of a sport", got: h.CompetitionKeys(competition.Filter{SportKey: "soccer"}), want: []string{"championship", "la-liga", "premier-league"}},
+		{name: "competitions of a category", got: h.CompetitionKeys(competition.Filter{CategoryKey: "england"}), want: []string{"premier-league", "wimbledon"}},
+		{name: "moved competition", got: h.CompetitionKeys(competition.Filter{CategoryKey: "international"}), want: []string{"championship"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	s, ok := h.Sport("soccer", 90)
+	if !ok || s.CategoryCount() != 3 || s.CompetitionCount() != 3 || s.LiveTime() != 90 {
+		t.Errorf("Sport() = %d categories, %d competitions, %t", s.CategoryCount(), s.CompetitionCount(), ok)
+	}
+	c, ok := h.Category("england", "")
+	if !ok || !reflect.DeepEqual(c.SportKeys(), []string{"soccer", "tennis"}) || c.CompetitionCount() != 2 {
+		t.Errorf("Category() = %v sports, %d competitions, %t", c.SportKeys(), c.CompetitionCount(), ok)
+	}
+	if c, ok := h.Category("england", "tennis"); !ok || !reflect.DeepEqual(c.SportKeys(), []string{"tennis"}) || c.CompetitionCount() != 1 {
+		t.Errorf("Category(tennis) = %v sports, %d competitions, %t", c.SportKeys(), c.CompetitionCount(), ok)
+	}
+	if _, ok := h.Category("england", "golf"); ok {
+		t.Errorf("Category(golf) found")
+	}
+	if co, ok := h.Competition("championship"); !ok || co.SportKey() != "soccer" || co.CategoryKey() != "international" {
+		t.Errorf("Competition() = %s/%s, %t", co.SportKey(), co.CategoryKey(), ok)
+	}
+
+	// nodes stay once their events are removed
+	h.Remove(moved, time.Time{})
+	h.Remove(newHierarchyEvent(t, "tennis", "england", "wimbledon", "e4", true, false, cutOff), time.Time{})
+	if got := h.CompetitionEvents("wimbledon"); len(got) != 0 {
+		t.Errorf("CompetitionEvents() = %v after removal", got)
+	}
+	if got := h.SportKeys(); !reflect.DeepEqual(got, []string{"soccer", "tennis"}) {
+		t.Errorf("SportKeys() = %v after removal", got)
+	}
+}
+
+func TestActivity(t *testing.T) {
+	now := time.Now()
+	updated := now.Add(-time.Hour)
+	newEvent := func(key string, active bool, live bool, cutOff time.Duration, lastUpdated time.Duration) event.Event {
+		e := newHierarchyEvent(t, "soccer", "england", "premier-league", key, active, live, now.Add(cutOff))
+		e.SetVersion("e1", 1, updated.Add(lastUpdated))
+		return e
+	}
+	h := NewHierarchy()
+	// stored versions of the events, as Replace and Remove expect
+	stored := map[string]event.Event{}
+	add := func(e event.Event) {
+		if previous, ok := stored[e.Key()]; ok {
+			h.Replace(previous, e)
+		} else {
+			h.Add(e)
+		}
+		stored[e.Key()] = e
+	}
+
+	tests := []struct {
+		name           string
+		update         func()
+		eventCount     int
+		active         int
+		live           int
+		nextCutOff     time.Duration
+		noNextCutOff   bool
+		lastUpdated    time.Duration
+		categoryEvents int
+	}{
+		{
+			name: "added",
+			update: func() {
+				add(newEvent("started", true, true, -time.Hour, time.Minute))
+				add(newEvent("later", true, false, 2*time.Hour, 2*time.Minute))
+				add(newEvent("sooner", true, false, time.Hour, 3*time.Minute))
+				add(newEvent("ended", false, false, 30*time.Minute, 0))
+			},
+			eventCount: 4, active: 3, live: 1, nextCutOff: time.Hour, lastUpdated: 3 * time.Minute, categoryEvents: 4,
+		},
+		{
+			name:       "updated in place",
+			update:     func() { add(newEvent("sooner", true, false, time.Hour, 4*time.Minute)) },
+			eventCount: 4, active: 3, live: 1, nextCutOff: time.Hour, lastUpdated: 4 * time.Minute, categoryEvents: 4,
+		},
+		{
+			name:       "went inactive",
+			update:     func() { add(newEvent("sooner", false, false, time.Hour, 5*time.Minute)) },
+			eventCount: 4, active: 2, live: 1, nextCutOff: 2 * time.Hour, lastUpdated: 5 * time.Minute, categoryEvents: 4,
+		},
+		{
+			name: "evicted",
+			update: func() {
+				h.Remove(stored["later"], updated.Add(6*time.Minute))
+				delete(stored, "later")
+			},
+			eventCount: 3, active: 1, live: 1, noNextCutOff: true, lastUpdated: 6 * time.Minute, categoryEvents: 3,
+		},
+		{
+			name: "same category under another sport",
+			update: func() {
+				e := newHierarchyEvent(t, "tennis", "england", "wimbledon", "final", true, false, now.Add(30*time.Minute))
+				e.SetVersion("e1", 1, updated.Add(7*time.Minute))
+				h.Add(e)
+			},
+			eventCount: 3, active: 1, live: 1, noNextCutOff: true, lastUpdated: 6 * time.Minute, categoryEvents: 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.update()
+			co, ok := h.Competition("premier-league")
+			if !ok {
+				t.Fatal("Competition() not found")
+			}
+			s, _ := h.Sport("soccer", 0)
+			for _, a := range []struct {
+				node string
+				got  activity.Activity
+			}{{"competition", co.Activity()}, {"sport", s.Activity()}} {
+				got := a.got
+				if got.EventCount() != tt.eventCount || got.ActiveEventCount() != tt.active || got.LiveEventCount() != tt.live {
+					t.Errorf("%s counts = %d, %d, %d, want %d, %d, %d", a.node, got.EventCount(), got.ActiveEventCount(), got.LiveEventCount(), tt.eventCount, tt.active, tt.live)
+				}
+				if tt.noNextCutOff != got.NextCutOffTime().IsZero() || (!tt.noNextCutOff && !got.NextCutOffTime().Equal(now.Add(tt.nextCutOff))) {
+					t.Errorf("%s next cutoff = %s, want now + %s", a.node, got.NextCutOffTime(), tt.nextCutOff)
+				}
+				if !got.LastUpdated().Equal(updated.Add(tt.lastUpdated)) {
+					t.Errorf("%s last updated = %s, want %s", a.node, got.LastUpdated(), updated.Add(tt.lastUpdated))
+				}
+			}
+			if c, _ := h.Category("england", ""); c.Activity().EventCount() != tt.categoryEvents {
+				t.Errorf("category event count = %d, want %d", c.Activity().EventCount(), tt.categoryEvents)
+			}
+		})
+	}
+
+	c, _ := h.Category("england", "")
+	if !c.Activity().NextCutOffTime().Equal(now.Add(30*time.Minute)) || !c.Activity().LastUpdated().Equal(updated.Add(7*time.Minute)) {
+		t.Errorf("merged category activity = %s, %s", c.Activity().NextCutOffTime(), c.Activity().LastUpdated())
+	}
+}
@@ -0,0 +1,142 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+var ErrInvalidCursor = failure.New(failure.Invalid, "invalid_cursor", "invalid cursor")
+
+// Position locate an item in an ordered listing
+type Position struct {
+	// sort value of the item, empty when items are ordered by key only
+	Value string `json:"v,omitempty"`
+	// key of the item, breaking ties between equal sort values
+	Key string `json:"k"`
+}
+
+// Cursor is the opaque token handed to clients to continue a listing from a Position.
+// Positions are compared by value rather than offset, so items saved between requests never shift a page.
+type Cursor struct {
+	// ordering the cursor was issued for, a cursor cannot be reused with another ordering
+	Order string `json:"o,omitempty"`
+	// last item of the previous page, or first item of the next page when Backward
+	Position Position `json:"p"`
+	// select the items before Position instead of after it
+	Backward bool `json:"b,omitempty"`
+}
+
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parse a cursor returned by Encode, an empty string is the start of the listing
+func DecodeCursor(cursor string) (*Cursor, error) {
+	if cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var result Cursor
+	if err := json.Unmarshal(data, &result); err != nil || result.Position.Key == "" {
+		return nil, ErrInvalidCursor
+	}
+	return &result, nil
+}
+
+type Request struct {
+	// page size
+	First int
+	// nil for the first page
+	Cursor *Cursor
+}
+
+func NewRequest(first int, cursor *Cursor) Request {
+	return Request{
+		First:  first,
+		Cursor: cursor,
+	}
+}
+
+type Info struct {
+	// number of items in the whole listing
+	totalCount int
+	// cursor of the following page, empty on the last page
+	nextCursor string
+	// cursor of the preceding page, empty on the first page
+	prevCursor string
+}
+
+func (i Info) TotalCount() int {
+	return i.totalCount
+}
+
+func (i Info) NextCursor() string {
+	return i.nextCursor
+}
+
+func (i Info) PrevCursor() string {
+	return i.prevCursor
+}
+
+// CompareKeys order positions by key only
+func CompareKeys(a Position, b Position) int {
+	switch {
+	case a.Key < b.Key:
+		return -1
+	case a.Key > b.Key:
+		return 1
+	}
+	return 0
+}
+
+// Paginate select the page [start, end) of request out of n items sorted by compare under order.
+// position return the Position of the i-th item.
+func Paginate(n int, order string, request Request, position func(i int) Position, compare func(a Position, b Position) int) (int, int, Info, error) {
+	info := Info{
+		totalCount: n,
+	}
+
+	start, end := 0, request.First
+	if request.Cursor != nil {
+		if request.Cursor.Order != order {
+			return 0, 0, Info{}, ErrInvalidCursor
+		}
+		if request.Cursor.Backward {
+			end = sort.Search(n, func(i int) bool {
+				return compare(position(i), request.Cursor.Position) >= 0
+			})
+			start = end - request.First
+		} else {
+			start = sort.Search(n, func(i int) bool {
+				return compare(position(i), request.Cursor.Position) > 0
+			})
+			end = start + request.First
+		}
+	}
+	if start < 0 {
+		start = 0
+	}
+	if end > n {
+		end = n
+	}
+
+	if end < n && end > start {
+		info.nextCursor = Cursor{Order: order, Position: position(end - 1)}.Encode()
+	}
+	if start > 0 {
+		boundary := request.Cursor.Position
+		if start < n {
+			boundary = position(start)
+		}
+		info.prevCursor = Cursor{Order: order, Position: boundary, Backward: true}.Encode()
+	}
+
+	return start, end, info, nil
+}
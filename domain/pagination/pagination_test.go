@@ -0,0 +1,98 @@
+package pagination
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// page run Paginate over keys ordered by key
+func page(t *testing.T, keys []string, order string, request Request) ([]string, Info) {
+	t.Helper()
+	start, end, info, err := Paginate(len(keys), order, request, func(i int) Position {
+		return Position{Key: keys[i]}
+	}, CompareKeys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys[start:end], info
+}
+
+func decode(t *testing.T, cursor string) *Cursor {
+	t.Helper()
+	result, err := DecodeCursor(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return result
+}
+
+func TestPaginate(t *testing.T) {
+	keys := []string{"a", "b", "c", "d", "e"}
+
+	items, info := page(t, keys, "", NewRequest(2, nil))
+	if !reflect.DeepEqual(items, []string{"a", "b"}) || info.TotalCount() != 5 || info.PrevCursor() != "" {
+		t.Fatalf("first page = %v %+v", items, info)
+	}
+
+	items, info = page(t, keys, "", NewRequest(2, decode(t, info.NextCursor())))
+	if !reflect.DeepEqual(items, []string{"c", "d"}) || info.NextCursor() == "" || info.PrevCursor() == "" {
+		t.Fatalf("second page = %v %+v", items, info)
+	}
+	prevCursor := info.PrevCursor()
+
+	items, info = page(t, keys, "", NewRequest(2, decode(t, info.NextCursor())))
+	if !reflect.DeepEqual(items, []string{"e"}) || info.NextCursor() != "" {
+		t.Fatalf("last page = %v %+v", items, info)
+	}
+
+	items, info = page(t, keys, "", NewRequest(2, decode(t, prevCursor)))
+	if !reflect.DeepEqual(items, []string{"a", "b"}) || info.PrevCursor() != "" {
+		t.Fatalf("page before the second = %v %+v", items, info)
+	}
+}
+
+func TestPaginateStableAcrossChanges(t *testing.T) {
+	_, info := page(t, []string{"a", "b", "c", "d", "e"}, "", NewRequest(2, nil))
+	cursor := decode(t, info.NextCursor())
+
+	// saving an item before the cursor or removing the last item of the page never shifts the next page
+	items, _ := page(t, []string{"0", "a", "c", "d", "e"}, "", NewRequest(2, cursor))
+	if !reflect.DeepEqual(items, []string{"c", "d"}) {
+		t.Errorf("next page after changes = %v, want [c d]", items)
+	}
+}
+
+func TestPaginateEmpty(t *testing.T) {
+	items, info := page(t, nil, "", NewRequest(2, nil))
+	if len(items) != 0 || info.TotalCount() != 0 || info.NextCursor() != "" || info.PrevCursor() != "" {
+		t.Errorf("empty listing = %v %+v", items, info)
+	}
+}
+
+func TestPaginateOtherOrder(t *testing.T) {
+	cursor := Cursor{Order: "cutOffTime", Position: Position{Key: "b"}}
+	_, _, _, err := Paginate(3, "", NewRequest(2, &cursor), func(i int) Position {
+		return Position{Key: []string{"a", "b", "c"}[i]}
+	}, CompareKeys)
+	if !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Paginate() with a cursor of another order error = %v, want %v", err, ErrInvalidCursor)
+	}
+}
+
+func TestDecodeCursor(t *testing.T) {
+	cursor := Cursor{Order: "name", Position: Position{Value: "Lakers", Key: "lakers"}, Backward: true}
+	decoded, err := DecodeCursor(cursor.Encode())
+	if err != nil || !reflect.DeepEqual(*decoded, cursor) {
+		t.Errorf("DecodeCursor(Encode()) = %+v, %v, want %+v", decoded, err, cursor)
+	}
+
+	if decoded, err := DecodeCursor(""); decoded != nil || err != nil {
+		t.Errorf("DecodeCursor(\"\") = %+v, %v, want the start of the listing", decoded, err)
+	}
+	for _, invalid := range []string{"not base64!", "bm90IGpzb24", Cursor{}.Encode()} {
+		if _, err := DecodeCursor(invalid); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) error = %v, want %v", invalid, err, ErrInvalidCursor)
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package replication
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+var (
+	ErrNotReplica = failure.New(failure.NotFound, "not_replica", "this instance is not following a peer")
+)
+
+type Repository interface {
+	SaveStatus(ctx context.Context, status Status) error
+	// GetStatus return ErrNotReplica until a status is saved
+	GetStatus(ctx context.Context) (Status, error)
+}
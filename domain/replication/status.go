@@ -0,0 +1,64 @@
+package replication
+
+import (
+	"time"
+)
+
+// Status of a replica following the change feed of a peer instance
+type Status struct {
+	// base URL of the followed peer
+	peer string
+	// last peer sequence applied to the local repository
+	sequence uint64
+	// latest sequence of the peer when last synced
+	peerSequence uint64
+	// time of the last successful sync
+	lastSync time.Time
+	// time the last applied change happened on the peer
+	lastChange time.Time
+	// number of full resyncs since start, including the initial bootstrap
+	resyncs int
+}
+
+func NewStatus(peer string, sequence uint64, peerSequence uint64, lastSync time.Time, lastChange time.Time, resyncs int) Status {
+	return Status{
+		peer:         peer,
+		sequence:     sequence,
+		peerSequence: peerSequence,
+		lastSync:     lastSync,
+		lastChange:   lastChange,
+		resyncs:      resyncs,
+	}
+}
+
+func (s Status) Peer() string {
+	return s.peer
+}
+
+func (s Status) Sequence() uint64 {
+	return s.sequence
+}
+
+func (s Status) PeerSequence() uint64 {
+	return s.peerSequence
+}
+
+func (s Status) LastSync() time.Time {
+	return s.lastSync
+}
+
+func (s Status) LastChange() time.Time {
+	return s.lastChange
+}
+
+func (s Status) Resyncs() int {
+	return s.resyncs
+}
+
+// Lag return the number of peer changes not applied yet as of the last sync
+func (s Status) Lag() uint64 {
+	if s.peerSequence < s.sequence {
+		return 0
+	}
+	return s.peerSequence - s.sequence
+}
@@ -0,0 +1,10 @@
+package search
+
+import (
+	"context"
+)
+
+type Repository interface {
+	// Search return documents matching every term of query by prefix or typo tolerant match, limited to kinds when not empty
+	Search(ctx context.Context, query string, kinds []string, limit int) ([]Result, error)
+}
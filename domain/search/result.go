@@ -0,0 +1,72 @@
+package search
+
+import (
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+const (
+	KindEvent       = "event"
+	KindTeam        = "team"
+	KindCompetition = "competition"
+	KindCategory    = "category"
+)
+
+var (
+	ErrEmptyQuery   = failure.New(failure.Invalid, "empty_query", "search query cannot be empty")
+	ErrInvalidKind  = failure.New(failure.Invalid, "invalid_search_type", "search type must be one of event, team, competition, category")
+	ErrInvalidLimit = failure.New(failure.Invalid, "invalid_limit", "limit cannot be smaller than 1 or larger than 50")
+)
+
+type Result struct {
+	// type of the matched document, event, team, competition or category
+	kind string
+	// slug of the matched document
+	key string
+	// display name of the matched document
+	name string
+	// relevance of the match, higher is better
+	score float64
+	// cutoff time of the event, or the nearest upcoming event for other kinds
+	cutoffTime time.Time
+}
+
+func NewResult(kind string, key string, name string, score float64, cutoffTime time.Time) Result {
+	return Result{
+		kind:       kind,
+		key:        key,
+		name:       name,
+		score:      score,
+		cutoffTime: cutoffTime,
+	}
+}
+
+func (r Result) Kind() string {
+	return r.kind
+}
+
+func (r Result) Key() string {
+	return r.key
+}
+
+func (r Result) Name() string {
+	return r.name
+}
+
+func (r Result) Score() float64 {
+	return r.score
+}
+
+func (r Result) CutoffTime() time.Time {
+	return r.cutoffTime
+}
+
+// ValidKind check whether kind can be searched
+func ValidKind(kind string) bool {
+	switch kind {
+	case KindEvent, KindTeam, KindCompetition, KindCategory:
+		return true
+	}
+	return false
+}
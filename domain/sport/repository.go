@@ -2,9 +2,14 @@ package sport
 
 import (
 	"context"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+	"github.com/awcjack/cloudbet/domain/pagination"
 )
 
+var ErrSportNotFound = failure.New(failure.NotFound, "sport_not_found", "sport not found")
+
 type Repository interface {
-	ListSports(ctx context.Context, first int, page int) ([]Sport, error)
+	ListSports(ctx context.Context, request pagination.Request) ([]Sport, pagination.Info, error)
 	GetSport(ctx context.Context, sportKey string) (Sport, error)
 }
@@ -1,5 +1,9 @@
 package sport
 
+import (
+	"github.com/awcjack/cloudbet/domain/activity"
+)
+
 type Sport struct {
 	// name of this Identifier
 	name string
@@ -7,13 +11,22 @@ type Sport struct {
 	key string
 	// average live time
 	liveTime float64
+	// number of categories with competitions of this Sport
+	categoryCount int
+	// number of competitions of this Sport
+	competitionCount int
+	// events of this Sport
+	activity activity.Activity
 }
 
-func NewSport(name string, key string, liveTime float64) Sport {
+func NewSport(name string, key string, liveTime float64, categoryCount int, competitionCount int, activity activity.Activity) Sport {
 	return Sport{
-		name:     name,
-		key:      key,
-		liveTime: liveTime,
+		name:             name,
+		key:              key,
+		liveTime:         liveTime,
+		categoryCount:    categoryCount,
+		competitionCount: competitionCount,
+		activity:         activity,
 	}
 }
 
@@ -32,3 +45,15 @@ func (s *Sport) SetLiveTime(time float64) {
 func (s Sport) LiveTime() float64 {
 	return s.liveTime
 }
+
+func (s Sport) CategoryCount() int {
+	return s.categoryCount
+}
+
+func (s Sport) CompetitionCount() int {
+	return s.competitionCount
+}
+
+func (s Sport) Activity() activity.Activity {
+	return s.activity
+}
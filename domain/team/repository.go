@@ -0,0 +1,15 @@
+package team
+
+import (
+	"context"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+	"github.com/awcjack/cloudbet/domain/pagination"
+)
+
+var ErrTeamNotFound = failure.New(failure.NotFound, "team_not_found", "team not found")
+
+type Repository interface {
+	ListTeams(ctx context.Context, request pagination.Request, nationality string) ([]Team, pagination.Info, error)
+	GetTeam(ctx context.Context, teamKey string) (Team, error)
+}
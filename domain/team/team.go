@@ -0,0 +1,37 @@
+package team
+
+type Team struct {
+	// name of this Team
+	name string
+	// slug for this Team
+	key string
+	// abbreviation for this team's name
+	abbreviation string
+	// team country code
+	nationality string
+}
+
+func NewTeam(name string, key string, abbreviation string, nationality string) Team {
+	return Team{
+		name:         name,
+		key:          key,
+		abbreviation: abbreviation,
+		nationality:  nationality,
+	}
+}
+
+func (t Team) Key() string {
+	return t.key
+}
+
+func (t Team) Name() string {
+	return t.name
+}
+
+func (t Team) Abbreviation() string {
+	return t.abbreviation
+}
+
+func (t Team) Nationality() string {
+	return t.nationality
+}
@@ -0,0 +1,79 @@
+package valuebet
+
+import (
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+var (
+	ErrMissingEventKey    = failure.New(failure.Invalid, "missing_event_key", "missing event key")
+	ErrMissingMarketKey   = failure.New(failure.Invalid, "missing_market_key", "missing market key")
+	ErrMissingOutcome     = failure.New(failure.Invalid, "missing_outcome", "missing outcome")
+	ErrInvalidProbability = failure.New(failure.Invalid, "invalid_probability", "probability must be larger than 0 and not larger than 1")
+	ErrInvalidEdge        = failure.New(failure.Invalid, "invalid_edge", "edge cannot be smaller than 0")
+	ErrInvalidBankroll    = failure.New(failure.Invalid, "invalid_bankroll", "bankroll cannot be smaller than 0")
+)
+
+type Probability struct {
+	// slug of the event this Probability is modelled for
+	eventKey string
+	// market key of the selection
+	marketKey string
+	// outcome of the selection
+	outcome string
+	// parameters of the selection, such as handicap, period etc.
+	params string
+	// fair probability of the outcome produced by the model
+	probability float64
+	// time that this Probability was uploaded
+	updatedAt time.Time
+}
+
+func NewProbability(eventKey string, marketKey string, outcome string, params string, probability float64) (Probability, error) {
+	if eventKey == "" {
+		return Probability{}, ErrMissingEventKey
+	}
+	if marketKey == "" {
+		return Probability{}, ErrMissingMarketKey
+	}
+	if outcome == "" {
+		return Probability{}, ErrMissingOutcome
+	}
+	if probability <= 0 || probability > 1 {
+		return Probability{}, ErrInvalidProbability
+	}
+
+	return Probability{
+		eventKey:    eventKey,
+		marketKey:   marketKey,
+		outcome:     outcome,
+		params:      params,
+		probability: probability,
+		updatedAt:   time.Now(),
+	}, nil
+}
+
+func (p Probability) EventKey() string {
+	return p.eventKey
+}
+
+func (p Probability) MarketKey() string {
+	return p.marketKey
+}
+
+func (p Probability) Outcome() string {
+	return p.outcome
+}
+
+func (p Probability) Params() string {
+	return p.params
+}
+
+func (p Probability) Probability() float64 {
+	return p.probability
+}
+
+func (p Probability) UpdatedAt() time.Time {
+	return p.updatedAt
+}
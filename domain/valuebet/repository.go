@@ -0,0 +1,10 @@
+package valuebet
+
+import (
+	"context"
+)
+
+type Repository interface {
+	SaveProbabilities(ctx context.Context, probabilities []Probability) error
+	ListProbabilities(ctx context.Context) ([]Probability, error)
+}
@@ -0,0 +1,131 @@
+package valuebet
+
+import (
+	"math"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+type ValueBet struct {
+	// slug of the event offering this ValueBet
+	eventKey string
+	// name of the event offering this ValueBet
+	eventName string
+	// event cutoff time
+	cutoffTime time.Time
+	// market key of the selection
+	marketKey string
+	// submarket key of the selection
+	submarketKey string
+	// outcome of the selection
+	outcome string
+	// parameters of the selection
+	params string
+	// cached Cloudbet price of the selection
+	price float64
+	// fair probability produced by the model
+	modelProbability float64
+	// expected return per unit staked, price * modelProbability - 1
+	edge float64
+	// fraction of bankroll suggested by the Kelly criterion
+	kellyFraction float64
+	// suggested stake in EUR, Kelly stake capped at maxStake
+	stake float64
+	// maximum stake in EUR of the selection
+	maxStake float64
+}
+
+func (v ValueBet) EventKey() string {
+	return v.eventKey
+}
+
+func (v ValueBet) EventName() string {
+	return v.eventName
+}
+
+func (v ValueBet) CutoffTime() time.Time {
+	return v.cutoffTime
+}
+
+func (v ValueBet) MarketKey() string {
+	return v.marketKey
+}
+
+func (v ValueBet) SubmarketKey() string {
+	return v.submarketKey
+}
+
+func (v ValueBet) Outcome() string {
+	return v.outcome
+}
+
+func (v ValueBet) Params() string {
+	return v.params
+}
+
+func (v ValueBet) Price() float64 {
+	return v.price
+}
+
+func (v ValueBet) ModelProbability() float64 {
+	return v.modelProbability
+}
+
+func (v ValueBet) Edge() float64 {
+	return v.edge
+}
+
+func (v ValueBet) KellyFraction() float64 {
+	return v.kellyFraction
+}
+
+func (v ValueBet) Stake() float64 {
+	return v.stake
+}
+
+func (v ValueBet) MaxStake() float64 {
+	return v.maxStake
+}
+
+// Find compare model probabilities of an event with its cached prices and return selections whose edge exceed minEdge
+func Find(e event.Event, probabilities []Probability, minEdge float64, bankroll float64) []ValueBet {
+	if !e.Active() {
+		return nil
+	}
+
+	var result []ValueBet
+	for _, probability := range probabilities {
+		if probability.EventKey() != e.Key() {
+			continue
+		}
+		selection, submarketKey, err := e.Selection(probability.MarketKey(), probability.Outcome(), probability.Params())
+		if err != nil || selection.Status() == "SELECTION_DISABLED" || selection.Price() <= 1 {
+			continue
+		}
+
+		edge := selection.Price()*probability.Probability() - 1
+		if edge <= minEdge {
+			continue
+		}
+		kellyFraction := edge / (selection.Price() - 1)
+
+		result = append(result, ValueBet{
+			eventKey:         e.Key(),
+			eventName:        e.Name(),
+			cutoffTime:       e.CutOffTime(),
+			marketKey:        probability.MarketKey(),
+			submarketKey:     submarketKey,
+			outcome:          selection.Outcome(),
+			params:           selection.Params(),
+			price:            selection.Price(),
+			modelProbability: probability.Probability(),
+			edge:             edge,
+			kellyFraction:    kellyFraction,
+			stake:            math.Min(kellyFraction*bankroll, selection.MaxStake()),
+			maxStake:         selection.MaxStake(),
+		})
+	}
+
+	return result
+}
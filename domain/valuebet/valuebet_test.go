@@ -0,0 +1,160 @@
+package valuebet
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+)
+
+const testMarketKey = "soccer.match_odds"
+
+func newTestEvent(t *testing.T, active bool) event.Event {
+	t.Helper()
+	sport, _ := event.NewIdentifier("Soccer", "soccer")
+	category, _ := event.NewIdentifier("England", "england")
+	competition, _ := event.NewIdentifier("Premier League", "soccer-england-premier-league")
+	markets := map[string]event.Market{
+		testMarketKey: event.NewMarket(map[string][]event.Selection{"period=ft": {
+			event.NewSelection("home", "", 2.5, 100, 0.38, "SELECTION_ENABLED", "BACK"),
+			event.NewSelection("draw", "", 3.4, 100, 0.28, "SELECTION_DISABLED", "BACK"),
+			event.NewSelection("away", "", 3.0, 20, 0.31, "SELECTION_ENABLED", "BACK"),
+			event.NewSelection("away", "", 3.6, 100, 0.31, "SELECTION_ENABLED", "LAY"),
+		}}),
+	}
+	e, err := event.NewEvent(&sport, &competition, &category, event.TeamIdentifier{}, event.TeamIdentifier{}, active, false, markets, "Arsenal V Chelsea", "arsenal-v-chelsea", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *e
+}
+
+func newTestProbability(t *testing.T, eventKey string, outcome string, probability float64) Probability {
+	t.Helper()
+	p, err := NewProbability(eventKey, testMarketKey, outcome, "", probability)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestNewProbability(t *testing.T) {
+	tests := []struct {
+		name        string
+		eventKey    string
+		marketKey   string
+		outcome     string
+		probability float64
+		wantErr     error
+	}{
+		{name: "valid", eventKey: "arsenal-v-chelsea", marketKey: testMarketKey, outcome: "home", probability: 1},
+		{name: "missing event", marketKey: testMarketKey, outcome: "home", probability: 0.5, wantErr: ErrMissingEventKey},
+		{name: "missing market", eventKey: "arsenal-v-chelsea", outcome: "home", probability: 0.5, wantErr: ErrMissingMarketKey},
+		{name: "missing outcome", eventKey: "arsenal-v-chelsea", marketKey: testMarketKey, probability: 0.5, wantErr: ErrMissingOutcome},
+		{name: "zero probability", eventKey: "arsenal-v-chelsea", marketKey: testMarketKey, outcome: "home", wantErr: ErrInvalidProbability},
+		{name: "probability over 1", eventKey: "arsenal-v-chelsea", marketKey: testMarketKey, outcome: "home", probability: 1.01, wantErr: ErrInvalidProbability},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewProbability(tt.eventKey, tt.marketKey, tt.outcome, "", tt.probability); !errors.Is(err, tt.wantErr) {
+				t.Errorf("NewProbability() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	tests := []struct {
+		name        string
+		active      bool
+		probability Probability
+		minEdge     float64
+		bankroll    float64
+		wantPrice   float64
+		wantEdge    float64
+		wantStake   float64
+		wantNone    bool
+	}{
+		{
+			name:        "value bet",
+			active:      true,
+			probability: newTestProbability(t, "arsenal-v-chelsea", "home", 0.5),
+			bankroll:    100,
+			wantPrice:   2.5,
+			wantEdge:    0.25,
+			// Kelly fraction 0.25 / 1.5 of the bankroll
+			wantStake: 100 * 0.25 / 1.5,
+		},
+		{
+			name:        "stake capped by the max stake",
+			active:      true,
+			probability: newTestProbability(t, "arsenal-v-chelsea", "away", 0.5),
+			bankroll:    1000,
+			// the back price, not the lay one
+			wantPrice: 3.0,
+			wantEdge:  0.5,
+			wantStake: 20,
+		},
+		{
+			name:        "edge not above the minimum",
+			active:      true,
+			probability: newTestProbability(t, "arsenal-v-chelsea", "home", 0.5),
+			minEdge:     0.25,
+			wantNone:    true,
+		},
+		{
+			name:        "price below the model",
+			active:      true,
+			probability: newTestProbability(t, "arsenal-v-chelsea", "home", 0.35),
+			wantNone:    true,
+		},
+		{
+			name:        "disabled selection",
+			active:      true,
+			probability: newTestProbability(t, "arsenal-v-chelsea", "draw", 0.5),
+			wantNone:    true,
+		},
+		{
+			name:        "unknown outcome",
+			active:      true,
+			probability: newTestProbability(t, "arsenal-v-chelsea", "over", 0.9),
+			wantNone:    true,
+		},
+		{
+			name:        "probability of another event",
+			active:      true,
+			probability: newTestProbability(t, "chelsea-v-arsenal", "home", 0.9),
+			wantNone:    true,
+		},
+		{
+			name:        "inactive event",
+			probability: newTestProbability(t, "arsenal-v-chelsea", "home", 0.9),
+			wantNone:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Find(newTestEvent(t, tt.active), []Probability{tt.probability}, tt.minEdge, tt.bankroll)
+			if tt.wantNone {
+				if len(got) != 0 {
+					t.Errorf("Find() = %d value bets, want none", len(got))
+				}
+				return
+			}
+			if len(got) != 1 {
+				t.Fatalf("Find() = %d value bets, want 1", len(got))
+			}
+			v := got[0]
+			if v.Price() != tt.wantPrice {
+				t.Errorf("Price() = %f, want %f", v.Price(), tt.wantPrice)
+			}
+			if v.SubmarketKey() != "period=ft" || v.Outcome() != tt.probability.Outcome() || math.Abs(v.Edge()-tt.wantEdge) > 1e-9 || math.Abs(v.Stake()-tt.wantStake) > 1e-9 {
+				t.Errorf("Find() = %s %s edge %f stake %f, want edge %f stake %f", v.SubmarketKey(), v.Outcome(), v.Edge(), v.Stake(), tt.wantEdge, tt.wantStake)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/apikey"
+)
+
+type ApiKeyMemoryRepository struct {
+	keys map[string]apikey.Key
+	// mapping between secret hash and key id
+	hashes map[string]string
+	usages map[string]*keyUsage
+	lock   *sync.Mutex
+}
+
+// keyUsage count requests in fixed windows, a window restart from zero once its end passed
+type keyUsage struct {
+	minuteCount int
+	minuteReset time.Time
+	dayCount    int
+	dayReset    time.Time
+}
+
+func NewApiKeyMemoryRepository() *ApiKeyMemoryRepository {
+	return &ApiKeyMemoryRepository{
+		keys:   make(map[string]apikey.Key),
+		hashes: make(map[string]string),
+		usages: make(map[string]*keyUsage),
+		lock:   &sync.Mutex{},
+	}
+}
+
+func (a *ApiKeyMemoryRepository) SaveKey(_ context.Context, key apikey.Key) error {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if previous, ok := a.keys[key.ID()]; ok && previous.Hash() != key.Hash() {
+		delete(a.hashes, previous.Hash())
+	}
+	a.keys[key.ID()] = key
+	a.hashes[key.Hash()] = key.ID()
+
+	return nil
+}
+
+func (a *ApiKeyMemoryRepository) GetKey(_ context.Context, id string) (apikey.Key, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	key, ok := a.keys[id]
+	if !ok {
+		return apikey.Key{}, apikey.ErrKeyNotFound
+	}
+	return key, nil
+}
+
+func (a *ApiKeyMemoryRepository) GetKeyByHash(_ context.Context, hash string) (apikey.Key, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	id, ok := a.hashes[hash]
+	if !ok {
+		return apikey.Key{}, apikey.ErrKeyNotFound
+	}
+	return a.keys[id], nil
+}
+
+func (a *ApiKeyMemoryRepository) ListKeys(_ context.Context) ([]apikey.Key, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	keys := make([]apikey.Key, 0, len(a.keys))
+	for _, key := range a.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].CreatedAt().Equal(keys[j].CreatedAt()) {
+			return keys[i].ID() < keys[j].ID()
+		}
+		return keys[i].CreatedAt().Before(keys[j].CreatedAt())
+	})
+	return keys, nil
+}
+
+func (a *ApiKeyMemoryRepository) RecordRequest(_ context.Context, id string, now time.Time) (apikey.Usage, error) {
+	a.lock.Lock()
+	defer a.lock.Unlock()
+
+	if _, ok := a.keys[id]; !ok {
+		return apikey.Usage{}, apikey.ErrKeyNotFound
+	}
+	usage, ok := a.usages[id]
+	if !ok {
+		usage = &keyUsage{}
+		a.usages[id] = usage
+	}
+	if !now.Before(usage.minuteReset) {
+		usage.minuteCount = 0
+		usage.minuteReset = now.Truncate(time.Minute).Add(time.Minute)
+	}
+	if !now.Before(usage.dayReset) {
+		usage.dayCount = 0
+		year, month, day := now.UTC().Date()
+		usage.dayReset = time.Date(year, month, day+1, 0, 0, 0, 0, time.UTC)
+	}
+	usage.minuteCount++
+	usage.dayCount++
+
+	return apikey.NewUsage(usage.minuteCount, usage.minuteReset, usage.dayCount, usage.dayReset), nil
+}
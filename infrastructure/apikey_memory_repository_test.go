@@ -0,0 +1,82 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/apikey"
+)
+
+func TestRecordRequestWindows(t *testing.T) {
+	ctx := context.Background()
+	repo := NewApiKeyMemoryRepository()
+	key, err := apikey.NewKey("id", "name", "hash", []string{apikey.ScopeRead}, 10, 100, time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SaveKey(ctx, key); err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2022, 6, 1, 23, 58, 30, 0, time.UTC)
+	tests := []struct {
+		name        string
+		now         time.Time
+		minuteCount int
+		minuteReset time.Time
+		dayCount    int
+		dayReset    time.Time
+	}{
+		{
+			name:        "first request",
+			now:         start,
+			minuteCount: 1,
+			minuteReset: time.Date(2022, 6, 1, 23, 59, 0, 0, time.UTC),
+			dayCount:    1,
+			dayReset:    time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "same minute",
+			now:         start.Add(29 * time.Second),
+			minuteCount: 2,
+			minuteReset: time.Date(2022, 6, 1, 23, 59, 0, 0, time.UTC),
+			dayCount:    2,
+			dayReset:    time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "minute window restarts at its end",
+			now:         start.Add(30 * time.Second),
+			minuteCount: 1,
+			minuteReset: time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC),
+			dayCount:    3,
+			dayReset:    time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "day window restarts at UTC midnight",
+			now:         start.Add(90 * time.Second),
+			minuteCount: 1,
+			minuteReset: time.Date(2022, 6, 2, 0, 1, 0, 0, time.UTC),
+			dayCount:    1,
+			dayReset:    time.Date(2022, 6, 3, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		usage, err := repo.RecordRequest(ctx, key.ID(), tt.now)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if usage.MinuteCount() != tt.minuteCount || !usage.MinuteReset().Equal(tt.minuteReset) {
+			t.Errorf("%s: minute window %d until %s, want %d until %s", tt.name, usage.MinuteCount(), usage.MinuteReset(), tt.minuteCount, tt.minuteReset)
+		}
+		if usage.DayCount() != tt.dayCount || !usage.DayReset().Equal(tt.dayReset) {
+			t.Errorf("%s: day window %d until %s, want %d until %s", tt.name, usage.DayCount(), usage.DayReset(), tt.dayCount, tt.dayReset)
+		}
+	}
+
+	if _, err := repo.RecordRequest(ctx, "unknown", start); !errors.Is(err, apikey.ErrKeyNotFound) {
+		t.Errorf("RecordRequest() of unknown key error = %v, want %v", err, apikey.ErrKeyNotFound)
+	}
+}
@@ -0,0 +1,54 @@
+package infrastructure
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/awcjack/cloudbet/domain/arbitrage"
+)
+
+type ArbitrageMemoryRepository struct {
+	// opportunities found in the latest scan, most profitable first
+	opportunities []arbitrage.Opportunity
+	lock          *sync.RWMutex
+}
+
+func NewArbitrageMemoryRepository() *ArbitrageMemoryRepository {
+	return &ArbitrageMemoryRepository{
+		opportunities: make([]arbitrage.Opportunity, 0),
+		lock:          &sync.RWMutex{},
+	}
+}
+
+func (a *ArbitrageMemoryRepository) ReplaceOpportunities(_ context.Context, opportunities []arbitrage.Opportunity) error {
+	sorted := make([]arbitrage.Opportunity, len(opportunities))
+	copy(sorted, opportunities)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].ProfitPercentage() > sorted[j].ProfitPercentage()
+	})
+
+	a.lock.Lock()
+	defer a.lock.Unlock()
+	a.opportunities = sorted
+
+	return nil
+}
+
+func (a *ArbitrageMemoryRepository) ListOpportunities(_ context.Context, first int, page int) ([]arbitrage.Opportunity, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if len(a.opportunities) <= (page-1)*first {
+		return []arbitrage.Opportunity{}, nil
+	}
+
+	var target []arbitrage.Opportunity
+	if len(a.opportunities) <= (page)*first {
+		target = a.opportunities[(page-1)*first:]
+	} else {
+		target = a.opportunities[(page-1)*first : (page)*first]
+	}
+
+	return target, nil
+}
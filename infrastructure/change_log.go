@@ -0,0 +1,120 @@
+package infrastructure
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/change"
+)
+
+type changeEntry struct {
+	sequence uint64
+	kind     string
+	eventKey string
+	time     time.Time
+}
+
+// changeLog is a compacted log of event changes keeping only the latest change of every event, not safe for concurrent use
+type changeLog struct {
+	// entries in sequence order, including superseded ones until the next compaction
+	entries []changeEntry
+	// sequence of the latest change of every event still in the log
+	latest map[string]uint64
+	// number of superseded entries in entries
+	superseded int
+	// last sequence assigned
+	sequence uint64
+	// changes up to this sequence may have been pruned
+	floor uint64
+	// random ID of this log, so consumers can tell its sequences from the ones of a log started before a restart
+	epoch string
+}
+
+func newChangeLog() *changeLog {
+	return &changeLog{
+		entries: make([]changeEntry, 0),
+		latest:  make(map[string]uint64),
+		epoch:   newEpoch(),
+	}
+}
+
+func newEpoch() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(id)
+}
+
+// record append a change of eventKey and return its sequence
+func (l *changeLog) record(kind string, eventKey string, time time.Time) uint64 {
+	l.sequence++
+	if _, ok := l.latest[eventKey]; ok {
+		l.superseded++
+	}
+	l.latest[eventKey] = l.sequence
+	l.entries = append(l.entries, changeEntry{
+		sequence: l.sequence,
+		kind:     kind,
+		eventKey: eventKey,
+		time:     time,
+	})
+
+	// compact once superseded entries dominate, so the log stays proportional to the number of events
+	if l.superseded > len(l.entries)/2 {
+		l.compact()
+	}
+	return l.sequence
+}
+
+func (l *changeLog) compact() {
+	entries := make([]changeEntry, 0, len(l.latest))
+	for _, entry := range l.entries {
+		if l.latest[entry.eventKey] == entry.sequence {
+			entries = append(entries, entry)
+		}
+	}
+	l.entries = entries
+	l.superseded = 0
+}
+
+// since return up to limit current entries with sequence larger than sequence.
+// Sequence 0 is a full resync and never expires, a new consumer has nothing to delete.
+func (l *changeLog) since(sequence uint64, limit int) ([]changeEntry, error) {
+	if sequence != 0 && sequence < l.floor {
+		return nil, change.ErrSequenceExpired
+	}
+
+	result := make([]changeEntry, 0)
+	for i := sort.Search(len(l.entries), func(i int) bool {
+		return l.entries[i].sequence > sequence
+	}); i < len(l.entries) && len(result) < limit; i++ {
+		if l.latest[l.entries[i].eventKey] == l.entries[i].sequence {
+			result = append(result, l.entries[i])
+		}
+	}
+	return result, nil
+}
+
+// pruneTombstones drop deletion records older than before and raise the floor past them
+func (l *changeLog) pruneTombstones(before time.Time) {
+	entries := make([]changeEntry, 0, len(l.entries))
+	for _, entry := range l.entries {
+		if l.latest[entry.eventKey] != entry.sequence {
+			continue
+		}
+		if entry.kind == change.TypeDeleted && entry.time.Before(before) {
+			delete(l.latest, entry.eventKey)
+			if entry.sequence > l.floor {
+				l.floor = entry.sequence
+			}
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	l.entries = entries
+	l.superseded = 0
+}
@@ -0,0 +1,98 @@
+package infrastructure
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/change"
+)
+
+func newTestChangeLog(base time.Time) *changeLog {
+	l := newChangeLog()
+	for i, v := range []struct {
+		kind     string
+		eventKey string
+	}{
+		{change.TypeCreated, "a"},
+		{change.TypeCreated, "b"},
+		{change.TypeUpdated, "a"},
+		{change.TypeCreated, "c"},
+		{change.TypeDeleted, "b"},
+	} {
+		l.record(v.kind, v.eventKey, base.Add(time.Duration(i)*time.Minute))
+	}
+	return l
+}
+
+func changeIDs(entries []changeEntry) []string {
+	ids := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.kind+":"+entry.eventKey)
+	}
+	return ids
+}
+
+func TestChangeLogSince(t *testing.T) {
+	base := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		prune    time.Time
+		sequence uint64
+		limit    int
+		want     []string
+		wantErr  error
+	}{
+		{name: "full resync with only the latest change of every event", limit: 10, want: []string{"updated:a", "created:c", "deleted:b"}},
+		{name: "after a superseded sequence", sequence: 1, limit: 10, want: []string{"updated:a", "created:c", "deleted:b"}},
+		{name: "after a sequence", sequence: 3, limit: 10, want: []string{"created:c", "deleted:b"}},
+		{name: "limit", limit: 2, want: []string{"updated:a", "created:c"}},
+		{name: "up to date", sequence: 5, limit: 10, want: []string{}},
+		{name: "recent tombstone kept", prune: base.Add(4 * time.Minute), sequence: 3, limit: 10, want: []string{"created:c", "deleted:b"}},
+		{name: "full resync after pruning", prune: base.Add(time.Hour), limit: 10, want: []string{"updated:a", "created:c"}},
+		{name: "at the floor after pruning", prune: base.Add(time.Hour), sequence: 5, limit: 10, want: []string{}},
+		{name: "expired after pruning", prune: base.Add(time.Hour), sequence: 4, limit: 10, wantErr: change.ErrSequenceExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newTestChangeLog(base)
+			if !tt.prune.IsZero() {
+				l.pruneTombstones(tt.prune)
+			}
+			entries, err := l.since(tt.sequence, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("since() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := changeIDs(entries); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("since() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChangeLogCompaction(t *testing.T) {
+	l := newChangeLog()
+	for i := 0; i < 100; i++ {
+		l.record(change.TypeUpdated, "a", time.Time{})
+		l.record(change.TypeUpdated, "b", time.Time{})
+	}
+	if len(l.entries) > 4 {
+		t.Errorf("%d entries kept for 2 events", len(l.entries))
+	}
+	entries, err := l.since(0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 || entries[0].sequence != 199 || entries[1].sequence != 200 || l.sequence != 200 {
+		t.Errorf("since() = %+v", entries)
+	}
+	if newChangeLog().epoch == l.epoch {
+		t.Errorf("epoch %s reused", l.epoch)
+	}
+}
@@ -0,0 +1,67 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+
+	"github.com/awcjack/cloudbet/domain/crawl"
+)
+
+type CrawlRunMemoryRepository struct {
+	// runs in ID order, oldest first
+	runs []crawl.Run
+	// number of runs kept
+	retention int
+	lock      *sync.RWMutex
+}
+
+func NewCrawlRunMemoryRepository(retention int) *CrawlRunMemoryRepository {
+	return &CrawlRunMemoryRepository{
+		runs:      make([]crawl.Run, 0),
+		retention: retention,
+		lock:      &sync.RWMutex{},
+	}
+}
+
+func (c *CrawlRunMemoryRepository) SaveRun(_ context.Context, run crawl.Run) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for i := len(c.runs) - 1; i >= 0; i-- {
+		if c.runs[i].ID() == run.ID() {
+			c.runs[i] = run
+			return nil
+		}
+	}
+	c.runs = append(c.runs, run)
+	if len(c.runs) > c.retention {
+		c.runs = append(make([]crawl.Run, 0, c.retention), c.runs[len(c.runs)-c.retention:]...)
+	}
+
+	return nil
+}
+
+func (c *CrawlRunMemoryRepository) GetRun(_ context.Context, id uint64) (crawl.Run, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	for i := len(c.runs) - 1; i >= 0; i-- {
+		if c.runs[i].ID() == id {
+			return c.runs[i], nil
+		}
+	}
+	return crawl.Run{}, crawl.ErrRunNotFound
+}
+
+func (c *CrawlRunMemoryRepository) ListRuns(_ context.Context, filter crawl.Filter, first int) ([]crawl.Run, error) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	result := make([]crawl.Run, 0, first)
+	for i := len(c.runs) - 1; i >= 0 && len(result) < first; i-- {
+		if c.runs[i].Matches(filter) {
+			result = append(result, c.runs[i])
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,83 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/crawl"
+)
+
+func TestCrawlRunMemoryRepository(t *testing.T) {
+	ctx := context.Background()
+	start := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	repo := NewCrawlRunMemoryRepository(4)
+	for i, v := range []struct {
+		kind    string
+		trigger string
+		failed  bool
+	}{
+		{crawl.KindFull, crawl.TriggerScheduled, false},
+		{crawl.KindFull, crawl.TriggerScheduled, true},
+		{crawl.KindCompetition, crawl.TriggerManual, false},
+		{crawl.KindFull, crawl.TriggerManual, true},
+		{crawl.KindFull, crawl.TriggerScheduled, false},
+	} {
+		run := crawl.NewRun(uint64(i+1), v.kind, "", v.trigger, start.Add(time.Duration(i)*time.Minute))
+		if v.failed {
+			run.Fail(crawl.NewItemError(start, crawl.ItemSport, "", 503, "unavailable"))
+		}
+		if err := repo.SaveRun(ctx, run); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// saving a run again updates it in place
+	run, err := repo.GetRun(ctx, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	run.Finish(start.Add(time.Hour))
+	if err := repo.SaveRun(ctx, run); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		filter crawl.Filter
+		first  int
+		want   []uint64
+	}{
+		{name: "latest first within retention", first: 10, want: []uint64{5, 4, 3, 2}},
+		{name: "first", first: 2, want: []uint64{5, 4}},
+		{name: "before", filter: crawl.Filter{Before: 4}, first: 10, want: []uint64{3, 2}},
+		{name: "kind", filter: crawl.Filter{Kind: crawl.KindCompetition}, first: 10, want: []uint64{3}},
+		{name: "trigger", filter: crawl.Filter{Trigger: crawl.TriggerScheduled}, first: 10, want: []uint64{5, 2}},
+		{name: "failed", filter: crawl.Filter{Failed: true}, first: 10, want: []uint64{4, 2}},
+		{name: "failed before", filter: crawl.Filter{Failed: true, Before: 4}, first: 10, want: []uint64{2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs, err := repo.ListRuns(ctx, tt.filter, tt.first)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := make([]uint64, 0, len(runs))
+			for _, run := range runs {
+				got = append(got, run.ID())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ListRuns() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if run, err := repo.GetRun(ctx, 3); err != nil || !run.Finished() {
+		t.Errorf("GetRun(3) = finished %t, %v, want the updated run", run.Finished(), err)
+	}
+	if _, err := repo.GetRun(ctx, 1); !errors.Is(err, crawl.ErrRunNotFound) {
+		t.Errorf("GetRun(1) error = %v, want %v", err, crawl.ErrRunNotFound)
+	}
+}
@@ -2,221 +2,227 @@ package infrastructure
 
 import (
 	"context"
-	"errors"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/awcjack/cloudbet/domain/category"
+	"github.com/awcjack/cloudbet/domain/change"
 	"github.com/awcjack/cloudbet/domain/competition"
 	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/hierarchy"
+	"github.com/awcjack/cloudbet/domain/pagination"
+	"github.com/awcjack/cloudbet/domain/search"
 	"github.com/awcjack/cloudbet/domain/sport"
-)
-
-var (
-	ErrOutOfRange    = errors.New("page exceed max page")
-	ErrEventNotFound = errors.New("event not found")
+	"github.com/awcjack/cloudbet/domain/team"
+	"github.com/awcjack/cloudbet/domain/valuebet"
 )
 
 type MemoryRepository struct {
-	// TODO: change to map?
-	sports             []sport.Sport
-	categories         []category.Category
-	sportsCategories   map[string][]string
-	competitions       []competition.Competition
-	sportsCompetitions map[string][]string
-	events             []event.Event
-	sportsEvents       map[string][]string
-	categoriesEvents   map[string][]string
-	competitionsEvents map[string][]string
-	lock               *sync.RWMutex
+	// sport -> category -> competition -> event
+	hierarchy      *hierarchy.Hierarchy
+	events         []event.Event
+	eventsIndex    map[string]int
+	eventsByCutOff []string
+	// every event change gets the next sequence of changes, which becomes the changed event's version
+	changes     *changeLog
+	teams       map[string]team.Team
+	teamsEvents map[string][]string
+	// model probabilities by event key, then by market, outcome and params
+	probabilities map[string]map[string]valuebet.Probability
+	search        *searchIndex
+	lock          *sync.RWMutex
 }
 
 func NewMemoryRepository() *MemoryRepository {
 	return &MemoryRepository{
-		sports:             make([]sport.Sport, 0),
-		categories:         make([]category.Category, 0),
-		sportsCategories:   make(map[string][]string),
-		competitions:       make([]competition.Competition, 0),
-		sportsCompetitions: make(map[string][]string),
-		events:             make([]event.Event, 0),
-		sportsEvents:       make(map[string][]string),
-		categoriesEvents:   make(map[string][]string),
-		competitionsEvents: make(map[string][]string),
-		lock:               &sync.RWMutex{},
+		hierarchy:      hierarchy.NewHierarchy(),
+		events:         make([]event.Event, 0),
+		eventsIndex:    make(map[string]int),
+		eventsByCutOff: make([]string, 0),
+		teams:          make(map[string]team.Team),
+		teamsEvents:    make(map[string][]string),
+		probabilities:  make(map[string]map[string]valuebet.Probability),
+		search:         newSearchIndex(),
+		changes:        newChangeLog(),
+		lock:           &sync.RWMutex{},
 	}
 }
 
-func (m *MemoryRepository) Save(_ context.Context, event event.Event) error {
+func (m *MemoryRepository) Save(ctx context.Context, event event.Event) error {
+	defer observeOperation(ctx, "save")()
 	m.lock.Lock()
 	defer m.lock.Unlock()
 
-	sportKey := event.Sport().Key()
-	categoryKey := event.Category().Key()
-	competitionKey := event.Competition().Key()
 	eventKey := event.Key()
-	m.sports = append(m.sports, sport.NewSport(event.Sport().Name(), sportKey, 0))
-	found := false
-	for _, v := range m.sports {
-		if v.Key() == sportKey {
-			found = true
-			break
-		}
-	}
-	if !found {
-		m.sports = append(m.sports, sport.NewSport(event.Sport().Name(), sportKey, 0))
-	}
-	found = false
-	for _, v := range m.categories {
-		if v.Key() == categoryKey {
-			found = true
-			break
-		}
-	}
-	if !found {
-		m.categories = append(m.categories, category.NewCategory(event.Category().Name(), categoryKey))
-	}
-	_, ok := m.sportsCategories[sportKey]
-	if ok {
-		found := false
-		for _, v := range m.sportsCategories[sportKey] {
-			if v == categoryKey {
-				found = true
-				break
+
+	if i, ok := m.eventsIndex[eventKey]; ok {
+		previous := m.events[i]
+		// keep the time the event first went live and stopped trading across crawls
+		if !previous.StartTradingLiveTime().IsZero() {
+			event.SetstartTradingLiveTime(previous.StartTradingLiveTime())
+		}
+		if !event.Active() {
+			if previous.Active() {
+				// keep the inactive time given, e.g. by the peer of a replica
+				if event.InactiveTime().IsZero() {
+					event.Inactivate()
+				}
+			} else {
+				event.SetInactiveTime(previous.InactiveTime())
 			}
 		}
-		if !found {
-			m.sportsCategories[sportKey] = append(m.sportsCategories[sportKey], categoryKey)
-		}
-	} else {
-		m.sportsCategories[sportKey] = append(m.sportsCategories[sportKey], categoryKey)
-	}
-	found = false
-	for _, v := range m.competitions {
-		if v.Key() == competitionKey {
-			found = true
-			break
-		}
-	}
-	if !found {
-		m.competitions = append(m.competitions, competition.NewCompetition(event.Competition().Name(), competitionKey))
-	}
-	_, ok = m.sportsCompetitions[sportKey]
-	if ok {
-		found := false
-		for _, v := range m.sportsCompetitions[sportKey] {
-			if v == competitionKey {
-				found = true
-				break
+		if event.SameContent(previous) {
+			event.SetVersion(previous.Epoch(), previous.Version(), previous.LastUpdated())
+		} else {
+			kind := change.TypeUpdated
+			if previous.Active() && !event.Active() {
+				kind = change.TypeInactivated
+			}
+			now := time.Now()
+			event.SetVersion(m.changes.epoch, m.changes.record(kind, eventKey, now), now)
+		}
+		if !previous.CutOffTime().Equal(event.CutOffTime()) {
+			m.removeCutOff(previous)
+			m.insertCutOff(event)
+		}
+		m.hierarchy.Replace(previous, event)
+		// a corrected fixture may change the teams playing
+		for _, teamKey := range []string{previous.Home().Key(), previous.Away().Key()} {
+			if teamKey != event.Home().Key() && teamKey != event.Away().Key() {
+				m.unlinkTeam(teamKey, eventKey)
 			}
 		}
-		if !found {
-			m.sportsCompetitions[sportKey] = append(m.sportsCompetitions[sportKey], competitionKey)
-		}
+		m.events[i] = event
 	} else {
-		m.sportsCompetitions[sportKey] = append(m.sportsCompetitions[sportKey], competitionKey)
-	}
-	found = false
-	for _, v := range m.events {
-		if v.Key() == eventKey {
-			found = true
-			break
+		now := time.Now()
+		if !event.Active() && event.InactiveTime().IsZero() {
+			// first seen already inactive, start the eviction clock now
+			event.SetInactiveTime(now)
 		}
-	}
-	if !found {
+		event.SetVersion(m.changes.epoch, m.changes.record(change.TypeCreated, eventKey, now), now)
+		m.eventsIndex[eventKey] = len(m.events)
 		m.events = append(m.events, event)
+		m.insertCutOff(event)
+		m.hierarchy.Add(event)
 	}
-	_, ok = m.categoriesEvents[categoryKey]
-	if ok {
-		found := false
-		for _, v := range m.sportsEvents[sportKey] {
-			if v == eventKey {
-				found = true
-				break
-			}
-		}
-		if !found {
-			m.sportsEvents[sportKey] = append(m.sportsEvents[sportKey], eventKey)
-		}
-	} else {
-		m.sportsEvents[sportKey] = append(m.sportsEvents[sportKey], eventKey)
-	}
-	_, ok = m.categoriesEvents[categoryKey]
-	if ok {
-		found := false
-		for _, v := range m.categoriesEvents[categoryKey] {
-			if v == eventKey {
-				found = true
-				break
-			}
-		}
-		if !found {
-			m.categoriesEvents[categoryKey] = append(m.categoriesEvents[categoryKey], eventKey)
+	m.saveTeam(event.Home(), eventKey)
+	m.saveTeam(event.Away(), eventKey)
+	m.indexEvent(event)
+
+	return nil
+}
+
+// removeValue return values without value, copied so slices handed out before are not modified
+func removeValue(values []string, value string) []string {
+	for i, v := range values {
+		if v == value {
+			return append(append(make([]string, 0, len(values)-1), values[:i]...), values[i+1:]...)
 		}
-	} else {
-		m.categoriesEvents[categoryKey] = append(m.categoriesEvents[categoryKey], eventKey)
-	}
-	_, ok = m.competitionsEvents[competitionKey]
-	if ok {
-		found := false
-		for _, v := range m.competitionsEvents[competitionKey] {
-			if v == eventKey {
-				found = true
-				break
-			}
+	}
+	return values
+}
+
+// cutOffPosition return where e is or would be in eventsByCutOff, ordered by cutoff time then key
+func (m *MemoryRepository) cutOffPosition(e event.Event) int {
+	return sort.Search(len(m.eventsByCutOff), func(i int) bool {
+		v := m.events[m.eventsIndex[m.eventsByCutOff[i]]]
+		if v.CutOffTime().Equal(e.CutOffTime()) {
+			return v.Key() >= e.Key()
 		}
-		if !found {
-			m.competitionsEvents[competitionKey] = append(m.competitionsEvents[competitionKey], eventKey)
+		return v.CutOffTime().After(e.CutOffTime())
+	})
+}
+
+// insertCutOff add e to eventsByCutOff keeping it sorted
+func (m *MemoryRepository) insertCutOff(e event.Event) {
+	i := m.cutOffPosition(e)
+	m.eventsByCutOff = append(m.eventsByCutOff, "")
+	copy(m.eventsByCutOff[i+1:], m.eventsByCutOff[i:])
+	m.eventsByCutOff[i] = e.Key()
+}
+
+// removeCutOff must be called while e is still the stored version of the event
+func (m *MemoryRepository) removeCutOff(e event.Event) {
+	i := m.cutOffPosition(e)
+	if i < len(m.eventsByCutOff) && m.eventsByCutOff[i] == e.Key() {
+		m.eventsByCutOff = append(m.eventsByCutOff[:i], m.eventsByCutOff[i+1:]...)
+	}
+}
+
+// indexEvent refresh the search documents of an event and its teams, competition and category
+func (m *MemoryRepository) indexEvent(e event.Event) {
+	m.search.put(search.KindEvent, e.Key(), e.Name(), e.CutOffTime(), e.Home().Name(), e.Home().Abbreviation(), e.Away().Name(), e.Away().Abbreviation())
+	for _, t := range []event.TeamIdentifier{e.Home(), e.Away()} {
+		if !t.IsZero() {
+			m.search.put(search.KindTeam, t.Key(), t.Name(), e.CutOffTime(), t.Abbreviation())
 		}
-	} else {
-		m.competitionsEvents[competitionKey] = append(m.competitionsEvents[competitionKey], eventKey)
 	}
+	m.search.put(search.KindCompetition, e.Competition().Key(), e.Competition().Name(), e.CutOffTime(), e.Category().Name())
+	m.search.put(search.KindCategory, e.Category().Key(), e.Category().Name(), e.CutOffTime())
+}
 
-	return nil
+func (m *MemoryRepository) Search(ctx context.Context, query string, kinds []string, limit int) ([]search.Result, error) {
+	defer observeOperation(ctx, "search")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return m.search.search(query, kinds, limit), nil
 }
 
-func (m MemoryRepository) ListSports(_ context.Context, first int, page int) ([]sport.Sport, error) {
-	if len(m.sports) == 0 {
-		return nil, ErrEventNotFound
+func (m *MemoryRepository) saveTeam(teamIdentifier event.TeamIdentifier, eventKey string) {
+	if teamIdentifier.IsZero() {
+		return
 	}
-	if len(m.sports) < (page-1)*first {
-		return nil, ErrOutOfRange
+
+	teamKey := teamIdentifier.Key()
+	// keep team info up to date with the latest crawled event
+	m.teams[teamKey] = team.NewTeam(teamIdentifier.Name(), teamKey, teamIdentifier.Abbreviation(), teamIdentifier.Nationality())
+	for _, v := range m.teamsEvents[teamKey] {
+		if v == eventKey {
+			return
+		}
 	}
+	m.teamsEvents[teamKey] = append(m.teamsEvents[teamKey], eventKey)
+}
 
-	var target []sport.Sport
-	if len(m.sports) <= (page)*first {
-		target = m.sports[(page-1)*first : len(m.sports)]
-	} else {
-		target = m.sports[(page-1)*first : (page)*first]
+// unlinkTeam remove eventKey from the events of the team, dropping the team once it has no events left
+func (m *MemoryRepository) unlinkTeam(teamKey string, eventKey string) {
+	if teamKey == "" {
+		return
 	}
 
-	for i, v := range target {
-		counter := 0
-		var timer int64 = 0
-		for _, eventValue := range m.events {
-			if v.Key() == eventValue.Sport().Key() && !eventValue.StartTradingLiveTime().IsZero() && !eventValue.InactiveTime().IsZero() {
-				timer += int64(eventValue.InactiveTime().Sub(eventValue.StartTradingLiveTime()) / time.Millisecond)
-				counter++
-			}
-		}
-		target[i].SetLiveTime(float64(timer) / float64(counter))
+	removeKey(m.teamsEvents, teamKey, eventKey)
+	if _, ok := m.teamsEvents[teamKey]; !ok {
+		delete(m.teams, teamKey)
+		m.search.remove(search.KindTeam, teamKey)
 	}
-	return target, nil
 }
 
-func (m MemoryRepository) GetSport(_ context.Context, sportKey string) (sport.Sport, error) {
-	var found bool
-	var targetSport sport.Sport
-	for _, v := range m.sports {
-		if v.Key() == sportKey {
-			found = true
-			targetSport = v
-			break
-		}
+func (m *MemoryRepository) ListSports(ctx context.Context, request pagination.Request) ([]sport.Sport, pagination.Info, error) {
+	defer observeOperation(ctx, "list_sports")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	keys := m.hierarchy.SportKeys()
+	start, end, info, err := pagination.Paginate(len(keys), "", request, func(i int) pagination.Position {
+		return pagination.Position{Key: keys[i]}
+	}, pagination.CompareKeys)
+	if err != nil {
+		return nil, pagination.Info{}, err
 	}
-	if !found {
-		return sport.Sport{}, ErrEventNotFound
+
+	result := make([]sport.Sport, 0, end-start)
+	for _, key := range keys[start:end] {
+		v, _ := m.hierarchy.Sport(key, m.liveTime(key))
+		result = append(result, v)
 	}
+	return result, info, nil
+}
 
+// liveTime return the average milliseconds events of the sport stay in TRADING_LIVE, NaN when none finished yet
+func (m *MemoryRepository) liveTime(sportKey string) float64 {
 	counter := 0
 	var timer int64 = 0
 	for _, eventValue := range m.events {
@@ -225,131 +231,123 @@ func (m MemoryRepository) GetSport(_ context.Context, sportKey string) (sport.Sp
 			counter++
 		}
 	}
-
-	return sport.NewSport(targetSport.Name(), sportKey, float64(timer)/float64(counter)), nil
+	return float64(timer) / float64(counter)
 }
 
-func (m MemoryRepository) ListCategories(_ context.Context, first int, page int, sportKey string) ([]category.Category, error) {
-	if len(m.categories) == 0 {
-		return nil, ErrEventNotFound
-	}
-	if sportKey == "" {
-		if len(m.categories) < (page-1)*first {
-			return nil, ErrOutOfRange
-		}
-
-		var target []category.Category
-		if len(m.categories) <= (page)*first {
-			target = m.categories[(page-1)*first:]
-		} else {
-			target = m.categories[(page-1)*first : (page)*first]
-		}
-
-		return target, nil
-	}
+func (m *MemoryRepository) GetSport(ctx context.Context, sportKey string) (sport.Sport, error) {
+	defer observeOperation(ctx, "get_sport")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
 
-	v, ok := m.sportsCategories[sportKey]
+	v, ok := m.hierarchy.Sport(sportKey, m.liveTime(sportKey))
 	if !ok {
-		return nil, ErrEventNotFound
+		return sport.Sport{}, sport.ErrSportNotFound
 	}
+	return v, nil
+}
 
-	if len(v) < (page-1)*first {
-		return nil, ErrOutOfRange
-	}
-	var targets []string
-	if len(v) <= (page)*first {
-		targets = v[(page-1)*first:]
-	} else {
-		targets = v[(page-1)*first : (page)*first]
+func (m *MemoryRepository) ListCategories(ctx context.Context, request pagination.Request, sportKey string) ([]category.Category, pagination.Info, error) {
+	defer observeOperation(ctx, "list_categories")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	keys := m.hierarchy.CategoryKeys(sportKey)
+	start, end, info, err := pagination.Paginate(len(keys), "", request, func(i int) pagination.Position {
+		return pagination.Position{Key: keys[i]}
+	}, pagination.CompareKeys)
+	if err != nil {
+		return nil, pagination.Info{}, err
 	}
-	result := make([]category.Category, 0, len(targets))
-	for _, target := range targets {
-		for _, v := range m.categories {
-			if target == v.Key() {
-				result = append(result, v)
-			}
-		}
+
+	result := make([]category.Category, 0, end-start)
+	for _, key := range keys[start:end] {
+		v, _ := m.hierarchy.Category(key, sportKey)
+		result = append(result, v)
 	}
-	return result, nil
+	return result, info, nil
 }
 
-func (m MemoryRepository) GetCategory(_ context.Context, categoryKey string) (category.Category, error) {
-	var found bool
-	var targetCategory category.Category
-	for _, v := range m.categories {
-		if v.Key() == categoryKey {
-			found = true
-			targetCategory = v
-			break
-		}
-	}
-	if !found {
-		return category.Category{}, ErrEventNotFound
-	}
+func (m *MemoryRepository) GetCategory(ctx context.Context, categoryKey string) (category.Category, error) {
+	defer observeOperation(ctx, "get_category")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
 
-	return targetCategory, nil
+	v, ok := m.hierarchy.Category(categoryKey, "")
+	if !ok {
+		return category.Category{}, category.ErrCategoryNotFound
+	}
+	return v, nil
 }
 
-func (m MemoryRepository) ListCompetitions(_ context.Context, first int, page int, sportKey string) ([]competition.Competition, error) {
-	if len(m.competitions) == 0 {
-		return nil, ErrEventNotFound
-	}
-	if sportKey == "" {
-		if len(m.competitions) < (page-1)*first {
-			return nil, ErrOutOfRange
-		}
+func (m *MemoryRepository) ListCompetitions(ctx context.Context, request pagination.Request, filter competition.Filter) ([]competition.Competition, pagination.Info, error) {
+	defer observeOperation(ctx, "list_competitions")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
 
-		var target []competition.Competition
-		if len(m.competitions) <= (page)*first {
-			target = m.competitions[(page-1)*first : len(m.competitions)]
-		} else {
-			target = m.competitions[(page-1)*first : (page)*first]
-		}
-
-		return target, nil
+	keys := m.hierarchy.CompetitionKeys(filter)
+	start, end, info, err := pagination.Paginate(len(keys), "", request, func(i int) pagination.Position {
+		return pagination.Position{Key: keys[i]}
+	}, pagination.CompareKeys)
+	if err != nil {
+		return nil, pagination.Info{}, err
 	}
 
-	v, ok := m.sportsCompetitions[sportKey]
-	if !ok {
-		return nil, ErrEventNotFound
+	result := make([]competition.Competition, 0, end-start)
+	for _, key := range keys[start:end] {
+		v, _ := m.hierarchy.Competition(key)
+		result = append(result, v)
 	}
+	return result, info, nil
+}
 
-	if len(v) < (page-1)*first {
-		return nil, ErrOutOfRange
-	}
+func (m *MemoryRepository) GetCompetition(ctx context.Context, competitionKey string) (competition.Competition, error) {
+	defer observeOperation(ctx, "get_competition")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
 
-	var targets []string
-	if len(v) <= (page)*first {
-		targets = v[(page-1)*first:]
-	} else {
-		targets = v[(page-1)*first : (page)*first]
-	}
-	result := make([]competition.Competition, 0, len(targets))
-	for _, target := range targets {
-		for _, v := range m.competitions {
-			if target == v.Key() {
-				result = append(result, v)
-			}
-		}
+	v, ok := m.hierarchy.Competition(competitionKey)
+	if !ok {
+		return competition.Competition{}, competition.ErrCompetitionNotFound
 	}
-	return result, nil
+	return v, nil
 }
 
-func (m MemoryRepository) GetCompetition(_ context.Context, competitionKey string) (competition.Competition, error) {
-	var found bool
-	var targetCompetition competition.Competition
-	for _, v := range m.competitions {
-		if v.Key() == competitionKey {
-			found = true
-			targetCompetition = v
-			break
+func (m *MemoryRepository) ListTeams(ctx context.Context, request pagination.Request, nationality string) ([]team.Team, pagination.Info, error) {
+	defer observeOperation(ctx, "list_teams")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	keys := make([]string, 0, len(m.teams))
+	for key, v := range m.teams {
+		if nationality == "" || v.Nationality() == nationality {
+			keys = append(keys, key)
 		}
 	}
-	if !found {
-		return competition.Competition{}, ErrEventNotFound
+	sort.Strings(keys)
+	start, end, info, err := pagination.Paginate(len(keys), "", request, func(i int) pagination.Position {
+		return pagination.Position{Key: keys[i]}
+	}, pagination.CompareKeys)
+	if err != nil {
+		return nil, pagination.Info{}, err
+	}
+
+	result := make([]team.Team, 0, end-start)
+	for _, key := range keys[start:end] {
+		result = append(result, m.teams[key])
 	}
+	return result, info, nil
+}
 
-	return targetCompetition, nil
+func (m *MemoryRepository) GetTeam(ctx context.Context, teamKey string) (team.Team, error) {
+	defer observeOperation(ctx, "get_team")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	v, ok := m.teams[teamKey]
+	if !ok {
+		return team.Team{}, team.ErrTeamNotFound
+	}
+	return v, nil
 }
 
 func intersection(s1, s2 []string) (inter []string) {
@@ -363,12 +361,12 @@ func intersection(s1, s2 []string) (inter []string) {
 			inter = append(inter, e)
 		}
 	}
-	//Remove dups from slice.
+	// Remove dups from slice.
 	inter = removeDups(inter)
 	return
 }
 
-//Remove dups from slice.
+// Remove dups from slice.
 func removeDups(elements []string) (nodups []string) {
 	encountered := make(map[string]bool)
 	for _, element := range elements {
@@ -380,119 +378,328 @@ func removeDups(elements []string) (nodups []string) {
 	return
 }
 
-func (m MemoryRepository) ListEvents(_ context.Context, first int, page int, sportKey string, categoryKey string, competitionKey string) ([]event.Event, error) {
-	if len(m.events) == 0 {
-		return nil, ErrEventNotFound
-	}
-	if sportKey == "" && categoryKey == "" && competitionKey == "" {
-		if len(m.events) < (page-1)*first {
-			return nil, ErrOutOfRange
-		}
+func (m *MemoryRepository) ListEvents(ctx context.Context, request pagination.Request, filter event.Filter, order event.Order) ([]event.Event, pagination.Info, error) {
+	defer observeOperation(ctx, "list_events")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
 
-		var target []event.Event
-		if len(m.events) <= (page)*first {
-			target = m.events[(page-1)*first:]
+	var targets []string
+	keyFiltered := false
+	for _, v := range []struct {
+		key    string
+		events func(key string) []string
+	}{
+		{filter.SportKey, m.hierarchy.SportEvents},
+		{filter.CategoryKey, m.hierarchy.CategoryEvents},
+		{filter.CompetitionKey, m.hierarchy.CompetitionEvents},
+		{filter.TeamKey, func(key string) []string { return m.teamsEvents[key] }},
+	} {
+		if v.key == "" {
+			continue
+		}
+		// an unknown key matches no event
+		keys := v.events(v.key)
+		if keyFiltered {
+			targets = intersection(targets, keys)
 		} else {
-			target = m.events[(page-1)*first : (page)*first]
+			targets = keys
+			keyFiltered = true
 		}
-
-		return target, nil
+	}
+	selected := make(map[string]bool, len(targets))
+	for _, target := range targets {
+		selected[target] = true
 	}
 
-	var targets []string
-	if sportKey != "" {
-		v, ok := m.sportsEvents[sportKey]
-		if !ok {
-			return nil, ErrEventNotFound
+	match := func(v event.Event) bool {
+		if keyFiltered && !selected[v.Key()] {
+			return false
 		}
-
-		if len(v) < (page-1)*first {
-			return nil, ErrOutOfRange
+		if filter.Nationality != "" && v.Home().Nationality() != filter.Nationality && v.Away().Nationality() != filter.Nationality {
+			return false
+		}
+		if filter.ActiveOnly && !v.Active() {
+			return false
 		}
-		targets = v
+		if filter.LiveOnly && !v.Live() {
+			return false
+		}
+		return true
 	}
 
-	if categoryKey != "" {
-		v, ok := m.categoriesEvents[categoryKey]
-		if !ok {
-			return nil, ErrEventNotFound
+	var result []event.Event
+	if order.By == event.SortCutOffTime {
+		// eventsByCutOff is already sorted by cutoff time then key, so no sort is needed
+		keys := m.eventKeysByCutOff(filter.CutOffFrom, filter.CutOffTo)
+		result = make([]event.Event, 0, len(keys))
+		for i := range keys {
+			key := keys[i]
+			if order.Descending {
+				key = keys[len(keys)-1-i]
+			}
+			if v := m.events[m.eventsIndex[key]]; match(v) {
+				result = append(result, v)
+			}
 		}
-
-		if len(targets) == 0 {
-			targets = v
-		} else {
-			targets = intersection(targets, v)
-			if len(targets) == 0 {
-				return nil, ErrEventNotFound
+	} else {
+		candidates := m.events
+		if keyFiltered {
+			candidates = make([]event.Event, 0, len(targets))
+			for _, target := range targets {
+				candidates = append(candidates, m.events[m.eventsIndex[target]])
+			}
+		}
+		result = make([]event.Event, 0, len(candidates))
+		for _, v := range candidates {
+			if !filter.CutOffFrom.IsZero() && v.CutOffTime().Before(filter.CutOffFrom) {
+				continue
+			}
+			if !filter.CutOffTo.IsZero() && !v.CutOffTime().Before(filter.CutOffTo) {
+				continue
+			}
+			if match(v) {
+				result = append(result, v)
 			}
 		}
 	}
+	positions := make([]pagination.Position, len(result))
+	for i, v := range result {
+		positions[i] = order.Position(v)
+	}
+	if order.By != event.SortCutOffTime {
+		sort.Sort(eventsByPosition{events: result, positions: positions, order: order})
+	}
 
-	if competitionKey != "" {
-		v, ok := m.competitionsEvents[competitionKey]
-		if !ok {
-			return nil, ErrEventNotFound
+	start, end, info, err := pagination.Paginate(len(result), order.String(), request, func(i int) pagination.Position {
+		return positions[i]
+	}, order.Compare)
+	if err != nil {
+		return nil, pagination.Info{}, err
+	}
+	return result[start:end], info, nil
+}
+
+// eventsByPosition sort events together with their precomputed positions
+type eventsByPosition struct {
+	events    []event.Event
+	positions []pagination.Position
+	order     event.Order
+}
+
+func (e eventsByPosition) Len() int {
+	return len(e.events)
+}
+
+func (e eventsByPosition) Less(i, j int) bool {
+	return e.order.Compare(e.positions[i], e.positions[j]) < 0
+}
+
+func (e eventsByPosition) Swap(i, j int) {
+	e.events[i], e.events[j] = e.events[j], e.events[i]
+	e.positions[i], e.positions[j] = e.positions[j], e.positions[i]
+}
+
+// eventKeysByCutOff return keys of events with cutoff time in [from, to), zero bounds are open
+func (m *MemoryRepository) eventKeysByCutOff(from time.Time, to time.Time) []string {
+	start := 0
+	if !from.IsZero() {
+		start = sort.Search(len(m.eventsByCutOff), func(i int) bool {
+			return !m.events[m.eventsIndex[m.eventsByCutOff[i]]].CutOffTime().Before(from)
+		})
+	}
+	end := len(m.eventsByCutOff)
+	if !to.IsZero() {
+		end = sort.Search(len(m.eventsByCutOff), func(i int) bool {
+			return !m.events[m.eventsIndex[m.eventsByCutOff[i]]].CutOffTime().Before(to)
+		})
+	}
+	if end < start {
+		end = start
+	}
+	return m.eventsByCutOff[start:end]
+}
+
+func (m *MemoryRepository) GetEvent(ctx context.Context, eventKey string) (event.Event, error) {
+	defer observeOperation(ctx, "get_event")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	i, ok := m.eventsIndex[eventKey]
+	if !ok {
+		return event.Event{}, event.ErrEventNotFound
+	}
+
+	return m.events[i], nil
+}
+
+func (m *MemoryRepository) GetEvents(ctx context.Context, eventKeys []string) ([]event.Event, []string, error) {
+	defer observeOperation(ctx, "get_events")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	found := make([]event.Event, 0, len(eventKeys))
+	missing := make([]string, 0)
+	seen := make(map[string]bool, len(eventKeys))
+	for _, eventKey := range eventKeys {
+		if seen[eventKey] {
+			continue
 		}
+		seen[eventKey] = true
 
-		if len(targets) == 0 {
-			targets = v
+		if i, ok := m.eventsIndex[eventKey]; ok {
+			found = append(found, m.events[i])
 		} else {
-			targets = intersection(targets, v)
-			if len(targets) == 0 {
-				return nil, ErrEventNotFound
-			}
+			missing = append(missing, eventKey)
 		}
 	}
 
-	if len(targets) < (page-1)*first {
-		return nil, ErrOutOfRange
-	}
+	return found, missing, nil
+}
 
-	if len(targets) <= (page)*first {
-		targets = targets[(page-1)*first:]
-	} else {
-		targets = targets[(page-1)*first : (page)*first]
-	}
+func (m *MemoryRepository) ListEventsCutOffSoon(ctx context.Context) ([]event.Event, error) {
+	defer observeOperation(ctx, "list_events_cut_off_soon")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
 
-	result := make([]event.Event, 0, len(targets))
-	for _, target := range targets {
-		for _, v := range m.events {
-			if target == v.Key() {
-				result = append(result, v)
-			}
+	var result []event.Event
+	for _, event := range m.events {
+		if event.Active() && event.CutOffTime().Before(time.Now().Add(5*time.Minute)) {
+			result = append(result, event)
 		}
 	}
+
 	return result, nil
 }
 
-func (m MemoryRepository) GetEvent(_ context.Context, eventKey string) (event.Event, error) {
-	var found bool
-	var targetEvent event.Event
-	for _, v := range m.events {
-		if v.Key() == eventKey {
-			found = true
-			targetEvent = v
-			break
+func (m *MemoryRepository) ListActiveEvents(ctx context.Context) ([]event.Event, error) {
+	defer observeOperation(ctx, "list_active_events")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	var result []event.Event
+	for _, event := range m.events {
+		if event.Active() {
+			result = append(result, event)
 		}
 	}
-	if !found {
-		return event.Event{}, ErrEventNotFound
-	}
 
-	return targetEvent, nil
+	return result, nil
 }
 
-func (m MemoryRepository) ListEventsCutOffSoon(_ context.Context) ([]event.Event, error) {
-	if len(m.events) == 0 {
-		return nil, ErrEventNotFound
-	}
+func (m *MemoryRepository) ListInactiveEvents(ctx context.Context, before time.Time) ([]event.Event, error) {
+	defer observeOperation(ctx, "list_inactive_events")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
 
 	var result []event.Event
 	for _, event := range m.events {
-		if event.Active() && event.CutOffTime().Before(time.Now().Add(5*time.Minute)) {
+		if !event.Active() && event.InactiveTime().Before(before) {
 			result = append(result, event)
 		}
 	}
 
 	return result, nil
 }
+
+func (m *MemoryRepository) DeleteEvent(ctx context.Context, eventKey string) error {
+	defer observeOperation(ctx, "delete_event")()
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	i, ok := m.eventsIndex[eventKey]
+	if !ok {
+		return event.ErrEventNotFound
+	}
+	deleted := m.events[i]
+
+	m.removeCutOff(deleted)
+	last := len(m.events) - 1
+	m.events[i] = m.events[last]
+	m.eventsIndex[m.events[i].Key()] = i
+	m.events = m.events[:last]
+	delete(m.eventsIndex, eventKey)
+
+	now := time.Now()
+	m.hierarchy.Remove(deleted, now)
+	m.unlinkTeam(deleted.Home().Key(), eventKey)
+	m.unlinkTeam(deleted.Away().Key(), eventKey)
+	m.search.remove(search.KindEvent, eventKey)
+	delete(m.probabilities, eventKey)
+	m.changes.record(change.TypeDeleted, eventKey, now)
+
+	return nil
+}
+
+// removeKey remove value under key of index, dropping the key once nothing is left
+func removeKey(index map[string][]string, key string, value string) {
+	values := removeValue(index[key], value)
+	if len(values) == 0 {
+		delete(index, key)
+		return
+	}
+	index[key] = values
+}
+
+func (m *MemoryRepository) ListChanges(ctx context.Context, since uint64, limit int) (change.List, error) {
+	defer observeOperation(ctx, "list_changes")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	entries, err := m.changes.since(since, limit)
+	if err != nil {
+		return change.List{}, err
+	}
+
+	result := make([]change.Change, len(entries))
+	for i, entry := range entries {
+		var e *event.Event
+		if entry.kind != change.TypeDeleted {
+			v := m.events[m.eventsIndex[entry.eventKey]]
+			e = &v
+		}
+		result[i] = change.NewChange(entry.sequence, entry.kind, entry.eventKey, entry.time, e)
+	}
+	return change.NewList(result, m.changes.sequence, m.changes.epoch), nil
+}
+
+func (m *MemoryRepository) PruneTombstones(ctx context.Context, before time.Time) error {
+	defer observeOperation(ctx, "prune_tombstones")()
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.changes.pruneTombstones(before)
+	return nil
+}
+
+func (m *MemoryRepository) SaveProbabilities(ctx context.Context, probabilities []valuebet.Probability) error {
+	defer observeOperation(ctx, "save_probabilities")()
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, probability := range probabilities {
+		eventProbabilities, ok := m.probabilities[probability.EventKey()]
+		if !ok {
+			eventProbabilities = make(map[string]valuebet.Probability)
+			m.probabilities[probability.EventKey()] = eventProbabilities
+		}
+		key := strings.Join([]string{probability.MarketKey(), probability.Outcome(), probability.Params()}, "|")
+		eventProbabilities[key] = probability
+	}
+
+	return nil
+}
+
+func (m *MemoryRepository) ListProbabilities(ctx context.Context) ([]valuebet.Probability, error) {
+	defer observeOperation(ctx, "list_probabilities")()
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	result := make([]valuebet.Probability, 0)
+	for _, eventProbabilities := range m.probabilities {
+		for _, probability := range eventProbabilities {
+			result = append(result, probability)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,353 @@
+package infrastructure
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/pagination"
+	"github.com/awcjack/cloudbet/domain/search"
+	"github.com/awcjack/cloudbet/domain/team"
+	"github.com/awcjack/cloudbet/domain/valuebet"
+)
+
+var (
+	arsenal   = event.NewTeamIdentifier("Arsenal", "arsenal", "ARS", "ENG")
+	chelsea   = event.NewTeamIdentifier("Chelsea", "chelsea", "CHE", "ENG")
+	tottenham = event.NewTeamIdentifier("Tottenham Hotspur", "tottenham", "TOT", "ENG")
+)
+
+func newTestEvent(t *testing.T, key string, home event.TeamIdentifier, away event.TeamIdentifier, markets map[string]event.Market) event.Event {
+	t.Helper()
+	sport, _ := event.NewIdentifier("Soccer", "soccer")
+	category, _ := event.NewIdentifier("England", "england")
+	competition, _ := event.NewIdentifier("Premier League", "soccer-england-premier-league")
+	if markets == nil {
+		markets = map[string]event.Market{}
+	}
+	e, err := event.NewEvent(&sport, &competition, &category, home, away, true, false, markets, key, key, time.Date(2022, 6, 1, 19, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return *e
+}
+
+func TestTeamsFollowEvents(t *testing.T) {
+	tests := []struct {
+		name   string
+		update func(t *testing.T, repo *MemoryRepository) error
+		want   map[string][]string
+	}{
+		{
+			name:   "saved",
+			update: func(t *testing.T, repo *MemoryRepository) error { return nil },
+			want:   map[string][]string{"arsenal": {"match-1", "match-2"}, "chelsea": {"match-1"}, "tottenham": {"match-2"}},
+		},
+		{
+			name: "away team corrected",
+			update: func(t *testing.T, repo *MemoryRepository) error {
+				return repo.Save(context.Background(), newTestEvent(t, "match-1", arsenal, tottenham, nil))
+			},
+			want: map[string][]string{"arsenal": {"match-1", "match-2"}, "tottenham": {"match-1", "match-2"}},
+		},
+		{
+			name: "home and away swapped",
+			update: func(t *testing.T, repo *MemoryRepository) error {
+				return repo.Save(context.Background(), newTestEvent(t, "match-1", chelsea, arsenal, nil))
+			},
+			want: map[string][]string{"arsenal": {"match-1", "match-2"}, "chelsea": {"match-1"}, "tottenham": {"match-2"}},
+		},
+		{
+			name: "teams removed",
+			update: func(t *testing.T, repo *MemoryRepository) error {
+				return repo.Save(context.Background(), newTestEvent(t, "match-2", event.TeamIdentifier{}, event.TeamIdentifier{}, nil))
+			},
+			want: map[string][]string{"arsenal": {"match-1"}, "chelsea": {"match-1"}},
+		},
+		{
+			name: "deleted",
+			update: func(t *testing.T, repo *MemoryRepository) error {
+				return repo.DeleteEvent(context.Background(), "match-1")
+			},
+			want: map[string][]string{"arsenal": {"match-2"}, "tottenham": {"match-2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewMemoryRepository()
+			for _, e := range []event.Event{newTestEvent(t, "match-1", arsenal, chelsea, nil), newTestEvent(t, "match-2", arsenal, tottenham, nil)} {
+				if err := repo.Save(ctx, e); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := tt.update(t, repo); err != nil {
+				t.Fatal(err)
+			}
+
+			got := make(map[string][]string)
+			for teamKey, eventKeys := range repo.teamsEvents {
+				got[teamKey] = append([]string{}, eventKeys...)
+				sort.Strings(got[teamKey])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("team events = %v, want %v", got, tt.want)
+			}
+			for _, teamKey := range []string{"arsenal", "chelsea", "tottenham"} {
+				_, err := repo.GetTeam(ctx, teamKey)
+				results, _ := repo.Search(ctx, teamKey, []string{search.KindTeam}, 10)
+				if _, ok := tt.want[teamKey]; ok {
+					if err != nil || len(results) != 1 {
+						t.Errorf("team %s: GetTeam() error = %v, %d search results, want the team", teamKey, err, len(results))
+					}
+				} else if !errors.Is(err, team.ErrTeamNotFound) || len(results) != 0 {
+					t.Errorf("team %s: GetTeam() error = %v, %d search results, want the team dropped", teamKey, err, len(results))
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteEventProbabilities(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	var probabilities []valuebet.Probability
+	for _, eventKey := range []string{"match-1", "match-2"} {
+		if err := repo.Save(ctx, newTestEvent(t, eventKey, arsenal, chelsea, nil)); err != nil {
+			t.Fatal(err)
+		}
+		for _, outcome := range []string{"home", "draw", "away"} {
+			probability, err := valuebet.NewProbability(eventKey, "soccer.match_odds", outcome, "period=ft", 0.3)
+			if err != nil {
+				t.Fatal(err)
+			}
+			probabilities = append(probabilities, probability)
+		}
+	}
+	// saving again replaces the same outcomes
+	for i := 0; i < 2; i++ {
+		if err := repo.SaveProbabilities(ctx, probabilities); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := repo.DeleteEvent(ctx, "match-1"); err != nil {
+		t.Fatal(err)
+	}
+	got, err := repo.ListProbabilities(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 3 {
+		t.Fatalf("ListProbabilities() = %d probabilities, want the 3 of match-2", len(got))
+	}
+	for _, probability := range got {
+		if probability.EventKey() != "match-2" {
+			t.Errorf("ListProbabilities() kept %s %s of the deleted event", probability.EventKey(), probability.Outcome())
+		}
+	}
+}
+
+func TestListTeams(t *testing.T) {
+	ctx := context.Background()
+	repo := NewMemoryRepository()
+	lakers := event.NewTeamIdentifier("Los Angeles Lakers", "lakers", "LAL", "USA")
+	for _, e := range []event.Event{
+		newTestEvent(t, "match-1", arsenal, chelsea, nil),
+		newTestEvent(t, "match-2", tottenham, lakers, nil),
+	} {
+		if err := repo.Save(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	listKeys := func(request pagination.Request, nationality string) ([]string, pagination.Info) {
+		t.Helper()
+		teams, info, err := repo.ListTeams(ctx, request, nationality)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys := make([]string, 0, len(teams))
+		for _, v := range teams {
+			keys = append(keys, v.Key())
+		}
+		return keys, info
+	}
+
+	if got, info := listKeys(pagination.NewRequest(10, nil), "ENG"); !reflect.DeepEqual(got, []string{"arsenal", "chelsea", "tottenham"}) || info.TotalCount() != 3 {
+		t.Errorf("ListTeams(ENG) = %v of %d", got, info.TotalCount())
+	}
+	first, info := listKeys(pagination.NewRequest(2, nil), "")
+	if !reflect.DeepEqual(first, []string{"arsenal", "chelsea"}) || info.TotalCount() != 4 || info.NextCursor() == "" {
+		t.Fatalf("first page = %v of %d", first, info.TotalCount())
+	}
+	// a team dropped between pages does not shift the next page
+	if err := repo.DeleteEvent(ctx, "match-1"); err != nil {
+		t.Fatal(err)
+	}
+	cursor, err := pagination.DecodeCursor(info.NextCursor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second, info := listKeys(pagination.NewRequest(2, cursor), ""); !reflect.DeepEqual(second, []string{"lakers", "tottenham"}) || info.NextCursor() != "" {
+		t.Errorf("second page = %v, next cursor %q", second, info.NextCursor())
+	}
+}
+
+func TestListEvents(t *testing.T) {
+	ctx := context.Background()
+	base := time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+	sport, _ := event.NewIdentifier("Soccer", "soccer")
+	category, _ := event.NewIdentifier("England", "england")
+	competition, _ := event.NewIdentifier("Premier League", "soccer-england-premier-league")
+	repo := NewMemoryRepository()
+	for _, v := range []struct {
+		key    string
+		name   string
+		cutOff time.Duration
+		active bool
+		live   bool
+		// both selections at this price, no market when zero
+		price float64
+	}{
+		{key: "e1", name: "Bravo", cutOff: time.Hour, active: true, price: 1.9},
+		{key: "e2", name: "Alpha", cutOff: 3 * time.Hour, active: true, live: true, price: 1.8},
+		{key: "e3", name: "Charlie", cutOff: 2 * time.Hour},
+		{key: "e4", name: "Delta", cutOff: 2 * time.Hour, active: true, price: 2},
+	} {
+		markets := map[string]event.Market{}
+		if v.price != 0 {
+			markets["soccer.moneyline"] = event.NewMarket(map[string][]event.Selection{"period=ft": {
+				event.NewSelection("home", "", v.price, 100, 0.5, "SELECTION_ENABLED", "BACK"),
+				event.NewSelection("away", "", v.price, 100, 0.5, "SELECTION_ENABLED", "BACK"),
+			}})
+		}
+		e, err := event.NewEvent(&sport, &competition, &category, event.TeamIdentifier{}, event.TeamIdentifier{}, v.active, v.live, markets, v.name, v.key, base.Add(v.cutOff))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Save(ctx, *e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	listKeys := func(request pagination.Request, filter event.Filter, order event.Order) ([]string, pagination.Info) {
+		t.Helper()
+		events, info, err := repo.ListEvents(ctx, request, filter, order)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys := make([]string, 0, len(events))
+		for _, v := range events {
+			keys = append(keys, v.Key())
+		}
+		return keys, info
+	}
+
+	tests := []struct {
+		name       string
+		filter     event.Filter
+		by         string
+		descending bool
+		want       []string
+	}{
+		{name: "cutoff time with ties broken by key", want: []string{"e1", "e3", "e4", "e2"}},
+		{name: "cutoff time descending", descending: true, want: []string{"e2", "e4", "e3", "e1"}},
+		{name: "cutoff window excludes its end", filter: event.Filter{CutOffFrom: base.Add(2 * time.Hour), CutOffTo: base.Add(3 * time.Hour)}, want: []string{"e3", "e4"}},
+		{name: "cutoff window sorted by name", filter: event.Filter{CutOffFrom: base.Add(2 * time.Hour)}, by: event.SortName, want: []string{"e2", "e3", "e4"}},
+		{name: "active only", filter: event.Filter{ActiveOnly: true}, want: []string{"e1", "e4", "e2"}},
+		{name: "live only", filter: event.Filter{LiveOnly: true}, want: []string{"e2"}},
+		{name: "name", by: event.SortName, want: []string{"e2", "e1", "e3", "e4"}},
+		{name: "margin with unpriced events last", by: event.SortMargin, want: []string{"e4", "e1", "e2", "e3"}},
+		{name: "margin descending with unpriced events last", by: event.SortMargin, descending: true, want: []string{"e2", "e1", "e4", "e3"}},
+		{name: "unknown sport", filter: event.Filter{SportKey: "tennis"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order, err := event.NewOrder(tt.by, tt.descending)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got, info := listKeys(pagination.NewRequest(10, nil), tt.filter, order); !reflect.DeepEqual(got, tt.want) || info.TotalCount() != len(tt.want) {
+				t.Errorf("ListEvents() = %v of %d, want %v", got, info.TotalCount(), tt.want)
+			}
+		})
+	}
+
+	order, _ := event.NewOrder(event.SortMargin, false)
+	first, info := listKeys(pagination.NewRequest(2, nil), event.Filter{}, order)
+	if !reflect.DeepEqual(first, []string{"e4", "e1"}) || info.NextCursor() == "" {
+		t.Fatalf("first page = %v", first)
+	}
+	cursor, err := pagination.DecodeCursor(info.NextCursor())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if second, info := listKeys(pagination.NewRequest(2, cursor), event.Filter{}, order); !reflect.DeepEqual(second, []string{"e2", "e3"}) || info.NextCursor() != "" {
+		t.Errorf("second page = %v, next cursor %q", second, info.NextCursor())
+	}
+	// a cursor cannot be reused with another ordering
+	nameOrder, _ := event.NewOrder(event.SortName, false)
+	if _, _, err := repo.ListEvents(ctx, pagination.NewRequest(2, cursor), event.Filter{}, nameOrder); !errors.Is(err, pagination.ErrInvalidCursor) {
+		t.Errorf("ListEvents() error = %v, want %v", err, pagination.ErrInvalidCursor)
+	}
+}
+
+func TestSaveVersion(t *testing.T) {
+	odds := func(price float64) map[string]event.Market {
+		return map[string]event.Market{"soccer.match_odds": event.NewMarket(map[string][]event.Selection{"period=ft": {
+			event.NewSelection("home", "", price, 100, 1/price, "SELECTION_ENABLED", "BACK"),
+		}})}
+	}
+
+	tests := []struct {
+		name        string
+		saved       []map[string]event.Market
+		wantVersion uint64
+		wantChanges int
+	}{
+		{name: "created", saved: []map[string]event.Market{odds(2)}, wantVersion: 1, wantChanges: 1},
+		{name: "unchanged content keeps the version", saved: []map[string]event.Market{odds(2), odds(2)}, wantVersion: 1, wantChanges: 1},
+		{name: "changed price", saved: []map[string]event.Market{odds(2), odds(2.1)}, wantVersion: 2, wantChanges: 1},
+		{name: "changed back", saved: []map[string]event.Market{odds(2), odds(2.1), odds(2)}, wantVersion: 3, wantChanges: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := NewMemoryRepository()
+			var previous event.Event
+			for _, markets := range tt.saved {
+				if err := repo.Save(ctx, newTestEvent(t, "match-1", arsenal, chelsea, markets)); err != nil {
+					t.Fatal(err)
+				}
+				stored, _ := repo.GetEvent(ctx, "match-1")
+				if stored.Version() == previous.Version() && !stored.LastUpdated().Equal(previous.LastUpdated()) {
+					t.Errorf("LastUpdated() = %s, want %s kept with the version", stored.LastUpdated(), previous.LastUpdated())
+				}
+				previous = stored
+			}
+
+			stored, err := repo.GetEvent(ctx, "match-1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stored.Version() != tt.wantVersion || stored.Epoch() != repo.changes.epoch {
+				t.Errorf("version %s.%d, want %s.%d", stored.Epoch(), stored.Version(), repo.changes.epoch, tt.wantVersion)
+			}
+			changes, err := repo.ListChanges(ctx, 0, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(changes.Changes()) != tt.wantChanges || changes.Changes()[0].Sequence() != tt.wantVersion {
+				t.Errorf("ListChanges() = %d changes, want %d at sequence %d", len(changes.Changes()), tt.wantChanges, tt.wantVersion)
+			}
+		})
+	}
+}
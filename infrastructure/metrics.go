@@ -0,0 +1,33 @@
+package infrastructure
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var repositoryDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "cloudbet",
+	Name:      "repository_operation_duration_seconds",
+	Help:      "Duration of event repository operations, including waiting for the repository lock.",
+	// in memory operations take microseconds, listings over every event take longer
+	Buckets: prometheus.ExponentialBuckets(0.00001, 4, 10),
+}, []string{"operation"})
+
+var tracer = otel.Tracer("github.com/awcjack/cloudbet/infrastructure")
+
+// observeOperation start timing and tracing operation under ctx, and return the func recording it once done.
+// The result is deferred at the top of repository methods, e.g. defer observeOperation(ctx, "save")()
+func observeOperation(ctx context.Context, operation string) func() {
+	start := time.Now()
+	_, span := tracer.Start(ctx, "repository "+operation, trace.WithAttributes(attribute.String("repository.operation", operation)))
+	return func() {
+		span.End()
+		repositoryDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+	}
+}
@@ -0,0 +1,38 @@
+package infrastructure
+
+import (
+	"context"
+	"sync"
+
+	"github.com/awcjack/cloudbet/domain/replication"
+)
+
+type ReplicationMemoryRepository struct {
+	// nil until the replica saved its first status
+	status *replication.Status
+	lock   *sync.RWMutex
+}
+
+func NewReplicationMemoryRepository() *ReplicationMemoryRepository {
+	return &ReplicationMemoryRepository{
+		lock: &sync.RWMutex{},
+	}
+}
+
+func (r *ReplicationMemoryRepository) SaveStatus(_ context.Context, status replication.Status) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.status = &status
+
+	return nil
+}
+
+func (r *ReplicationMemoryRepository) GetStatus(_ context.Context) (replication.Status, error) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	if r.status == nil {
+		return replication.Status{}, replication.ErrNotReplica
+	}
+	return *r.status, nil
+}
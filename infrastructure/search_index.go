@@ -0,0 +1,265 @@
+package infrastructure
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/awcjack/cloudbet/domain/search"
+)
+
+const (
+	exactMatchScore  = 3
+	prefixMatchScore = 2
+	fuzzyMatchScore  = 1
+)
+
+type searchDocument struct {
+	kind string
+	key  string
+	name string
+	// normalised terms of every indexed field
+	terms []string
+	// cutoff time of the event, or the nearest upcoming event for other kinds
+	cutoffTime time.Time
+}
+
+// searchIndex is an inverted index from normalised terms to documents, not safe for concurrent use
+type searchIndex struct {
+	documents map[string]*searchDocument
+	postings  map[string]map[string]bool
+	// every indexed term in order, for prefix lookup
+	terms []string
+}
+
+func newSearchIndex() *searchIndex {
+	return &searchIndex{
+		documents: make(map[string]*searchDocument),
+		postings:  make(map[string]map[string]bool),
+		terms:     make([]string, 0),
+	}
+}
+
+func tokenize(fields ...string) []string {
+	seen := make(map[string]bool)
+	var terms []string
+	for _, field := range fields {
+		for _, term := range strings.FieldsFunc(strings.ToLower(field), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if !seen[term] {
+				seen[term] = true
+				terms = append(terms, term)
+			}
+		}
+	}
+	return terms
+}
+
+// put index a document or refresh its fields, cutoffTime replace the previous one when it is closer to now
+func (s *searchIndex) put(kind string, key string, name string, cutoffTime time.Time, fields ...string) {
+	id := kind + ":" + key
+	document, ok := s.documents[id]
+	if !ok {
+		document = &searchDocument{
+			kind: kind,
+			key:  key,
+		}
+		s.documents[id] = document
+	}
+	for _, term := range document.terms {
+		s.removePosting(term, id)
+	}
+
+	document.name = name
+	document.terms = tokenize(append(fields, name)...)
+	if document.cutoffTime.IsZero() || kind == search.KindEvent || closerCutoff(cutoffTime, document.cutoffTime) {
+		document.cutoffTime = cutoffTime
+	}
+	for _, term := range document.terms {
+		s.addPosting(term, id)
+	}
+}
+
+// remove drop a document from the index
+func (s *searchIndex) remove(kind string, key string) {
+	id := kind + ":" + key
+	document, ok := s.documents[id]
+	if !ok {
+		return
+	}
+	for _, term := range document.terms {
+		s.removePosting(term, id)
+	}
+	delete(s.documents, id)
+}
+
+func (s *searchIndex) addPosting(term string, id string) {
+	documents, ok := s.postings[term]
+	if !ok {
+		documents = make(map[string]bool)
+		s.postings[term] = documents
+		i := sort.SearchStrings(s.terms, term)
+		s.terms = append(s.terms, "")
+		copy(s.terms[i+1:], s.terms[i:])
+		s.terms[i] = term
+	}
+	documents[id] = true
+}
+
+func (s *searchIndex) removePosting(term string, id string) {
+	documents, ok := s.postings[term]
+	if !ok {
+		return
+	}
+	delete(documents, id)
+	if len(documents) == 0 {
+		delete(s.postings, term)
+		i := sort.SearchStrings(s.terms, term)
+		if i < len(s.terms) && s.terms[i] == term {
+			s.terms = append(s.terms[:i], s.terms[i+1:]...)
+		}
+	}
+}
+
+// closerCutoff report whether candidate is an upcoming cutoff nearer than current, or current already passed
+func closerCutoff(candidate time.Time, current time.Time) bool {
+	now := time.Now()
+	if candidate.Before(now) {
+		return false
+	}
+	return current.Before(now) || candidate.Before(current)
+}
+
+// match score every document containing a term matching queryTerm
+func (s *searchIndex) match(queryTerm string) map[string]float64 {
+	scores := make(map[string]float64)
+	apply := func(term string, score float64) {
+		for id := range s.postings[term] {
+			if scores[id] < score {
+				scores[id] = score
+			}
+		}
+	}
+
+	for i := sort.SearchStrings(s.terms, queryTerm); i < len(s.terms) && strings.HasPrefix(s.terms[i], queryTerm); i++ {
+		if s.terms[i] == queryTerm {
+			apply(s.terms[i], exactMatchScore)
+		} else {
+			apply(s.terms[i], prefixMatchScore)
+		}
+	}
+
+	// typo tolerance is only applied to terms long enough to be meaningful
+	maxDistance := 0
+	if len(queryTerm) >= 4 {
+		maxDistance = 1
+	}
+	if len(queryTerm) >= 8 {
+		maxDistance = 2
+	}
+	if maxDistance > 0 {
+		for _, term := range s.terms {
+			if abs(len(term)-len(queryTerm)) > maxDistance || strings.HasPrefix(term, queryTerm) {
+				continue
+			}
+			if levenshtein(term, queryTerm) <= maxDistance {
+				apply(term, fuzzyMatchScore)
+			}
+		}
+	}
+
+	return scores
+}
+
+// search rank documents matching every query term by relevance and proximity of cutoff time
+func (s *searchIndex) search(query string, kinds []string, limit int) []search.Result {
+	queryTerms := tokenize(query)
+	if len(queryTerms) == 0 {
+		return []search.Result{}
+	}
+	allowed := make(map[string]bool)
+	for _, kind := range kinds {
+		allowed[kind] = true
+	}
+
+	var scores map[string]float64
+	for _, queryTerm := range queryTerms {
+		matched := s.match(queryTerm)
+		if scores == nil {
+			scores = matched
+			continue
+		}
+		for id, score := range scores {
+			if termScore, ok := matched[id]; ok {
+				scores[id] = score + termScore
+			} else {
+				delete(scores, id)
+			}
+		}
+	}
+
+	now := time.Now()
+	result := make([]search.Result, 0, len(scores))
+	for id, score := range scores {
+		document := s.documents[id]
+		if len(allowed) > 0 && !allowed[document.kind] {
+			continue
+		}
+		// upcoming documents get up to 1 extra point, decaying with hours until cutoff
+		if document.cutoffTime.After(now) {
+			score += 1 / (1 + document.cutoffTime.Sub(now).Hours())
+		}
+		result = append(result, search.NewResult(document.kind, document.key, document.name, score, document.cutoffTime))
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Score() == result[j].Score() {
+			return result[i].Name() < result[j].Name()
+		}
+		return result[i].Score() > result[j].Score()
+	})
+
+	if len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func levenshtein(a string, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = minInt(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+func minInt(values ...int) int {
+	result := values[0]
+	for _, v := range values[1:] {
+		if v < result {
+			result = v
+		}
+	}
+	return result
+}
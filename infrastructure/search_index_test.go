@@ -0,0 +1,107 @@
+package infrastructure
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/search"
+)
+
+func newTestSearchIndex() *searchIndex {
+	past := time.Date(2022, 6, 1, 19, 0, 0, 0, time.UTC)
+	s := newSearchIndex()
+	s.put(search.KindEvent, "arsenal-v-chelsea", "Arsenal V Chelsea", past, "Arsenal", "ARS", "Chelsea", "CHE")
+	s.put(search.KindTeam, "arsenal", "Arsenal", past, "ARS")
+	s.put(search.KindTeam, "chelsea", "Chelsea", past, "CHE")
+	s.put(search.KindCompetition, "soccer-england-premier-league", "Premier League", past, "England")
+	s.put(search.KindCategory, "england", "England", past)
+	return s
+}
+
+func resultIDs(results []search.Result) []string {
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.Kind()+":"+result.Key())
+	}
+	return ids
+}
+
+func TestSearch(t *testing.T) {
+	s := newTestSearchIndex()
+
+	tests := []struct {
+		name  string
+		query string
+		kinds []string
+		limit int
+		want  []string
+	}{
+		{name: "exact", query: "Arsenal", limit: 10, want: []string{"team:arsenal", "event:arsenal-v-chelsea"}},
+		{name: "abbreviation", query: "ars", limit: 10, want: []string{"team:arsenal", "event:arsenal-v-chelsea"}},
+		{name: "prefix", query: "prem", limit: 10, want: []string{"competition:soccer-england-premier-league"}},
+		{name: "typo", query: "arsenl", limit: 10, want: []string{"team:arsenal", "event:arsenal-v-chelsea"}},
+		{name: "two typos in a long term", query: "premiir leage", limit: 10, want: []string{"competition:soccer-england-premier-league"}},
+		{name: "no typo tolerance on short terms", query: "arx", limit: 10, want: []string{}},
+		{name: "every term must match", query: "arsenal chelsea", limit: 10, want: []string{"event:arsenal-v-chelsea"}},
+		{name: "kinds", query: "arsenal", kinds: []string{search.KindEvent}, limit: 10, want: []string{"event:arsenal-v-chelsea"}},
+		{name: "indexed fields", query: "england", limit: 10, want: []string{"category:england", "competition:soccer-england-premier-league"}},
+		{name: "limit", query: "chel", limit: 1, want: []string{"event:arsenal-v-chelsea"}},
+		{name: "punctuation only", query: "--", limit: 10, want: []string{}},
+		{name: "no match", query: "tottenham", limit: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := resultIDs(s.search(tt.query, tt.kinds, tt.limit)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("search(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchScore(t *testing.T) {
+	s := newTestSearchIndex()
+	results := s.search("arsenal chel", nil, 10)
+	// exact match on the first term, prefix match on the second, no bonus for a past cutoff
+	if len(results) != 1 || results[0].Score() != exactMatchScore+prefixMatchScore {
+		t.Fatalf("search() = %v", results)
+	}
+
+	now := time.Now()
+	s.put(search.KindEvent, "later", "Lakers V Celtics", now.Add(48*time.Hour))
+	s.put(search.KindEvent, "sooner", "Lakers V Heat", now.Add(time.Hour))
+	if got := resultIDs(s.search("lakers", nil, 10)); !reflect.DeepEqual(got, []string{"event:sooner", "event:later"}) {
+		t.Errorf("search() = %v, want the nearest cutoff first", got)
+	}
+
+	// other kinds keep the nearest upcoming cutoff of their events
+	s.put(search.KindTeam, "lakers", "Lakers", now.Add(48*time.Hour))
+	s.put(search.KindTeam, "lakers", "Lakers", now.Add(time.Hour))
+	s.put(search.KindTeam, "lakers", "Lakers", now.Add(24*time.Hour))
+	if results := s.search("lakers", []string{search.KindTeam}, 10); len(results) != 1 || !results[0].CutoffTime().Equal(now.Add(time.Hour)) {
+		t.Errorf("search() = %v, want the nearest cutoff kept", results)
+	}
+}
+
+func TestSearchRemove(t *testing.T) {
+	s := newTestSearchIndex()
+	s.remove(search.KindTeam, "arsenal")
+	if got := resultIDs(s.search("arsenal", nil, 10)); !reflect.DeepEqual(got, []string{"event:arsenal-v-chelsea"}) {
+		t.Errorf("search() after removing the team = %v", got)
+	}
+
+	// renaming drops the old terms
+	s.put(search.KindEvent, "arsenal-v-chelsea", "Gunners V Blues", time.Time{})
+	s.remove(search.KindEvent, "missing")
+	if got := resultIDs(s.search("arsenal", nil, 10)); len(got) != 0 {
+		t.Errorf("search() after renaming = %v", got)
+	}
+	for _, term := range []string{"arsenal", "ars"} {
+		i := sort.SearchStrings(s.terms, term)
+		if _, ok := s.postings[term]; ok || (i < len(s.terms) && s.terms[i] == term) {
+			t.Errorf("term %s still indexed", term)
+		}
+	}
+}
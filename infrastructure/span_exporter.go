@@ -0,0 +1,281 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// OtlpSpanExporter send spans to an OpenTelemetry collector over OTLP/HTTP, JSON encoded
+type OtlpSpanExporter struct {
+	url     string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewOtlpSpanExporter export to the collector at endpoint, e.g. http://localhost:4318, sending headers with every request
+func NewOtlpSpanExporter(endpoint string, headers map[string]string) *OtlpSpanExporter {
+	return &OtlpSpanExporter{
+		url:     strings.TrimSuffix(endpoint, "/") + "/v1/traces",
+		headers: headers,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (e *OtlpSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	if len(spans) == 0 {
+		return nil
+	}
+	body, err := json.Marshal(newOtlpTraces(spans))
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("export %d spans: [%s] %s", len(spans), resp.Status, message)
+	}
+	return nil
+}
+
+func (e *OtlpSpanExporter) Shutdown(ctx context.Context) error {
+	e.client.CloseIdleConnections()
+	return nil
+}
+
+// StdoutSpanExporter write spans to w for local use, one OTLP JSON encoded span per line
+type StdoutSpanExporter struct {
+	lock    *sync.Mutex
+	encoder *json.Encoder
+}
+
+func NewStdoutSpanExporter(w io.Writer) *StdoutSpanExporter {
+	return &StdoutSpanExporter{
+		lock:    &sync.Mutex{},
+		encoder: json.NewEncoder(w),
+	}
+}
+
+func (e *StdoutSpanExporter) ExportSpans(ctx context.Context, spans []sdktrace.ReadOnlySpan) error {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+
+	for _, span := range spans {
+		if err := e.encoder.Encode(newOtlpSpan(span)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *StdoutSpanExporter) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// OTLP JSON encoding of spans, see https://github.com/open-telemetry/opentelemetry-proto
+type otlpTraces struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Events            []otlpEvent    `json:"events,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpEvent struct {
+	TimeUnixNano string         `json:"timeUnixNano"`
+	Name         string         `json:"name"`
+	Attributes   []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string    `json:"key"`
+	Value otlpValue `json:"value"`
+}
+
+type otlpValue struct {
+	StringValue *string         `json:"stringValue,omitempty"`
+	BoolValue   *bool           `json:"boolValue,omitempty"`
+	IntValue    *string         `json:"intValue,omitempty"`
+	DoubleValue *float64        `json:"doubleValue,omitempty"`
+	ArrayValue  *otlpArrayValue `json:"arrayValue,omitempty"`
+}
+
+type otlpArrayValue struct {
+	Values []otlpValue `json:"values"`
+}
+
+// newOtlpTraces group spans by resource then instrumentation scope
+func newOtlpTraces(spans []sdktrace.ReadOnlySpan) otlpTraces {
+	var traces otlpTraces
+	resources := make(map[attribute.Distinct]int)
+	scopes := make(map[attribute.Distinct]map[string]int)
+	for _, span := range spans {
+		resourceKey := span.Resource().Equivalent()
+		resourceIndex, ok := resources[resourceKey]
+		if !ok {
+			resourceIndex = len(traces.ResourceSpans)
+			resources[resourceKey] = resourceIndex
+			scopes[resourceKey] = make(map[string]int)
+			traces.ResourceSpans = append(traces.ResourceSpans, otlpResourceSpans{
+				Resource: otlpResource{Attributes: newOtlpKeyValues(span.Resource().Attributes())},
+			})
+		}
+		resourceSpans := &traces.ResourceSpans[resourceIndex]
+
+		library := span.InstrumentationLibrary()
+		scopeKey := library.Name + "@" + library.Version
+		scopeIndex, ok := scopes[resourceKey][scopeKey]
+		if !ok {
+			scopeIndex = len(resourceSpans.ScopeSpans)
+			scopes[resourceKey][scopeKey] = scopeIndex
+			resourceSpans.ScopeSpans = append(resourceSpans.ScopeSpans, otlpScopeSpans{
+				Scope: otlpScope{Name: library.Name, Version: library.Version},
+			})
+		}
+		scopeSpans := &resourceSpans.ScopeSpans[scopeIndex]
+		scopeSpans.Spans = append(scopeSpans.Spans, newOtlpSpan(span))
+	}
+	return traces
+}
+
+func newOtlpSpan(span sdktrace.ReadOnlySpan) otlpSpan {
+	result := otlpSpan{
+		TraceID:           span.SpanContext().TraceID().String(),
+		SpanID:            span.SpanContext().SpanID().String(),
+		Name:              span.Name(),
+		Kind:              int(span.SpanKind()),
+		StartTimeUnixNano: strconv.FormatInt(span.StartTime().UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(span.EndTime().UnixNano(), 10),
+		Attributes:        newOtlpKeyValues(span.Attributes()),
+		Status:            otlpStatus{Message: span.Status().Description},
+	}
+	if span.Parent().HasSpanID() {
+		result.ParentSpanID = span.Parent().SpanID().String()
+	}
+	for _, event := range span.Events() {
+		result.Events = append(result.Events, otlpEvent{
+			TimeUnixNano: strconv.FormatInt(event.Time.UnixNano(), 10),
+			Name:         event.Name,
+			Attributes:   newOtlpKeyValues(event.Attributes),
+		})
+	}
+	// OTLP numbers the codes in another order than the API
+	switch span.Status().Code {
+	case codes.Ok:
+		result.Status.Code = 1
+	case codes.Error:
+		result.Status.Code = 2
+	}
+	return result
+}
+
+func newOtlpKeyValues(attributes []attribute.KeyValue) []otlpKeyValue {
+	result := make([]otlpKeyValue, 0, len(attributes))
+	for _, attr := range attributes {
+		result = append(result, otlpKeyValue{
+			Key:   string(attr.Key),
+			Value: newOtlpValue(attr.Value),
+		})
+	}
+	return result
+}
+
+func newOtlpValue(value attribute.Value) otlpValue {
+	switch value.Type() {
+	case attribute.BOOL:
+		v := value.AsBool()
+		return otlpValue{BoolValue: &v}
+	case attribute.INT64:
+		v := strconv.FormatInt(value.AsInt64(), 10)
+		return otlpValue{IntValue: &v}
+	case attribute.FLOAT64:
+		v := value.AsFloat64()
+		return otlpValue{DoubleValue: &v}
+	case attribute.BOOLSLICE:
+		var values []otlpValue
+		for _, v := range value.AsBoolSlice() {
+			values = append(values, newOtlpValue(attribute.BoolValue(v)))
+		}
+		return otlpValue{ArrayValue: &otlpArrayValue{Values: values}}
+	case attribute.INT64SLICE:
+		var values []otlpValue
+		for _, v := range value.AsInt64Slice() {
+			values = append(values, newOtlpValue(attribute.Int64Value(v)))
+		}
+		return otlpValue{ArrayValue: &otlpArrayValue{Values: values}}
+	case attribute.FLOAT64SLICE:
+		var values []otlpValue
+		for _, v := range value.AsFloat64Slice() {
+			values = append(values, newOtlpValue(attribute.Float64Value(v)))
+		}
+		return otlpValue{ArrayValue: &otlpArrayValue{Values: values}}
+	case attribute.STRINGSLICE:
+		var values []otlpValue
+		for _, v := range value.AsStringSlice() {
+			values = append(values, newOtlpValue(attribute.StringValue(v)))
+		}
+		return otlpValue{ArrayValue: &otlpArrayValue{Values: values}}
+	default:
+		v := value.Emit()
+		return otlpValue{StringValue: &v}
+	}
+}
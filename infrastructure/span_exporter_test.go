@@ -0,0 +1,256 @@
+package infrastructure
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/sdk/instrumentation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+	commonpb "go.opentelemetry.io/proto/otlp/common/v1"
+	tracepb "go.opentelemetry.io/proto/otlp/trace/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	testTraceID, _  = trace.TraceIDFromHex("0af7651916cd43dd8448eb211c80319c")
+	testSpanID, _   = trace.SpanIDFromHex("b7ad6b7169203331")
+	testParentID, _ = trace.SpanIDFromHex("00f067aa0ba902b7")
+	testStart       = time.Unix(1654084800, 500)
+)
+
+func newTestSpan(name string, kind trace.SpanKind, status codes.Code, attributes ...attribute.KeyValue) tracetest.SpanStub {
+	return tracetest.SpanStub{
+		Name:        name,
+		SpanContext: trace.NewSpanContext(trace.SpanContextConfig{TraceID: testTraceID, SpanID: testSpanID}),
+		Parent:      trace.NewSpanContext(trace.SpanContextConfig{TraceID: testTraceID, SpanID: testParentID}),
+		SpanKind:    kind,
+		StartTime:   testStart,
+		EndTime:     testStart.Add(time.Second),
+		Attributes:  attributes,
+		Events: []sdktrace.Event{
+			{Name: "retry", Time: testStart.Add(time.Millisecond), Attributes: []attribute.KeyValue{attribute.Int("attempt", 2)}},
+		},
+		Status:                 sdktrace.Status{Code: status, Description: "done"},
+		Resource:               resource.NewSchemaless(attribute.String("service.name", "cloudbet")),
+		InstrumentationLibrary: instrumentation.Library{Name: "github.com/awcjack/cloudbet", Version: "v1"},
+	}
+}
+
+// decodeOtlp decode body with the OTLP protobuf schema, rejecting unknown fields. OTLP/JSON hex encodes trace and
+// span ids where protojson expects base64, so they are converted first.
+func decodeOtlp(t *testing.T, body []byte, message proto.Message) {
+	t.Helper()
+	var generic interface{}
+	if err := json.Unmarshal(body, &generic); err != nil {
+		t.Fatal(err)
+	}
+	converted, err := json.Marshal(hexToBase64(t, generic))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := protojson.Unmarshal(converted, message); err != nil {
+		t.Fatalf("OTLP decode error = %v\n%s", err, body)
+	}
+}
+
+func hexToBase64(t *testing.T, value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if s, ok := field.(string); ok && (key == "traceId" || key == "spanId" || key == "parentSpanId") {
+				raw, err := hex.DecodeString(s)
+				if err != nil {
+					t.Fatalf("%s %q is not hex: %v", key, s, err)
+				}
+				v[key] = base64.StdEncoding.EncodeToString(raw)
+				continue
+			}
+			v[key] = hexToBase64(t, field)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = hexToBase64(t, item)
+		}
+	}
+	return value
+}
+
+func TestNewOtlpSpan(t *testing.T) {
+	tests := []struct {
+		name       string
+		kind       trace.SpanKind
+		status     codes.Code
+		attribute  attribute.KeyValue
+		wantKind   tracepb.Span_SpanKind
+		wantStatus tracepb.Status_StatusCode
+		wantValue  *commonpb.AnyValue
+	}{
+		{
+			name: "string", kind: trace.SpanKindServer, status: codes.Unset, attribute: attribute.String("http.route", "/event"),
+			wantKind: tracepb.Span_SPAN_KIND_SERVER, wantStatus: tracepb.Status_STATUS_CODE_UNSET,
+			wantValue: &commonpb.AnyValue{Value: &commonpb.AnyValue_StringValue{StringValue: "/event"}},
+		},
+		{
+			name: "int", kind: trace.SpanKindClient, status: codes.Ok, attribute: attribute.Int64("http.status_code", 200),
+			wantKind: tracepb.Span_SPAN_KIND_CLIENT, wantStatus: tracepb.Status_STATUS_CODE_OK,
+			wantValue: &commonpb.AnyValue{Value: &commonpb.AnyValue_IntValue{IntValue: 200}},
+		},
+		{
+			name: "bool", kind: trace.SpanKindInternal, status: codes.Error, attribute: attribute.Bool("cache.hit", true),
+			wantKind: tracepb.Span_SPAN_KIND_INTERNAL, wantStatus: tracepb.Status_STATUS_CODE_ERROR,
+			wantValue: &commonpb.AnyValue{Value: &commonpb.AnyValue_BoolValue{BoolValue: true}},
+		},
+		{
+			name: "double", kind: trace.SpanKindProducer, attribute: attribute.Float64("margin", 0.05),
+			wantKind:  tracepb.Span_SPAN_KIND_PRODUCER,
+			wantValue: &commonpb.AnyValue{Value: &commonpb.AnyValue_DoubleValue{DoubleValue: 0.05}},
+		},
+		{
+			name: "string slice", kind: trace.SpanKindConsumer, attribute: attribute.StringSlice("sports", []string{"soccer", "tennis"}),
+			wantKind: tracepb.Span_SPAN_KIND_CONSUMER,
+			wantValue: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: []*commonpb.AnyValue{
+				{Value: &commonpb.AnyValue_StringValue{StringValue: "soccer"}},
+				{Value: &commonpb.AnyValue_StringValue{StringValue: "tennis"}},
+			}}}},
+		},
+		{
+			name: "int slice", kind: trace.SpanKindUnspecified, attribute: attribute.Int64Slice("runs", []int64{1, 2}),
+			wantKind: tracepb.Span_SPAN_KIND_UNSPECIFIED,
+			wantValue: &commonpb.AnyValue{Value: &commonpb.AnyValue_ArrayValue{ArrayValue: &commonpb.ArrayValue{Values: []*commonpb.AnyValue{
+				{Value: &commonpb.AnyValue_IntValue{IntValue: 1}},
+				{Value: &commonpb.AnyValue_IntValue{IntValue: 2}},
+			}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stub := newTestSpan(tt.name, tt.kind, tt.status, tt.attribute)
+			body, err := json.Marshal(newOtlpTraces(tracetest.SpanStubs{stub}.Snapshots()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var traces tracepb.TracesData
+			decodeOtlp(t, body, &traces)
+
+			if len(traces.ResourceSpans) != 1 || len(traces.ResourceSpans[0].ScopeSpans) != 1 || len(traces.ResourceSpans[0].ScopeSpans[0].Spans) != 1 {
+				t.Fatalf("decoded %s, want one span", body)
+			}
+			resourceSpans := traces.ResourceSpans[0]
+			if attrs := resourceSpans.Resource.Attributes; len(attrs) != 1 || attrs[0].Key != "service.name" || attrs[0].Value.GetStringValue() != "cloudbet" {
+				t.Errorf("resource attributes = %v", attrs)
+			}
+			if scope := resourceSpans.ScopeSpans[0].Scope; scope.Name != "github.com/awcjack/cloudbet" || scope.Version != "v1" {
+				t.Errorf("scope = %v", scope)
+			}
+			span := resourceSpans.ScopeSpans[0].Spans[0]
+			if !bytes.Equal(span.TraceId, testTraceID[:]) || !bytes.Equal(span.SpanId, testSpanID[:]) || !bytes.Equal(span.ParentSpanId, testParentID[:]) {
+				t.Errorf("ids = %x %x %x", span.TraceId, span.SpanId, span.ParentSpanId)
+			}
+			if span.Name != tt.name || span.Kind != tt.wantKind {
+				t.Errorf("span %s of kind %s, want %s of kind %s", span.Name, span.Kind, tt.name, tt.wantKind)
+			}
+			if span.StartTimeUnixNano != uint64(testStart.UnixNano()) || span.EndTimeUnixNano != uint64(testStart.Add(time.Second).UnixNano()) {
+				t.Errorf("times = %d to %d", span.StartTimeUnixNano, span.EndTimeUnixNano)
+			}
+			if span.Status.Code != tt.wantStatus {
+				t.Errorf("status code = %s, want %s", span.Status.Code, tt.wantStatus)
+			}
+			if len(span.Attributes) != 1 || span.Attributes[0].Key != string(tt.attribute.Key) || !proto.Equal(span.Attributes[0].Value, tt.wantValue) {
+				t.Errorf("attributes = %v, want %s = %v", span.Attributes, tt.attribute.Key, tt.wantValue)
+			}
+			if len(span.Events) != 1 || span.Events[0].Name != "retry" || span.Events[0].TimeUnixNano != uint64(testStart.Add(time.Millisecond).UnixNano()) || span.Events[0].Attributes[0].Value.GetIntValue() != 2 {
+				t.Errorf("events = %v", span.Events)
+			}
+		})
+	}
+}
+
+func TestNewOtlpTracesGrouping(t *testing.T) {
+	first := newTestSpan("first", trace.SpanKindServer, codes.Unset)
+	second := newTestSpan("second", trace.SpanKindServer, codes.Unset)
+	otherScope := newTestSpan("other scope", trace.SpanKindServer, codes.Unset)
+	otherScope.InstrumentationLibrary = instrumentation.Library{Name: "net/http"}
+	otherResource := newTestSpan("other resource", trace.SpanKindServer, codes.Unset)
+	otherResource.Resource = resource.NewSchemaless(attribute.String("service.name", "replica"))
+
+	traces := newOtlpTraces(tracetest.SpanStubs{first, otherScope, otherResource, second}.Snapshots())
+	if len(traces.ResourceSpans) != 2 {
+		t.Fatalf("%d resources, want 2", len(traces.ResourceSpans))
+	}
+	scopes := traces.ResourceSpans[0].ScopeSpans
+	if len(scopes) != 2 || len(scopes[0].Spans) != 2 || scopes[0].Spans[1].Name != "second" || scopes[1].Scope.Name != "net/http" {
+		t.Errorf("scopes of the first resource = %+v", scopes)
+	}
+	if spans := traces.ResourceSpans[1].ScopeSpans; len(spans) != 1 || spans[0].Spans[0].Name != "other resource" {
+		t.Errorf("scopes of the second resource = %+v", spans)
+	}
+}
+
+func TestOtlpSpanExporter(t *testing.T) {
+	var body []byte
+	var header http.Header
+	var path string
+	status := http.StatusOK
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, header = r.URL.Path, r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	exporter := NewOtlpSpanExporter(srv.URL+"/", map[string]string{"Authorization": "Bearer token"})
+	spans := tracetest.SpanStubs{newTestSpan("export", trace.SpanKindServer, codes.Ok)}.Snapshots()
+	if err := exporter.ExportSpans(context.Background(), spans); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/v1/traces" || header.Get("Content-Type") != "application/json" || header.Get("Authorization") != "Bearer token" {
+		t.Errorf("request to %s with %v", path, header)
+	}
+	var traces tracepb.TracesData
+	decodeOtlp(t, body, &traces)
+
+	status = http.StatusBadRequest
+	if err := exporter.ExportSpans(context.Background(), spans); err == nil || !strings.Contains(err.Error(), "400") {
+		t.Errorf("ExportSpans() error = %v, want the collector rejection", err)
+	}
+}
+
+func TestStdoutSpanExporter(t *testing.T) {
+	var out bytes.Buffer
+	exporter := NewStdoutSpanExporter(&out)
+	spans := tracetest.SpanStubs{
+		newTestSpan("first", trace.SpanKindServer, codes.Ok),
+		newTestSpan("second", trace.SpanKindClient, codes.Error),
+	}.Snapshots()
+	if err := exporter.ExportSpans(context.Background(), spans); err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("%d lines, want one per span", len(lines))
+	}
+	for i, line := range lines {
+		var span tracepb.Span
+		decodeOtlp(t, []byte(line), &span)
+		if span.Name != spans[i].Name() {
+			t.Errorf("line %d = span %s, want %s", i, span.Name, spans[i].Name())
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package interfaces
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/apikey"
+	"github.com/gin-gonic/gin"
+)
+
+const apiKeyHeader = "X-API-Key"
+
+// scopes map method and route to the scope they require, any other route requires apikey.ScopeRead
+var scopes = map[string]string{
+	"GET /changes":                 apikey.ScopeStream,
+	"POST /value/probability":      apikey.ScopeAdmin,
+	"GET /apikey":                  apikey.ScopeAdmin,
+	"POST /apikey":                 apikey.ScopeAdmin,
+	"GET /apikey/:keyId":           apikey.ScopeAdmin,
+	"DELETE /apikey/:keyId":        apikey.ScopeAdmin,
+	"GET /admin/crawl":             apikey.ScopeAdmin,
+	"PATCH /admin/crawl":           apikey.ScopeAdmin,
+	"POST /admin/crawl/run":        apikey.ScopeAdmin,
+	"POST /admin/crawl/pause":      apikey.ScopeAdmin,
+	"POST /admin/crawl/resume":     apikey.ScopeAdmin,
+	"GET /admin/crawl-runs":        apikey.ScopeAdmin,
+	"GET /admin/crawl-runs/:runId": apikey.ScopeAdmin,
+	// exposes the peer instance URL
+	"GET /replication": apikey.ScopeAdmin,
+}
+
+// public routes are served without API key
+var public = map[string]bool{
+	"GET /metrics": true,
+	"GET /healthz": true,
+	"GET /readyz":  true,
+}
+
+// authenticate reject requests without a valid API key granted the scope of the route, and report the key limits in
+// RateLimit-* headers. It runs as router middleware since the generated operation middlewares cannot abort a request.
+func (h HttpServer) authenticate(c *gin.Context) {
+	if public[c.Request.Method+" "+c.FullPath()] {
+		c.Next()
+		return
+	}
+	scope, ok := scopes[c.Request.Method+" "+c.FullPath()]
+	if !ok {
+		scope = apikey.ScopeRead
+	}
+
+	_, limit, err := h.app.Command.Authenticate.Handle(c.Request.Context(), c.GetHeader(apiKeyHeader), scope)
+	if !limit.Unlimited() {
+		reset := int(math.Ceil(time.Until(limit.Reset()).Seconds()))
+		c.Header("RateLimit-Limit", strconv.Itoa(limit.Limit()))
+		c.Header("RateLimit-Remaining", strconv.Itoa(limit.Remaining()))
+		c.Header("RateLimit-Reset", strconv.Itoa(reset))
+		if limit.Exceeded() {
+			c.Header("Retry-After", strconv.Itoa(reset))
+		}
+	}
+	if err != nil {
+		if errors.Is(err, apikey.ErrMissingKey) || errors.Is(err, apikey.ErrInvalidKey) {
+			c.Header("WWW-Authenticate", `ApiKey header="`+apiKeyHeader+`"`)
+		}
+		writeError(c, err)
+		c.Abort()
+		return
+	}
+	c.Next()
+}
+
+func (h HttpServer) ListApiKeys(c *gin.Context) {
+	keys, err := h.app.Query.ListKeys.Handle(c.Request.Context())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := make([]ApiKey, len(keys))
+	for i, key := range keys {
+		result[i] = toApiKey(key)
+	}
+	c.JSON(http.StatusOK, ApiKeyList{
+		Items: result,
+	})
+}
+
+func (h HttpServer) CreateApiKey(c *gin.Context) {
+	var body CreateApiKeyJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, invalidBody(err))
+		return
+	}
+
+	scopes := make([]string, len(body.Scopes))
+	for i, v := range body.Scopes {
+		scopes[i] = string(v)
+	}
+	rateLimit, quota := 0, 0
+	if body.RateLimit != nil {
+		rateLimit = *body.RateLimit
+	}
+	if body.Quota != nil {
+		quota = *body.Quota
+	}
+
+	key, secret, err := h.app.Command.CreateKey.Handle(c.Request.Context(), body.Name, scopes, rateLimit, quota)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, ApiKeySecret{
+		Key:    toApiKey(key),
+		Secret: secret,
+	})
+}
+
+func (h HttpServer) GetApiKey(c *gin.Context, keyId string) {
+	if keyId == "" {
+		writeError(c, ErrMissingKey)
+		return
+	}
+
+	key, err := h.app.Query.GetKey.Handle(c.Request.Context(), keyId)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toApiKey(key))
+}
+
+func (h HttpServer) RevokeApiKey(c *gin.Context, keyId string) {
+	if keyId == "" {
+		writeError(c, ErrMissingKey)
+		return
+	}
+
+	key, err := h.app.Command.RevokeKey.Handle(c.Request.Context(), keyId)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toApiKey(key))
+}
+
+func toApiKey(key apikey.Key) ApiKey {
+	result := ApiKey{
+		Id:        key.ID(),
+		Name:      key.Name(),
+		Scopes:    key.Scopes(),
+		RateLimit: key.RateLimit(),
+		Quota:     key.Quota(),
+		CreatedAt: key.CreatedAt().Format(time.RFC3339),
+		Revoked:   key.Revoked(),
+	}
+	if key.Revoked() {
+		revokedAt := key.RevokedAt().Format(time.RFC3339)
+		result.RevokedAt = &revokedAt
+	}
+	return result
+}
@@ -0,0 +1,59 @@
+package interfaces
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/awcjack/cloudbet/domain/apikey"
+	"github.com/gin-gonic/gin"
+)
+
+// routeScope return the scope a route is expected to require
+func routeScope(method string, path string) string {
+	switch {
+	case strings.HasPrefix(path, "/admin/"), strings.HasPrefix(path, "/apikey"), path == "/replication":
+		return apikey.ScopeAdmin
+	case method == "POST" && path == "/value/probability":
+		return apikey.ScopeAdmin
+	case path == "/changes":
+		return apikey.ScopeStream
+	}
+	return apikey.ScopeRead
+}
+
+func TestScopes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterHandlers(router, HttpServer{})
+
+	routes := make(map[string]bool)
+	for _, route := range router.Routes() {
+		key := route.Method + " " + route.Path
+		routes[key] = true
+		if public[key] {
+			continue
+		}
+
+		scope, ok := scopes[key]
+		if !ok {
+			scope = apikey.ScopeRead
+		}
+		if want := routeScope(route.Method, route.Path); scope != want {
+			t.Errorf("%s requires scope %q, want %q", key, scope, want)
+		}
+	}
+
+	for key := range scopes {
+		if !routes[key] {
+			t.Errorf("scope set for unknown route %s", key)
+		}
+	}
+	for key := range public {
+		if !routes[key] {
+			t.Errorf("unknown public route %s", key)
+		}
+		if _, ok := scopes[key]; ok {
+			t.Errorf("public route %s has a scope", key)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package interfaces
+
+import (
+	"net/http"
+
+	"github.com/awcjack/cloudbet/domain/calculator"
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/gin-gonic/gin"
+)
+
+func (h HttpServer) CalculateKelly(c *gin.Context) {
+	var body KellyRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, invalidBody(err))
+		return
+	}
+
+	reference, err := toReference(body.Selection)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	multiplier := 1.0
+	if body.Fraction != nil {
+		multiplier = *body.Fraction
+	}
+	kelly, err := h.app.Query.CalculateKelly.Handle(c.Request.Context(), reference, body.Probability, body.Bankroll, multiplier)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, KellyResult{
+		Leg:         toCalculatedLeg(kelly.Leg()),
+		Probability: kelly.Probability(),
+		Edge:        kelly.Edge(),
+		Fraction:    kelly.Fraction(),
+	})
+}
+
+func (h HttpServer) CalculateHedge(c *gin.Context) {
+	var body HedgeRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, invalidBody(err))
+		return
+	}
+
+	reference, err := toReference(body.Selection)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	hedge, err := h.app.Query.CalculateHedge.Handle(c.Request.Context(), body.Stake, body.Price, reference)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, HedgeResult{
+		Leg:    toCalculatedLeg(hedge.Leg()),
+		Profit: hedge.Profit(),
+	})
+}
+
+func (h HttpServer) CalculateDutching(c *gin.Context) {
+	var body DutchingRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, invalidBody(err))
+		return
+	}
+
+	references, err := toReferences(body.Selections)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	dutching, err := h.app.Query.CalculateDutching.Handle(c.Request.Context(), references, body.Stake)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	legs := make([]CalculatedLeg, len(dutching.Legs()))
+	for i, leg := range dutching.Legs() {
+		legs[i] = toCalculatedLeg(leg)
+	}
+	c.JSON(http.StatusOK, DutchingResult{
+		Legs:       legs,
+		TotalStake: dutching.TotalStake(),
+		Payout:     dutching.Payout(),
+		Profit:     dutching.Profit(),
+	})
+}
+
+func (h HttpServer) CalculateParlay(c *gin.Context) {
+	var body ParlayRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, invalidBody(err))
+		return
+	}
+
+	references, err := toReferences(body.Selections)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	parlay, err := h.app.Query.CalculateParlay.Handle(c.Request.Context(), references, body.Stake)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	legs := make([]CalculatedLeg, len(parlay.Legs()))
+	for i, leg := range parlay.Legs() {
+		legs[i] = toCalculatedLeg(leg)
+	}
+	c.JSON(http.StatusOK, ParlayResult{
+		Legs:   legs,
+		Price:  parlay.Price(),
+		Stake:  parlay.Stake(),
+		Payout: parlay.Payout(),
+	})
+}
+
+func toReference(selection SelectionReference) (event.Reference, error) {
+	params := ""
+	if selection.Params != nil {
+		params = *selection.Params
+	}
+	return event.NewReference(selection.EventKey, selection.Market, selection.Outcome, params)
+}
+
+func toReferences(selections []SelectionReference) ([]event.Reference, error) {
+	references := make([]event.Reference, len(selections))
+	for i, selection := range selections {
+		reference, err := toReference(selection)
+		if err != nil {
+			return nil, err
+		}
+		references[i] = reference
+	}
+	return references, nil
+}
+
+func toCalculatedLeg(leg calculator.Leg) CalculatedLeg {
+	params := leg.Reference().Params()
+	side := leg.Selection().Side()
+	return CalculatedLeg{
+		Selection: SelectionReference{
+			EventKey: leg.Reference().EventKey(),
+			Market:   leg.Reference().MarketKey(),
+			Outcome:  leg.Reference().Outcome(),
+			Params:   &params,
+		},
+		Side:     &side,
+		Price:    leg.Selection().Price(),
+		MaxStake: leg.Selection().MaxStake(),
+		Stake:    leg.Stake(),
+	}
+}
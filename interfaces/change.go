@@ -0,0 +1,51 @@
+package interfaces
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrInvalidSince = failure.New(failure.Invalid, "invalid_since", "since cannot be negative")
+
+func (h HttpServer) ListChanges(c *gin.Context, params ListChangesParams) {
+	var since uint64
+	if params.Since != nil {
+		if *params.Since < 0 {
+			writeError(c, ErrInvalidSince)
+			return
+		}
+		since = uint64(*params.Since)
+	}
+	limit := 100
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+
+	repoData, err := h.app.Query.ListChanges.Handle(c.Request.Context(), since, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := make([]Change, len(repoData.Changes()))
+	for i, v := range repoData.Changes() {
+		result[i] = Change{
+			Sequence: int64(v.Sequence()),
+			Type:     ChangeType(v.Type()),
+			EventKey: v.EventKey(),
+			Time:     v.Time().Format(time.RFC3339),
+		}
+		if e, ok := v.Event(); ok {
+			event := toEvent(e, eventView{})
+			result[i].Event = &event
+		}
+	}
+	c.JSON(http.StatusOK, ChangeList{
+		Changes:        result,
+		LatestSequence: int64(repoData.LatestSequence()),
+		Epoch:          repoData.Epoch(),
+	})
+}
@@ -0,0 +1,51 @@
+package interfaces
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// setCacheControl let clients reuse a response until the next crawl can have changed it, following interval changes
+func (h HttpServer) setCacheControl(c *gin.Context) {
+	interval := h.app.Query.GetCrawlInterval.Handle(c.Request.Context())
+	c.Header("Cache-Control", "max-age="+strconv.Itoa(int(interval/time.Second)))
+}
+
+// notModified set the validators of a resource and report whether the request validators still match,
+// in which case 304 Not Modified is already written. If-None-Match takes precedence over If-Modified-Since.
+// Versions only compare within the change log epoch they were assigned in, so the ETag carries both and never
+// matches the one of another instance or of this instance before a restart.
+// variant tell apart the representations of the same version, e.g. a partial view, and is empty for the full one.
+func notModified(c *gin.Context, epoch string, version uint64, lastUpdated time.Time, variant string) bool {
+	tag := epoch + "." + strconv.FormatUint(version, 10)
+	if variant != "" {
+		tag += "-" + variant
+	}
+	etag := `"` + tag + `"`
+	c.Header("ETag", etag)
+	c.Header("Last-Modified", lastUpdated.UTC().Format(http.TimeFormat))
+
+	if ifNoneMatch := c.GetHeader("If-None-Match"); ifNoneMatch != "" {
+		for _, v := range strings.Split(ifNoneMatch, ",") {
+			v = strings.TrimPrefix(strings.TrimSpace(v), "W/")
+			if v == etag || v == "*" {
+				c.AbortWithStatus(http.StatusNotModified)
+				return true
+			}
+		}
+		return false
+	}
+
+	if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil {
+		// Last-Modified only has second precision
+		if !lastUpdated.Truncate(time.Second).After(since) {
+			c.AbortWithStatus(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package interfaces
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestNotModified(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	lastUpdated := time.Date(2022, 6, 1, 12, 0, 0, 500, time.UTC)
+
+	tests := []struct {
+		name            string
+		variant         string
+		ifNoneMatch     string
+		ifModifiedSince string
+		etag            string
+		want            bool
+	}{
+		{name: "no validators", etag: `"e1.42"`},
+		{name: "matching ETag", ifNoneMatch: `"e1.42"`, etag: `"e1.42"`, want: true},
+		{name: "weak matching ETag", ifNoneMatch: `W/"e1.42"`, etag: `"e1.42"`, want: true},
+		{name: "one of several ETags", ifNoneMatch: `"e1.41", "e1.42"`, etag: `"e1.42"`, want: true},
+		{name: "any", ifNoneMatch: `*`, etag: `"e1.42"`, want: true},
+		{name: "older version", ifNoneMatch: `"e1.41"`, etag: `"e1.42"`},
+		{name: "same version of another epoch", ifNoneMatch: `"e0.42"`, etag: `"e1.42"`},
+		{name: "version without epoch", ifNoneMatch: `"42"`, etag: `"e1.42"`},
+		{name: "partial view", variant: "ab12", ifNoneMatch: `"e1.42-ab12"`, etag: `"e1.42-ab12"`, want: true},
+		{name: "full view against a partial one", variant: "ab12", ifNoneMatch: `"e1.42"`, etag: `"e1.42-ab12"`},
+		{name: "not modified since", ifModifiedSince: "Wed, 01 Jun 2022 12:00:00 GMT", etag: `"e1.42"`, want: true},
+		{name: "modified since", ifModifiedSince: "Wed, 01 Jun 2022 11:59:59 GMT", etag: `"e1.42"`},
+		{name: "ETag wins over date", ifNoneMatch: `"e1.41"`, ifModifiedSince: "Wed, 01 Jun 2022 12:00:00 GMT", etag: `"e1.42"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			c.Request = httptest.NewRequest("GET", "/event/arsenal-v-chelsea", nil)
+			if tt.ifNoneMatch != "" {
+				c.Request.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			if tt.ifModifiedSince != "" {
+				c.Request.Header.Set("If-Modified-Since", tt.ifModifiedSince)
+			}
+
+			got := notModified(c, "e1", 42, lastUpdated, tt.variant)
+			if got != tt.want {
+				t.Errorf("notModified() = %t, want %t", got, tt.want)
+			}
+			if etag := w.Header().Get("ETag"); etag != tt.etag {
+				t.Errorf("ETag = %s, want %s", etag, tt.etag)
+			}
+			if lastModified := w.Header().Get("Last-Modified"); lastModified != "Wed, 01 Jun 2022 12:00:00 GMT" {
+				t.Errorf("Last-Modified = %s", lastModified)
+			}
+			if got && c.Writer.Status() != http.StatusNotModified {
+				t.Errorf("status = %d, want %d", c.Writer.Status(), http.StatusNotModified)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package interfaces
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/crawl"
+	"github.com/awcjack/cloudbet/domain/failure"
+	"github.com/gin-gonic/gin"
+)
+
+var ErrInvalidBefore = failure.New(failure.Invalid, "invalid_before", "before cannot be smaller than 1")
+
+func (h HttpServer) GetCrawlState(c *gin.Context, params GetCrawlStateParams) {
+	runs := 10
+	if params.Runs != nil {
+		runs = *params.Runs
+	}
+
+	state, err := h.app.Query.GetCrawlState.Handle(c.Request.Context(), runs)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toCrawlState(state))
+}
+
+func (h HttpServer) UpdateCrawlSettings(c *gin.Context) {
+	var body UpdateCrawlSettingsJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, invalidBody(err))
+		return
+	}
+
+	var interval, cutOffInterval *time.Duration
+	if body.IntervalSeconds != nil {
+		v := time.Duration(*body.IntervalSeconds) * time.Second
+		interval = &v
+	}
+	if body.CutOffIntervalSeconds != nil {
+		v := time.Duration(*body.CutOffIntervalSeconds) * time.Second
+		cutOffInterval = &v
+	}
+	if err := h.app.Command.SetCrawlIntervals.Handle(c.Request.Context(), interval, cutOffInterval); err != nil {
+		writeError(c, err)
+		return
+	}
+	h.GetCrawlState(c, GetCrawlStateParams{})
+}
+
+func (h HttpServer) TriggerCrawl(c *gin.Context) {
+	var body TriggerCrawlJSONRequestBody
+	// the body is optional, a full crawl is requested without it
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&body); err != nil {
+			writeError(c, invalidBody(err))
+			return
+		}
+	}
+	competitionKey := ""
+	if body.CompetitionKey != nil {
+		competitionKey = *body.CompetitionKey
+	}
+
+	run, err := h.app.Command.TriggerCrawl.Handle(c.Request.Context(), competitionKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusAccepted, toCrawlRun(run))
+}
+
+func (h HttpServer) PauseCrawl(c *gin.Context) {
+	h.app.Command.PauseCrawl.Handle(c.Request.Context(), true)
+	h.GetCrawlState(c, GetCrawlStateParams{})
+}
+
+func (h HttpServer) ResumeCrawl(c *gin.Context) {
+	h.app.Command.PauseCrawl.Handle(c.Request.Context(), false)
+	h.GetCrawlState(c, GetCrawlStateParams{})
+}
+
+func (h HttpServer) ListCrawlRuns(c *gin.Context, params ListCrawlRunsParams) {
+	var filter crawl.Filter
+	if params.Before != nil {
+		if *params.Before < 1 {
+			writeError(c, ErrInvalidBefore)
+			return
+		}
+		filter.Before = uint64(*params.Before)
+	}
+	if params.Kind != nil {
+		filter.Kind = string(*params.Kind)
+	}
+	if params.Trigger != nil {
+		filter.Trigger = string(*params.Trigger)
+	}
+	if params.Failed != nil {
+		filter.Failed = *params.Failed
+	}
+	limit := 10
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	runs, err := h.app.Query.ListCrawlRuns.Handle(c.Request.Context(), filter, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := CrawlRunList{
+		Items: make([]CrawlRun, len(runs)),
+	}
+	for i, run := range runs {
+		result.Items[i] = toCrawlRun(run)
+	}
+	// a full page may be followed by older runs
+	if len(runs) == limit && runs[len(runs)-1].ID() > 1 {
+		next := int64(runs[len(runs)-1].ID())
+		result.Next = &next
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h HttpServer) GetCrawlRun(c *gin.Context, runId int64) {
+	if runId < 1 {
+		writeError(c, crawl.ErrRunNotFound)
+		return
+	}
+
+	run, err := h.app.Query.GetCrawlRun.Handle(c.Request.Context(), uint64(runId))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toCrawlRunDetail(run))
+}
+
+func toCrawlState(state crawl.State) CrawlState {
+	runs := make([]CrawlRun, len(state.Runs()))
+	for i, run := range state.Runs() {
+		runs[i] = toCrawlRun(run)
+	}
+	result := CrawlState{
+		Enabled:               state.Enabled(),
+		Paused:                state.Paused(),
+		IntervalSeconds:       int(state.Interval() / time.Second),
+		CutOffIntervalSeconds: int(state.CutOffInterval() / time.Second),
+		Runs:                  runs,
+	}
+	if !state.NextRun().IsZero() {
+		nextRun := state.NextRun().Format(time.RFC3339)
+		result.NextRun = &nextRun
+	}
+	if state.Current() != nil {
+		current := toCrawlRun(*state.Current())
+		result.Current = &current
+	}
+	return result
+}
+
+func toCrawlRun(run crawl.Run) CrawlRun {
+	result := CrawlRun{
+		Id:                int64(run.ID()),
+		Kind:              CrawlRunKind(run.Kind()),
+		Trigger:           CrawlRunTrigger(run.Trigger()),
+		StartedAt:         run.StartedAt().Format(time.RFC3339),
+		CompetitionsTotal: run.CompetitionsTotal(),
+		CompetitionsDone:  run.CompetitionsDone(),
+		SportsFetched:     run.SportsFetched(),
+		EventsFetched:     run.EventsFetched(),
+		EventsCreated:     run.EventsCreated(),
+		EventsUpdated:     run.EventsUpdated(),
+		EventsUnchanged:   run.EventsUnchanged(),
+		ErrorCount:        run.ErrorCount(),
+	}
+	if run.CompetitionKey() != "" {
+		competitionKey := run.CompetitionKey()
+		result.CompetitionKey = &competitionKey
+	}
+	if run.Finished() {
+		finishedAt := run.FinishedAt().Format(time.RFC3339)
+		result.FinishedAt = &finishedAt
+	}
+	return result
+}
+
+// toCrawlRunDetail convert run with its errors
+func toCrawlRunDetail(run crawl.Run) CrawlRun {
+	result := toCrawlRun(run)
+	errors := make([]CrawlItemError, len(run.Errors()))
+	for i, v := range run.Errors() {
+		errors[i] = CrawlItemError{
+			Time:       v.Time().Format(time.RFC3339),
+			Kind:       CrawlItemErrorKind(v.Kind()),
+			StatusCode: v.StatusCode(),
+			Message:    v.Message(),
+		}
+		if v.Key() != "" {
+			key := v.Key()
+			errors[i].Key = &key
+		}
+	}
+	result.Errors = &errors
+	return result
+}
@@ -0,0 +1,104 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+	"github.com/gin-gonic/gin"
+)
+
+const problemContentType = "application/problem+json"
+
+var (
+	ErrSizeTooLarge = failure.New(failure.Invalid, "invalid_size", "size cannot be smaller than 1 or larger than 50")
+	ErrPageTooSmall = failure.New(failure.Invalid, "invalid_page", "page cannot be smaller than 1")
+	ErrMissingKey   = failure.New(failure.Invalid, "missing_key", "missing key")
+	ErrInvalidBody  = failure.New(failure.Invalid, "invalid_body", "invalid request body")
+)
+
+// statuses map failure kinds to HTTP status codes, anything else is an internal error
+var statuses = map[failure.Kind]int{
+	failure.Invalid:         http.StatusBadRequest,
+	failure.NotFound:        http.StatusNotFound,
+	failure.Unavailable:     http.StatusServiceUnavailable,
+	failure.Gone:            http.StatusGone,
+	failure.Unauthenticated: http.StatusUnauthorized,
+	failure.Forbidden:       http.StatusForbidden,
+	failure.TooManyRequests: http.StatusTooManyRequests,
+	failure.Conflict:        http.StatusConflict,
+}
+
+// writeError report err as a problem+json body with the status matching its failure kind
+func writeError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	problem := Error{
+		Code:    "internal_error",
+		Message: "internal server error",
+	}
+	if domainErr, ok := failure.As(err); ok {
+		if v, ok := statuses[domainErr.Kind()]; ok {
+			status = v
+		}
+		problem.Code = domainErr.Code()
+		problem.Message = domainErr.Message()
+		if err.Error() != domainErr.Message() {
+			details := err.Error()
+			problem.Details = &details
+		}
+	} else if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		status = http.StatusServiceUnavailable
+		problem.Code = "unavailable"
+		problem.Message = "request could not be served in time"
+	}
+
+	// internal errors are only logged, their details may leak implementation
+	_ = c.Error(err)
+	c.Header("Content-Type", problemContentType)
+	c.JSON(status, problem)
+}
+
+// invalidBody wrap a request body binding error
+func invalidBody(err error) error {
+	return fmt.Errorf("%w: %s", ErrInvalidBody, err)
+}
+
+// parameterErrorWriter rewrite the {"msg": ...} bodies written by the generated router on malformed parameters into problem+json
+type parameterErrorWriter struct {
+	gin.ResponseWriter
+}
+
+func (w parameterErrorWriter) Write(data []byte) (int, error) {
+	if w.Status() != http.StatusBadRequest || strings.HasPrefix(w.Header().Get("Content-Type"), problemContentType) {
+		return w.ResponseWriter.Write(data)
+	}
+	var body struct {
+		Msg string `json:"msg"`
+	}
+	if err := json.Unmarshal(data, &body); err != nil || body.Msg == "" {
+		return w.ResponseWriter.Write(data)
+	}
+
+	problem, err := json.Marshal(Error{
+		Code:    "invalid_parameter",
+		Message: "invalid parameter",
+		Details: &body.Msg,
+	})
+	if err != nil {
+		return w.ResponseWriter.Write(data)
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	if _, err := w.ResponseWriter.Write(problem); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+func problemMiddleware(c *gin.Context) {
+	c.Writer = parameterErrorWriter{c.Writer}
+	c.Next()
+}
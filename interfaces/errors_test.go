@@ -0,0 +1,99 @@
+package interfaces
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/failure"
+	"github.com/gin-gonic/gin"
+)
+
+func TestWriteError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name        string
+		err         error
+		wantStatus  int
+		wantCode    string
+		wantDetails string
+	}{
+		{name: "not found", err: event.ErrEventNotFound, wantStatus: http.StatusNotFound, wantCode: "event_not_found"},
+		{name: "invalid", err: event.ErrInvalidSort, wantStatus: http.StatusBadRequest, wantCode: "invalid_sort"},
+		{name: "wrapped with details", err: fmt.Errorf("%w: stake", ErrInvalidBody), wantStatus: http.StatusBadRequest, wantCode: "invalid_body", wantDetails: "invalid request body: stake"},
+		{name: "unavailable", err: failure.New(failure.Unavailable, "not_ready", "not ready"), wantStatus: http.StatusServiceUnavailable, wantCode: "not_ready"},
+		{name: "unknown kind", err: failure.New(failure.Kind(0), "odd", "odd"), wantStatus: http.StatusInternalServerError, wantCode: "odd"},
+		{name: "deadline", err: fmt.Errorf("list events: %w", context.DeadlineExceeded), wantStatus: http.StatusServiceUnavailable, wantCode: "unavailable"},
+		{name: "internal details hidden", err: errors.New("connection refused"), wantStatus: http.StatusInternalServerError, wantCode: "internal_error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+			writeError(c, tt.err)
+
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != problemContentType {
+				t.Errorf("Content-Type = %s, want %s", contentType, problemContentType)
+			}
+			var problem Error
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatal(err)
+			}
+			details := ""
+			if problem.Details != nil {
+				details = *problem.Details
+			}
+			if problem.Code != tt.wantCode || details != tt.wantDetails {
+				t.Errorf("writeError() = %s %q, want %s %q", problem.Code, details, tt.wantCode, tt.wantDetails)
+			}
+			if len(c.Errors) != 1 {
+				t.Errorf("%d errors recorded, want 1", len(c.Errors))
+			}
+		})
+	}
+}
+
+func TestParameterErrorWriter(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		wantContentType string
+		wantBody        string
+	}{
+		{name: "parameter error", status: http.StatusBadRequest, body: `{"msg":"invalid format for parameter first"}`, wantContentType: problemContentType, wantBody: `{"code":"invalid_parameter","details":"invalid format for parameter first","message":"invalid parameter"}`},
+		{name: "other bad request", status: http.StatusBadRequest, body: `{"code":"invalid_sort"}`, wantContentType: "application/json; charset=utf-8", wantBody: `{"code":"invalid_sort"}`},
+		{name: "success", status: http.StatusOK, body: `{"msg":"ok"}`, wantContentType: "application/json; charset=utf-8", wantBody: `{"msg":"ok"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(problemMiddleware)
+			router.GET("/", func(c *gin.Context) {
+				c.Data(tt.status, "application/json; charset=utf-8", []byte(tt.body))
+			})
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.wantContentType {
+				t.Errorf("Content-Type = %s, want %s", contentType, tt.wantContentType)
+			}
+			if w.Body.String() != tt.wantBody {
+				t.Errorf("body = %s, want %s", w.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
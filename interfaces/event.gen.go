@@ -13,30 +13,132 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Get crawl state
+	// (GET /admin/crawl)
+	GetCrawlState(c *gin.Context, params GetCrawlStateParams)
+	// Change crawl intervals
+	// (PATCH /admin/crawl)
+	UpdateCrawlSettings(c *gin.Context)
+	// List crawl runs
+	// (GET /admin/crawl-runs)
+	ListCrawlRuns(c *gin.Context, params ListCrawlRunsParams)
+	// Get crawl run
+	// (GET /admin/crawl-runs/{runId})
+	GetCrawlRun(c *gin.Context, runId int64)
+	// Pause crawl schedule
+	// (POST /admin/crawl/pause)
+	PauseCrawl(c *gin.Context)
+	// Resume crawl schedule
+	// (POST /admin/crawl/resume)
+	ResumeCrawl(c *gin.Context)
+	// Trigger crawl
+	// (POST /admin/crawl/run)
+	TriggerCrawl(c *gin.Context)
+	// List API keys
+	// (GET /apikey)
+	ListApiKeys(c *gin.Context)
+	// Create API key
+	// (POST /apikey)
+	CreateApiKey(c *gin.Context)
+	// Revoke API key
+	// (DELETE /apikey/{keyId})
+	RevokeApiKey(c *gin.Context, keyId KeyId)
+	// Get API key
+	// (GET /apikey/{keyId})
+	GetApiKey(c *gin.Context, keyId KeyId)
+	// List arbitrage opportunities
+	// (GET /arbitrage)
+	ListArbitrage(c *gin.Context, params ListArbitrageParams)
+	// Calculate dutching split
+	// (POST /calculator/dutching)
+	CalculateDutching(c *gin.Context)
+	// Calculate hedge stake
+	// (POST /calculator/hedge)
+	CalculateHedge(c *gin.Context)
+	// Calculate Kelly stake
+	// (POST /calculator/kelly)
+	CalculateKelly(c *gin.Context)
+	// Calculate parlay price
+	// (POST /calculator/parlay)
+	CalculateParlay(c *gin.Context)
 	// List categories
 	// (GET /category)
 	ListCategories(c *gin.Context, params ListCategoriesParams)
 	// Get category info
 	// (GET /category/{categoryKey})
 	GetCategory(c *gin.Context, categoryKey string)
+	// List category competitions
+	// (GET /category/{categoryKey}/competition)
+	ListCategoryCompetitions(c *gin.Context, categoryKey string, params ListCategoryCompetitionsParams)
+	// List changes
+	// (GET /changes)
+	ListChanges(c *gin.Context, params ListChangesParams)
 	// List competitions
 	// (GET /competition)
 	ListCompetitions(c *gin.Context, params ListCompetitionsParams)
 	// Get competition info
 	// (GET /competition/{competitionKey})
 	GetCompetition(c *gin.Context, competitionKey string)
+	// List competition events
+	// (GET /competition/{competitionKey}/event)
+	ListCompetitionEvents(c *gin.Context, competitionKey string, params ListCompetitionEventsParams)
 	// List events
 	// (GET /event)
 	ListEvents(c *gin.Context, params ListEventsParams)
+	// Get events by keys
+	// (POST /event/batchGet)
+	GetEvents(c *gin.Context, params GetEventsParams)
 	// Get event info
 	// (GET /event/{eventKey})
-	GetEvent(c *gin.Context, eventKey string)
+	GetEvent(c *gin.Context, eventKey string, params GetEventParams)
+	// Get event market ladder
+	// (GET /event/{eventKey}/ladder/{marketKey})
+	GetEventLadder(c *gin.Context, eventKey string, marketKey string, params GetEventLadderParams)
+	// Get event liquidity
+	// (GET /event/{eventKey}/liquidity)
+	GetEventLiquidity(c *gin.Context, eventKey string)
+	// Liveness probe
+	// (GET /healthz)
+	GetHealth(c *gin.Context)
+	// Rank events by liquidity
+	// (GET /liquidity)
+	RankLiquidity(c *gin.Context, params RankLiquidityParams)
+	// Prometheus metrics
+	// (GET /metrics)
+	GetMetrics(c *gin.Context)
+	// Readiness probe
+	// (GET /readyz)
+	GetReadiness(c *gin.Context)
+	// Get replication status
+	// (GET /replication)
+	GetReplicationStatus(c *gin.Context)
+	// Search
+	// (GET /search)
+	Search(c *gin.Context, params SearchParams)
 	// List sports
 	// (GET /sport)
 	ListSports(c *gin.Context, params ListSportsParams)
 	// Get sport info
 	// (GET /sport/{sportKey})
 	GetSport(c *gin.Context, sportKey string)
+	// List sport categories
+	// (GET /sport/{sportKey}/category)
+	ListSportCategories(c *gin.Context, sportKey string, params ListSportCategoriesParams)
+	// List teams
+	// (GET /team)
+	ListTeams(c *gin.Context, params ListTeamsParams)
+	// Get team info
+	// (GET /team/{teamKey})
+	GetTeam(c *gin.Context, teamKey string)
+	// List team events
+	// (GET /team/{teamKey}/events)
+	ListTeamEvents(c *gin.Context, teamKey string, params ListTeamEventsParams)
+	// List value bets
+	// (GET /value)
+	ListValueBets(c *gin.Context, params ListValueBetsParams)
+	// Upload model probabilities
+	// (POST /value/probability)
+	UploadModelProbabilities(c *gin.Context)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -47,50 +149,1201 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// GetCrawlState operation middleware
+func (siw *ServerInterfaceWrapper) GetCrawlState(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCrawlStateParams
+
+	// ------------- Optional query parameter "runs" -------------
+	if paramValue := c.Query("runs"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "runs", c.Request.URL.Query(), &params.Runs)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter runs: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetCrawlState(c, params)
+}
+
+// UpdateCrawlSettings operation middleware
+func (siw *ServerInterfaceWrapper) UpdateCrawlSettings(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.UpdateCrawlSettings(c)
+}
+
+// ListCrawlRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListCrawlRuns(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCrawlRunsParams
+
+	// ------------- Optional query parameter "before" -------------
+	if paramValue := c.Query("before"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "before", c.Request.URL.Query(), &params.Before)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter before: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := c.Query("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter limit: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "kind" -------------
+	if paramValue := c.Query("kind"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "kind", c.Request.URL.Query(), &params.Kind)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter kind: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "trigger" -------------
+	if paramValue := c.Query("trigger"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "trigger", c.Request.URL.Query(), &params.Trigger)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter trigger: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "failed" -------------
+	if paramValue := c.Query("failed"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "failed", c.Request.URL.Query(), &params.Failed)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter failed: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListCrawlRuns(c, params)
+}
+
+// GetCrawlRun operation middleware
+func (siw *ServerInterfaceWrapper) GetCrawlRun(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "runId" -------------
+	var runId int64
+
+	err = runtime.BindStyledParameter("simple", false, "runId", c.Param("runId"), &runId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter runId: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetCrawlRun(c, runId)
+}
+
+// PauseCrawl operation middleware
+func (siw *ServerInterfaceWrapper) PauseCrawl(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.PauseCrawl(c)
+}
+
+// ResumeCrawl operation middleware
+func (siw *ServerInterfaceWrapper) ResumeCrawl(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ResumeCrawl(c)
+}
+
+// TriggerCrawl operation middleware
+func (siw *ServerInterfaceWrapper) TriggerCrawl(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.TriggerCrawl(c)
+}
+
+// ListApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) ListApiKeys(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListApiKeys(c)
+}
+
+// CreateApiKey operation middleware
+func (siw *ServerInterfaceWrapper) CreateApiKey(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CreateApiKey(c)
+}
+
+// RevokeApiKey operation middleware
+func (siw *ServerInterfaceWrapper) RevokeApiKey(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId KeyId
+
+	err = runtime.BindStyledParameter("simple", false, "keyId", c.Param("keyId"), &keyId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter keyId: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.RevokeApiKey(c, keyId)
+}
+
+// GetApiKey operation middleware
+func (siw *ServerInterfaceWrapper) GetApiKey(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId KeyId
+
+	err = runtime.BindStyledParameter("simple", false, "keyId", c.Param("keyId"), &keyId)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter keyId: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetApiKey(c, keyId)
+}
+
+// ListArbitrage operation middleware
+func (siw *ServerInterfaceWrapper) ListArbitrage(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListArbitrageParams
+
+	// ------------- Required query parameter "first" -------------
+	if paramValue := c.Query("first"); paramValue != "" {
+
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
+		return
+	}
+
+	// ------------- Required query parameter "page" -------------
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument page is required, but not found"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListArbitrage(c, params)
+}
+
+// CalculateDutching operation middleware
+func (siw *ServerInterfaceWrapper) CalculateDutching(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CalculateDutching(c)
+}
+
+// CalculateHedge operation middleware
+func (siw *ServerInterfaceWrapper) CalculateHedge(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CalculateHedge(c)
+}
+
+// CalculateKelly operation middleware
+func (siw *ServerInterfaceWrapper) CalculateKelly(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CalculateKelly(c)
+}
+
+// CalculateParlay operation middleware
+func (siw *ServerInterfaceWrapper) CalculateParlay(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.CalculateParlay(c)
+}
+
 // ListCategories operation middleware
 func (siw *ServerInterfaceWrapper) ListCategories(c *gin.Context) {
 
 	var err error
 
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCategoriesParams
+
+	// ------------- Required query parameter "first" -------------
+	if paramValue := c.Query("first"); paramValue != "" {
+
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "sport" -------------
+	if paramValue := c.Query("sport"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sport", c.Request.URL.Query(), &params.Sport)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sport: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListCategories(c, params)
+}
+
+// GetCategory operation middleware
+func (siw *ServerInterfaceWrapper) GetCategory(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "categoryKey" -------------
+	var categoryKey string
+
+	err = runtime.BindStyledParameter("simple", false, "categoryKey", c.Param("categoryKey"), &categoryKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter categoryKey: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetCategory(c, categoryKey)
+}
+
+// ListCategoryCompetitions operation middleware
+func (siw *ServerInterfaceWrapper) ListCategoryCompetitions(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "categoryKey" -------------
+	var categoryKey string
+
+	err = runtime.BindStyledParameter("simple", false, "categoryKey", c.Param("categoryKey"), &categoryKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter categoryKey: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCategoryCompetitionsParams
+
+	// ------------- Required query parameter "first" -------------
+	if paramValue := c.Query("first"); paramValue != "" {
+
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "sport" -------------
+	if paramValue := c.Query("sport"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sport", c.Request.URL.Query(), &params.Sport)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sport: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListCategoryCompetitions(c, categoryKey, params)
+}
+
+// ListChanges operation middleware
+func (siw *ServerInterfaceWrapper) ListChanges(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListChangesParams
+
+	// ------------- Optional query parameter "since" -------------
+	if paramValue := c.Query("since"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "since", c.Request.URL.Query(), &params.Since)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter since: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := c.Query("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter limit: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListChanges(c, params)
+}
+
+// ListCompetitions operation middleware
+func (siw *ServerInterfaceWrapper) ListCompetitions(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCompetitionsParams
+
+	// ------------- Required query parameter "first" -------------
+	if paramValue := c.Query("first"); paramValue != "" {
+
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "sport" -------------
+	if paramValue := c.Query("sport"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sport", c.Request.URL.Query(), &params.Sport)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sport: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+	if paramValue := c.Query("category"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "category", c.Request.URL.Query(), &params.Category)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter category: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListCompetitions(c, params)
+}
+
+// GetCompetition operation middleware
+func (siw *ServerInterfaceWrapper) GetCompetition(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "competitionKey" -------------
+	var competitionKey string
+
+	err = runtime.BindStyledParameter("simple", false, "competitionKey", c.Param("competitionKey"), &competitionKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter competitionKey: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetCompetition(c, competitionKey)
+}
+
+// ListCompetitionEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListCompetitionEvents(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "competitionKey" -------------
+	var competitionKey string
+
+	err = runtime.BindStyledParameter("simple", false, "competitionKey", c.Param("competitionKey"), &competitionKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter competitionKey: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListCompetitionEventsParams
+
+	// ------------- Required query parameter "first" -------------
+	if paramValue := c.Query("first"); paramValue != "" {
+
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+	if paramValue := c.Query("fields"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", c.Request.URL.Query(), &params.Fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter fields: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "markets" -------------
+	if paramValue := c.Query("markets"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "markets", c.Request.URL.Query(), &params.Markets)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter markets: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListCompetitionEvents(c, competitionKey, params)
+}
+
+// ListEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListEvents(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListEventsParams
+
+	// ------------- Required query parameter "first" -------------
+	if paramValue := c.Query("first"); paramValue != "" {
+
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "sport" -------------
+	if paramValue := c.Query("sport"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sport", c.Request.URL.Query(), &params.Sport)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sport: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "competition" -------------
+	if paramValue := c.Query("competition"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "competition", c.Request.URL.Query(), &params.Competition)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter competition: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "category" -------------
+	if paramValue := c.Query("category"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "category", c.Request.URL.Query(), &params.Category)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter category: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "team" -------------
+	if paramValue := c.Query("team"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "team", c.Request.URL.Query(), &params.Team)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter team: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "nationality" -------------
+	if paramValue := c.Query("nationality"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "nationality", c.Request.URL.Query(), &params.Nationality)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter nationality: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "cutoffFrom" -------------
+	if paramValue := c.Query("cutoffFrom"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cutoffFrom", c.Request.URL.Query(), &params.CutoffFrom)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cutoffFrom: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "cutoffTo" -------------
+	if paramValue := c.Query("cutoffTo"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cutoffTo", c.Request.URL.Query(), &params.CutoffTo)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cutoffTo: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "active" -------------
+	if paramValue := c.Query("active"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "active", c.Request.URL.Query(), &params.Active)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter active: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "live" -------------
+	if paramValue := c.Query("live"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "live", c.Request.URL.Query(), &params.Live)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter live: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+	if paramValue := c.Query("sort"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sort: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "order" -------------
+	if paramValue := c.Query("order"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "order", c.Request.URL.Query(), &params.Order)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter order: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "fields" -------------
+	if paramValue := c.Query("fields"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", c.Request.URL.Query(), &params.Fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter fields: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "markets" -------------
+	if paramValue := c.Query("markets"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "markets", c.Request.URL.Query(), &params.Markets)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter markets: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListEvents(c, params)
+}
+
+// GetEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetEvents(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventsParams
+
+	// ------------- Optional query parameter "fields" -------------
+	if paramValue := c.Query("fields"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", c.Request.URL.Query(), &params.Fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter fields: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "markets" -------------
+	if paramValue := c.Query("markets"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "markets", c.Request.URL.Query(), &params.Markets)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter markets: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetEvents(c, params)
+}
+
+// GetEvent operation middleware
+func (siw *ServerInterfaceWrapper) GetEvent(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "eventKey" -------------
+	var eventKey string
+
+	err = runtime.BindStyledParameter("simple", false, "eventKey", c.Param("eventKey"), &eventKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter eventKey: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventParams
+
+	// ------------- Optional query parameter "fields" -------------
+	if paramValue := c.Query("fields"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "fields", c.Request.URL.Query(), &params.Fields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter fields: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "markets" -------------
+	if paramValue := c.Query("markets"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "markets", c.Request.URL.Query(), &params.Markets)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter markets: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetEvent(c, eventKey, params)
+}
+
+// GetEventLadder operation middleware
+func (siw *ServerInterfaceWrapper) GetEventLadder(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "eventKey" -------------
+	var eventKey string
+
+	err = runtime.BindStyledParameter("simple", false, "eventKey", c.Param("eventKey"), &eventKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter eventKey: %s", err)})
+		return
+	}
+
+	// ------------- Path parameter "marketKey" -------------
+	var marketKey string
+
+	err = runtime.BindStyledParameter("simple", false, "marketKey", c.Param("marketKey"), &marketKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter marketKey: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetEventLadderParams
+
+	// ------------- Optional query parameter "submarket" -------------
+	if paramValue := c.Query("submarket"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "submarket", c.Request.URL.Query(), &params.Submarket)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter submarket: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetEventLadder(c, eventKey, marketKey, params)
+}
+
+// GetEventLiquidity operation middleware
+func (siw *ServerInterfaceWrapper) GetEventLiquidity(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "eventKey" -------------
+	var eventKey string
+
+	err = runtime.BindStyledParameter("simple", false, "eventKey", c.Param("eventKey"), &eventKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter eventKey: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetEventLiquidity(c, eventKey)
+}
+
+// GetHealth operation middleware
+func (siw *ServerInterfaceWrapper) GetHealth(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetHealth(c)
+}
+
+// RankLiquidity operation middleware
+func (siw *ServerInterfaceWrapper) RankLiquidity(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params RankLiquidityParams
+
+	// ------------- Required query parameter "first" -------------
+	if paramValue := c.Query("first"); paramValue != "" {
+
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
+		return
+	}
+
+	// ------------- Required query parameter "page" -------------
+	if paramValue := c.Query("page"); paramValue != "" {
+
+	} else {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument page is required, but not found"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "sport" -------------
+	if paramValue := c.Query("sport"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "sport", c.Request.URL.Query(), &params.Sport)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sport: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.RankLiquidity(c, params)
+}
+
+// GetMetrics operation middleware
+func (siw *ServerInterfaceWrapper) GetMetrics(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetMetrics(c)
+}
+
+// GetReadiness operation middleware
+func (siw *ServerInterfaceWrapper) GetReadiness(c *gin.Context) {
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetReadiness(c)
+}
+
+// GetReplicationStatus operation middleware
+func (siw *ServerInterfaceWrapper) GetReplicationStatus(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.GetReplicationStatus(c)
+}
+
+// Search operation middleware
+func (siw *ServerInterfaceWrapper) Search(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListCategoriesParams
+	var params SearchParams
 
-	// ------------- Required query parameter "first" -------------
-	if paramValue := c.Query("first"); paramValue != "" {
+	// ------------- Required query parameter "q" -------------
+	if paramValue := c.Query("q"); paramValue != "" {
 
 	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument q is required, but not found"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
+	err = runtime.BindQueryParameter("form", true, true, "q", c.Request.URL.Query(), &params.Q)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter q: %s", err)})
 		return
 	}
 
-	// ------------- Required query parameter "page" -------------
-	if paramValue := c.Query("page"); paramValue != "" {
+	// ------------- Optional query parameter "type" -------------
+	if paramValue := c.Query("type"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "type", c.Request.URL.Query(), &params.Type)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter type: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+	if paramValue := c.Query("limit"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter limit: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.Search(c, params)
+}
+
+// ListSports operation middleware
+func (siw *ServerInterfaceWrapper) ListSports(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListSportsParams
+
+	// ------------- Required query parameter "first" -------------
+	if paramValue := c.Query("first"); paramValue != "" {
 
 	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument page is required, but not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
+	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "sport" -------------
-	if paramValue := c.Query("sport"); paramValue != "" {
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
 
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "sport", c.Request.URL.Query(), &params.Sport)
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sport: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
 		return
 	}
 
@@ -98,37 +1351,50 @@ func (siw *ServerInterfaceWrapper) ListCategories(c *gin.Context) {
 		middleware(c)
 	}
 
-	siw.Handler.ListCategories(c, params)
+	siw.Handler.ListSports(c, params)
 }
 
-// GetCategory operation middleware
-func (siw *ServerInterfaceWrapper) GetCategory(c *gin.Context) {
+// GetSport operation middleware
+func (siw *ServerInterfaceWrapper) GetSport(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "categoryKey" -------------
-	var categoryKey string
+	// ------------- Path parameter "sportKey" -------------
+	var sportKey string
 
-	err = runtime.BindStyledParameter("simple", false, "categoryKey", c.Param("categoryKey"), &categoryKey)
+	err = runtime.BindStyledParameter("simple", false, "sportKey", c.Param("sportKey"), &sportKey)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter categoryKey: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sportKey: %s", err)})
 		return
 	}
 
+	c.Set(ApiKeyScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.GetCategory(c, categoryKey)
+	siw.Handler.GetSport(c, sportKey)
 }
 
-// ListCompetitions operation middleware
-func (siw *ServerInterfaceWrapper) ListCompetitions(c *gin.Context) {
+// ListSportCategories operation middleware
+func (siw *ServerInterfaceWrapper) ListSportCategories(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "sportKey" -------------
+	var sportKey string
+
+	err = runtime.BindStyledParameter("simple", false, "sportKey", c.Param("sportKey"), &sportKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sportKey: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListCompetitionsParams
+	var params ListSportCategoriesParams
 
 	// ------------- Required query parameter "first" -------------
 	if paramValue := c.Query("first"); paramValue != "" {
@@ -144,28 +1410,67 @@ func (siw *ServerInterfaceWrapper) ListCompetitions(c *gin.Context) {
 		return
 	}
 
-	// ------------- Required query parameter "page" -------------
-	if paramValue := c.Query("page"); paramValue != "" {
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.ListSportCategories(c, sportKey, params)
+}
+
+// ListTeams operation middleware
+func (siw *ServerInterfaceWrapper) ListTeams(c *gin.Context) {
+
+	var err error
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTeamsParams
+
+	// ------------- Required query parameter "first" -------------
+	if paramValue := c.Query("first"); paramValue != "" {
 
 	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument page is required, but not found"})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument first is required, but not found"})
 		return
 	}
 
-	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
+	err = runtime.BindQueryParameter("form", true, true, "first", c.Request.URL.Query(), &params.First)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter first: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "sport" -------------
-	if paramValue := c.Query("sport"); paramValue != "" {
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
 
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "sport", c.Request.URL.Query(), &params.Sport)
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sport: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
+		return
+	}
+
+	// ------------- Optional query parameter "nationality" -------------
+	if paramValue := c.Query("nationality"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "nationality", c.Request.URL.Query(), &params.Nationality)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter nationality: %s", err)})
 		return
 	}
 
@@ -173,37 +1478,50 @@ func (siw *ServerInterfaceWrapper) ListCompetitions(c *gin.Context) {
 		middleware(c)
 	}
 
-	siw.Handler.ListCompetitions(c, params)
+	siw.Handler.ListTeams(c, params)
 }
 
-// GetCompetition operation middleware
-func (siw *ServerInterfaceWrapper) GetCompetition(c *gin.Context) {
+// GetTeam operation middleware
+func (siw *ServerInterfaceWrapper) GetTeam(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "competitionKey" -------------
-	var competitionKey string
+	// ------------- Path parameter "teamKey" -------------
+	var teamKey string
 
-	err = runtime.BindStyledParameter("simple", false, "competitionKey", c.Param("competitionKey"), &competitionKey)
+	err = runtime.BindStyledParameter("simple", false, "teamKey", c.Param("teamKey"), &teamKey)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter competitionKey: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter teamKey: %s", err)})
 		return
 	}
 
+	c.Set(ApiKeyScopes, []string{""})
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 	}
 
-	siw.Handler.GetCompetition(c, competitionKey)
+	siw.Handler.GetTeam(c, teamKey)
 }
 
-// ListEvents operation middleware
-func (siw *ServerInterfaceWrapper) ListEvents(c *gin.Context) {
+// ListTeamEvents operation middleware
+func (siw *ServerInterfaceWrapper) ListTeamEvents(c *gin.Context) {
 
 	var err error
 
+	// ------------- Path parameter "teamKey" -------------
+	var teamKey string
+
+	err = runtime.BindStyledParameter("simple", false, "teamKey", c.Param("teamKey"), &teamKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter teamKey: %s", err)})
+		return
+	}
+
+	c.Set(ApiKeyScopes, []string{""})
+
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListEventsParams
+	var params ListTeamEventsParams
 
 	// ------------- Required query parameter "first" -------------
 	if paramValue := c.Query("first"); paramValue != "" {
@@ -219,50 +1537,36 @@ func (siw *ServerInterfaceWrapper) ListEvents(c *gin.Context) {
 		return
 	}
 
-	// ------------- Required query parameter "page" -------------
-	if paramValue := c.Query("page"); paramValue != "" {
-
-	} else {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": "Query argument page is required, but not found"})
-		return
-	}
-
-	err = runtime.BindQueryParameter("form", true, true, "page", c.Request.URL.Query(), &params.Page)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter page: %s", err)})
-		return
-	}
-
-	// ------------- Optional query parameter "sport" -------------
-	if paramValue := c.Query("sport"); paramValue != "" {
+	// ------------- Optional query parameter "cursor" -------------
+	if paramValue := c.Query("cursor"); paramValue != "" {
 
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "sport", c.Request.URL.Query(), &params.Sport)
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sport: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter cursor: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "competition" -------------
-	if paramValue := c.Query("competition"); paramValue != "" {
+	// ------------- Optional query parameter "fields" -------------
+	if paramValue := c.Query("fields"); paramValue != "" {
 
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "competition", c.Request.URL.Query(), &params.Competition)
+	err = runtime.BindQueryParameter("form", true, false, "fields", c.Request.URL.Query(), &params.Fields)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter competition: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter fields: %s", err)})
 		return
 	}
 
-	// ------------- Optional query parameter "category" -------------
-	if paramValue := c.Query("category"); paramValue != "" {
+	// ------------- Optional query parameter "markets" -------------
+	if paramValue := c.Query("markets"); paramValue != "" {
 
 	}
 
-	err = runtime.BindQueryParameter("form", true, false, "category", c.Request.URL.Query(), &params.Category)
+	err = runtime.BindQueryParameter("form", true, false, "markets", c.Request.URL.Query(), &params.Markets)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter category: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter markets: %s", err)})
 		return
 	}
 
@@ -270,37 +1574,18 @@ func (siw *ServerInterfaceWrapper) ListEvents(c *gin.Context) {
 		middleware(c)
 	}
 
-	siw.Handler.ListEvents(c, params)
+	siw.Handler.ListTeamEvents(c, teamKey, params)
 }
 
-// GetEvent operation middleware
-func (siw *ServerInterfaceWrapper) GetEvent(c *gin.Context) {
+// ListValueBets operation middleware
+func (siw *ServerInterfaceWrapper) ListValueBets(c *gin.Context) {
 
 	var err error
 
-	// ------------- Path parameter "eventKey" -------------
-	var eventKey string
-
-	err = runtime.BindStyledParameter("simple", false, "eventKey", c.Param("eventKey"), &eventKey)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter eventKey: %s", err)})
-		return
-	}
-
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-	}
-
-	siw.Handler.GetEvent(c, eventKey)
-}
-
-// ListSports operation middleware
-func (siw *ServerInterfaceWrapper) ListSports(c *gin.Context) {
-
-	var err error
+	c.Set(ApiKeyScopes, []string{""})
 
 	// Parameter object where we will unmarshal all parameters from the context
-	var params ListSportsParams
+	var params ListValueBetsParams
 
 	// ------------- Required query parameter "first" -------------
 	if paramValue := c.Query("first"); paramValue != "" {
@@ -330,24 +1615,25 @@ func (siw *ServerInterfaceWrapper) ListSports(c *gin.Context) {
 		return
 	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-	}
+	// ------------- Optional query parameter "edge" -------------
+	if paramValue := c.Query("edge"); paramValue != "" {
 
-	siw.Handler.ListSports(c, params)
-}
+	}
 
-// GetSport operation middleware
-func (siw *ServerInterfaceWrapper) GetSport(c *gin.Context) {
+	err = runtime.BindQueryParameter("form", true, false, "edge", c.Request.URL.Query(), &params.Edge)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter edge: %s", err)})
+		return
+	}
 
-	var err error
+	// ------------- Optional query parameter "bankroll" -------------
+	if paramValue := c.Query("bankroll"); paramValue != "" {
 
-	// ------------- Path parameter "sportKey" -------------
-	var sportKey string
+	}
 
-	err = runtime.BindStyledParameter("simple", false, "sportKey", c.Param("sportKey"), &sportKey)
+	err = runtime.BindQueryParameter("form", true, false, "bankroll", c.Request.URL.Query(), &params.Bankroll)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter sportKey: %s", err)})
+		c.JSON(http.StatusBadRequest, gin.H{"msg": fmt.Sprintf("Invalid format for parameter bankroll: %s", err)})
 		return
 	}
 
@@ -355,7 +1641,19 @@ func (siw *ServerInterfaceWrapper) GetSport(c *gin.Context) {
 		middleware(c)
 	}
 
-	siw.Handler.GetSport(c, sportKey)
+	siw.Handler.ListValueBets(c, params)
+}
+
+// UploadModelProbabilities operation middleware
+func (siw *ServerInterfaceWrapper) UploadModelProbabilities(c *gin.Context) {
+
+	c.Set(ApiKeyScopes, []string{""})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+	}
+
+	siw.Handler.UploadModelProbabilities(c)
 }
 
 // GinServerOptions provides options for the Gin server.
@@ -376,21 +1674,89 @@ func RegisterHandlersWithOptions(router *gin.Engine, si ServerInterface, options
 		HandlerMiddlewares: options.Middlewares,
 	}
 
+	router.GET(options.BaseURL+"/admin/crawl", wrapper.GetCrawlState)
+
+	router.PATCH(options.BaseURL+"/admin/crawl", wrapper.UpdateCrawlSettings)
+
+	router.GET(options.BaseURL+"/admin/crawl-runs", wrapper.ListCrawlRuns)
+
+	router.GET(options.BaseURL+"/admin/crawl-runs/:runId", wrapper.GetCrawlRun)
+
+	router.POST(options.BaseURL+"/admin/crawl/pause", wrapper.PauseCrawl)
+
+	router.POST(options.BaseURL+"/admin/crawl/resume", wrapper.ResumeCrawl)
+
+	router.POST(options.BaseURL+"/admin/crawl/run", wrapper.TriggerCrawl)
+
+	router.GET(options.BaseURL+"/apikey", wrapper.ListApiKeys)
+
+	router.POST(options.BaseURL+"/apikey", wrapper.CreateApiKey)
+
+	router.DELETE(options.BaseURL+"/apikey/:keyId", wrapper.RevokeApiKey)
+
+	router.GET(options.BaseURL+"/apikey/:keyId", wrapper.GetApiKey)
+
+	router.GET(options.BaseURL+"/arbitrage", wrapper.ListArbitrage)
+
+	router.POST(options.BaseURL+"/calculator/dutching", wrapper.CalculateDutching)
+
+	router.POST(options.BaseURL+"/calculator/hedge", wrapper.CalculateHedge)
+
+	router.POST(options.BaseURL+"/calculator/kelly", wrapper.CalculateKelly)
+
+	router.POST(options.BaseURL+"/calculator/parlay", wrapper.CalculateParlay)
+
 	router.GET(options.BaseURL+"/category", wrapper.ListCategories)
 
 	router.GET(options.BaseURL+"/category/:categoryKey", wrapper.GetCategory)
 
+	router.GET(options.BaseURL+"/category/:categoryKey/competition", wrapper.ListCategoryCompetitions)
+
+	router.GET(options.BaseURL+"/changes", wrapper.ListChanges)
+
 	router.GET(options.BaseURL+"/competition", wrapper.ListCompetitions)
 
 	router.GET(options.BaseURL+"/competition/:competitionKey", wrapper.GetCompetition)
 
+	router.GET(options.BaseURL+"/competition/:competitionKey/event", wrapper.ListCompetitionEvents)
+
 	router.GET(options.BaseURL+"/event", wrapper.ListEvents)
 
+	router.POST(options.BaseURL+"/event/batchGet", wrapper.GetEvents)
+
 	router.GET(options.BaseURL+"/event/:eventKey", wrapper.GetEvent)
 
+	router.GET(options.BaseURL+"/event/:eventKey/ladder/:marketKey", wrapper.GetEventLadder)
+
+	router.GET(options.BaseURL+"/event/:eventKey/liquidity", wrapper.GetEventLiquidity)
+
+	router.GET(options.BaseURL+"/healthz", wrapper.GetHealth)
+
+	router.GET(options.BaseURL+"/liquidity", wrapper.RankLiquidity)
+
+	router.GET(options.BaseURL+"/metrics", wrapper.GetMetrics)
+
+	router.GET(options.BaseURL+"/readyz", wrapper.GetReadiness)
+
+	router.GET(options.BaseURL+"/replication", wrapper.GetReplicationStatus)
+
+	router.GET(options.BaseURL+"/search", wrapper.Search)
+
 	router.GET(options.BaseURL+"/sport", wrapper.ListSports)
 
 	router.GET(options.BaseURL+"/sport/:sportKey", wrapper.GetSport)
 
+	router.GET(options.BaseURL+"/sport/:sportKey/category", wrapper.ListSportCategories)
+
+	router.GET(options.BaseURL+"/team", wrapper.ListTeams)
+
+	router.GET(options.BaseURL+"/team/:teamKey", wrapper.GetTeam)
+
+	router.GET(options.BaseURL+"/team/:teamKey/events", wrapper.ListTeamEvents)
+
+	router.GET(options.BaseURL+"/value", wrapper.ListValueBets)
+
+	router.POST(options.BaseURL+"/value/probability", wrapper.UploadModelProbabilities)
+
 	return router
 }
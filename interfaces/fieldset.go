@@ -0,0 +1,188 @@
+package interfaces
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/awcjack/cloudbet/domain/failure"
+)
+
+var (
+	ErrInvalidFields        = failure.New(failure.Invalid, "invalid_fields", "fields must be a comma separated list of event properties")
+	ErrInvalidMarketPattern = failure.New(failure.Invalid, "invalid_market_pattern", "markets must be a comma separated list of market or market/submarket patterns")
+)
+
+// eventFields list every property of Event which can be requested with fields=, key and active are always returned
+var eventFields = map[string]bool{
+	"name":                 true,
+	"sport":                true,
+	"competition":          true,
+	"category":             true,
+	"home":                 true,
+	"away":                 true,
+	"live":                 true,
+	"margin":               true,
+	"market":               true,
+	"cutOffTime":           true,
+	"startTradingLiveTime": true,
+	"inactiveTime":         true,
+	"version":              true,
+	"lastUpdated":          true,
+}
+
+type marketPattern struct {
+	// path.Match pattern on the market key, e.g. soccer.*
+	market string
+	// path.Match pattern on the submarket key, empty for every submarket
+	submarket string
+}
+
+// eventView select which properties and markets of an Event are serialized, the zero value selects everything
+type eventView struct {
+	// requested properties, nil for all
+	fields map[string]bool
+	// requested markets, nil for all
+	markets []marketPattern
+}
+
+// newEventView parse the fields= and markets= query parameters
+func newEventView(fields *string, markets *string) (eventView, error) {
+	var view eventView
+	if fields != nil && *fields != "" {
+		view.fields = make(map[string]bool)
+		for _, field := range strings.Split(*fields, ",") {
+			field = strings.TrimSpace(field)
+			if field == "key" || field == "active" {
+				continue
+			}
+			if !eventFields[field] {
+				return eventView{}, fmt.Errorf("%w: unknown field %q", ErrInvalidFields, field)
+			}
+			view.fields[field] = true
+		}
+	}
+	if markets != nil && *markets != "" {
+		view.markets = make([]marketPattern, 0)
+		for _, v := range strings.Split(*markets, ",") {
+			var pattern marketPattern
+			pattern.market, pattern.submarket, _ = strings.Cut(strings.TrimSpace(v), "/")
+			// path.Match only reports malformed patterns when matching, so try both parts once here
+			if _, err := path.Match(pattern.market, ""); err != nil || pattern.market == "" {
+				return eventView{}, fmt.Errorf("%w: %q", ErrInvalidMarketPattern, v)
+			}
+			if _, err := path.Match(pattern.submarket, ""); err != nil {
+				return eventView{}, fmt.Errorf("%w: %q", ErrInvalidMarketPattern, v)
+			}
+			view.markets = append(view.markets, pattern)
+		}
+	}
+	return view, nil
+}
+
+// tag identify the selection of the view, the same whatever the order and repetitions of the query parameters,
+// empty for the zero value selecting everything
+func (v eventView) tag() string {
+	if v.fields == nil && v.markets == nil {
+		return ""
+	}
+	var selection strings.Builder
+	if v.fields != nil {
+		fields := make([]string, 0, len(v.fields))
+		for field := range v.fields {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		selection.WriteString("fields=" + strings.Join(fields, ","))
+	}
+	if v.markets != nil {
+		markets := make(map[string]bool)
+		for _, pattern := range v.markets {
+			markets[pattern.market+"/"+pattern.submarket] = true
+		}
+		patterns := make([]string, 0, len(markets))
+		for pattern := range markets {
+			patterns = append(patterns, pattern)
+		}
+		sort.Strings(patterns)
+		selection.WriteString(";markets=" + strings.Join(patterns, ","))
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(selection.String()))
+	return strconv.FormatUint(uint64(hash.Sum32()), 16)
+}
+
+func (v eventView) includes(field string) bool {
+	return v.fields == nil || v.fields[field]
+}
+
+func (v eventView) includesSubmarket(marketKey string, submarketKey string) bool {
+	if v.markets == nil {
+		return true
+	}
+	for _, pattern := range v.markets {
+		if ok, _ := path.Match(pattern.market, marketKey); !ok {
+			continue
+		}
+		if pattern.submarket == "" {
+			return true
+		}
+		if ok, _ := path.Match(pattern.submarket, submarketKey); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// apply clear the properties of e which were not requested
+func (v eventView) apply(e Event) Event {
+	if v.fields == nil {
+		return e
+	}
+	if !v.fields["name"] {
+		e.Name = nil
+	}
+	if !v.fields["sport"] {
+		e.Sport = nil
+	}
+	if !v.fields["competition"] {
+		e.Competition = nil
+	}
+	if !v.fields["category"] {
+		e.Category = nil
+	}
+	if !v.fields["home"] {
+		e.Home = nil
+	}
+	if !v.fields["away"] {
+		e.Away = nil
+	}
+	if !v.fields["live"] {
+		e.Live = nil
+	}
+	if !v.fields["margin"] {
+		e.Margin = nil
+	}
+	if !v.fields["market"] {
+		e.Market = nil
+	}
+	if !v.fields["cutOffTime"] {
+		e.CutOffTime = nil
+	}
+	if !v.fields["startTradingLiveTime"] {
+		e.StartTradingLiveTime = nil
+	}
+	if !v.fields["inactiveTime"] {
+		e.InactiveTime = nil
+	}
+	if !v.fields["version"] {
+		e.Version = nil
+	}
+	if !v.fields["lastUpdated"] {
+		e.LastUpdated = nil
+	}
+	return e
+}
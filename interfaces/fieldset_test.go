@@ -0,0 +1,116 @@
+package interfaces
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewEventView(t *testing.T) {
+	tests := []struct {
+		name    string
+		fields  string
+		markets string
+		// query parameters expected to select the same view
+		same    []string
+		wantErr error
+	}{
+		{name: "everything"},
+		{name: "fields in any order", fields: "name,live", same: []string{"live,name", " name , live,name", "key,name,active,live"}},
+		{name: "markets in any order", markets: "soccer.*,tennis.winner/period=ft", same: []string{"tennis.winner/period=ft,soccer.*,soccer.*"}},
+		{name: "unknown field", fields: "name,odds", wantErr: ErrInvalidFields},
+		{name: "malformed market pattern", markets: "soccer.[", wantErr: ErrInvalidMarketPattern},
+		{name: "malformed submarket pattern", markets: "soccer.*/period=[", wantErr: ErrInvalidMarketPattern},
+		{name: "empty market pattern", markets: "/period=ft", wantErr: ErrInvalidMarketPattern},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			view, err := newEventView(&tt.fields, &tt.markets)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("newEventView() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if (view.tag() == "") != (tt.fields == "" && tt.markets == "") {
+				t.Errorf("tag() = %q", view.tag())
+			}
+			for _, same := range tt.same {
+				fields, markets := tt.fields, tt.markets
+				if fields != "" {
+					fields = same
+				} else {
+					markets = same
+				}
+				other, err := newEventView(&fields, &markets)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if other.tag() != view.tag() {
+					t.Errorf("tag() of %q = %s, want %s", same, other.tag(), view.tag())
+				}
+			}
+		})
+	}
+
+	fields, other := "name", "live"
+	a, _ := newEventView(&fields, nil)
+	b, _ := newEventView(&other, nil)
+	c, _ := newEventView(nil, &fields)
+	if a.tag() == b.tag() || a.tag() == c.tag() {
+		t.Errorf("tag() = %s, %s, %s, want distinct views", a.tag(), b.tag(), c.tag())
+	}
+}
+
+func TestIncludesSubmarket(t *testing.T) {
+	markets := "soccer.*/period=ft,tennis.winner"
+	view, err := newEventView(nil, &markets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		marketKey    string
+		submarketKey string
+		want         bool
+	}{
+		{name: "wildcard market with submarket", marketKey: "soccer.match_odds", submarketKey: "period=ft", want: true},
+		{name: "other submarket", marketKey: "soccer.match_odds", submarketKey: "period=1h"},
+		{name: "every submarket", marketKey: "tennis.winner", submarketKey: "period=set1", want: true},
+		{name: "other market", marketKey: "tennis.total_games", submarketKey: "period=ft"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := view.includesSubmarket(tt.marketKey, tt.submarketKey); got != tt.want {
+				t.Errorf("includesSubmarket() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+	if !(eventView{}).includesSubmarket("soccer.match_odds", "period=ft") {
+		t.Errorf("includesSubmarket() = false for the zero view")
+	}
+}
+
+func TestEventViewApply(t *testing.T) {
+	name, live, margin, version := "Arsenal V Chelsea", true, 0.05, int64(42)
+	e := Event{Key: "arsenal-v-chelsea", Active: true, Name: &name, Live: &live, Margin: &margin, Version: &version}
+
+	if got := (eventView{}).apply(e); got.Name == nil || got.Live == nil || got.Margin == nil || got.Version == nil {
+		t.Errorf("apply() of the zero view = %+v, want every property", got)
+	}
+
+	fields := "name,margin"
+	view, err := newEventView(&fields, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := view.apply(e)
+	if got.Key != e.Key || !got.Active || got.Name == nil || got.Margin == nil {
+		t.Errorf("apply() = %+v, want key, active, name and margin", got)
+	}
+	if got.Live != nil || got.Version != nil {
+		t.Errorf("apply() = %+v, want live and version cleared", got)
+	}
+}
@@ -0,0 +1,35 @@
+package interfaces
+
+import (
+	"net/http"
+
+	"github.com/awcjack/cloudbet/domain/health"
+	"github.com/gin-gonic/gin"
+)
+
+func (h HttpServer) GetHealth(c *gin.Context) {
+	c.JSON(http.StatusOK, Health{
+		Status: health.StatusPass,
+	})
+}
+
+func (h HttpServer) GetReadiness(c *gin.Context) {
+	report := h.app.Query.GetReadiness.Handle(c.Request.Context())
+
+	checks := make([]HealthCheck, len(report.Checks()))
+	for i, check := range report.Checks() {
+		checks[i] = HealthCheck{
+			Name:    check.Name(),
+			Status:  HealthCheckStatus(check.Status()),
+			Message: check.Message(),
+		}
+	}
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, Readiness{
+		Status: ReadinessStatus(report.Status()),
+		Checks: checks,
+	})
+}
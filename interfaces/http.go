@@ -4,94 +4,103 @@
 package interfaces
 
 import (
-	"errors"
 	"math"
 	"net/http"
 	"time"
 
 	"github.com/awcjack/cloudbet/application"
+	"github.com/awcjack/cloudbet/domain/activity"
+	"github.com/awcjack/cloudbet/domain/category"
+	"github.com/awcjack/cloudbet/domain/competition"
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/awcjack/cloudbet/domain/pagination"
+	"github.com/awcjack/cloudbet/domain/sport"
+	"github.com/awcjack/cloudbet/domain/valuebet"
 	"github.com/gin-gonic/gin"
 )
 
-var (
-	ErrSizeTooLarge = errors.New("size cannot be smaller than 1 or larger than 50")
-	ErrPageTooSmall = errors.New("page cannot be smaller than 1")
-	ErrMissingKey   = errors.New("missing key")
-)
-
 type HttpServer struct {
-	app application.Application
+	app    application.Application
+	logger logger
 }
 
-func NewHttpServer(app application.Application) *HttpServer {
+func NewHttpServer(app application.Application, logger logger) *HttpServer {
 	return &HttpServer{
-		app: app,
+		app:    app,
+		logger: logger,
 	}
 }
 
 func (h HttpServer) ListSports(c *gin.Context, params ListSportsParams) {
-	if params.First <= 0 || params.First > 50 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrSizeTooLarge.Error()})
-		return
-	}
-	if params.Page <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrPageTooSmall.Error()})
+	request, err := toPageRequest(params.First, params.Cursor)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
-	repoData, err := h.app.Query.ListSports.Handle(c, int(params.First), int(params.Page))
+	repoData, info, err := h.app.Query.ListSports.Handle(c.Request.Context(), request)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
 	result := make([]Sport, len(repoData))
 	for i, sport := range repoData {
-		name := sport.Name()
-		liveTime := sport.LiveTime()
-		if math.IsNaN(liveTime) {
-			liveTime = 0
-		}
-		result[i] = Sport{
-			Key:      sport.Key(),
-			Name:     &name,
-			LiveTime: &liveTime,
-		}
-	}
-	c.JSON(http.StatusOK, result)
+		result[i] = toSport(sport)
+	}
+	nextCursor, prevCursor := toCursors(info)
+	c.JSON(http.StatusOK, SportList{
+		Items:      result,
+		TotalCount: info.TotalCount(),
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	})
 }
 
 func (h HttpServer) GetSport(c *gin.Context, sportKey string) {
 	if sportKey == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMissingKey.Error()})
+		writeError(c, ErrMissingKey)
 		return
 	}
 
-	sport, err := h.app.Query.GetSport.Handle(c, sportKey)
+	sport, err := h.app.Query.GetSport.Handle(c.Request.Context(), sportKey)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	name := sport.Name()
-	liveTime := sport.LiveTime()
-	if math.IsNaN(liveTime) {
-		liveTime = 0
-	}
-	c.JSON(http.StatusOK, Sport{
-		Key:      sport.Key(),
-		Name:     &name,
-		LiveTime: &liveTime,
-	})
+	c.JSON(http.StatusOK, toSport(sport))
 }
 
-func (h HttpServer) ListCategories(c *gin.Context, params ListCategoriesParams) {
-	if params.First <= 0 || params.First > 50 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrSizeTooLarge.Error()})
+func (h HttpServer) ListSportCategories(c *gin.Context, sportKey string, params ListSportCategoriesParams) {
+	if sportKey == "" {
+		writeError(c, ErrMissingKey)
 		return
 	}
-	if params.Page <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrPageTooSmall.Error()})
+	request, err := toPageRequest(params.First, params.Cursor)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	_, err = h.app.Query.GetSport.Handle(c.Request.Context(), sportKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	repoData, info, err := h.app.Query.ListCategories.Handle(c.Request.Context(), request, sportKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCategoryList(repoData, info))
+}
+
+func (h HttpServer) ListCategories(c *gin.Context, params ListCategoriesParams) {
+	request, err := toPageRequest(params.First, params.Cursor)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
@@ -99,257 +108,678 @@ func (h HttpServer) ListCategories(c *gin.Context, params ListCategoriesParams)
 	if params.Sport != nil {
 		sportKey = *params.Sport
 	}
-	repoData, err := h.app.Query.ListCategories.Handle(c, int(params.First), int(params.Page), sportKey)
+	repoData, info, err := h.app.Query.ListCategories.Handle(c.Request.Context(), request, sportKey)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	result := make([]Category, len(repoData))
-	for i, category := range repoData {
-		name := category.Name()
-		result[i] = Category{
-			Key:  category.Key(),
-			Name: &name,
-		}
-	}
-	c.JSON(http.StatusOK, result)
+	c.JSON(http.StatusOK, toCategoryList(repoData, info))
 }
 
 func (h HttpServer) GetCategory(c *gin.Context, categoryKey string) {
 	if categoryKey == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMissingKey.Error()})
+		writeError(c, ErrMissingKey)
 		return
 	}
 
-	category, err := h.app.Query.GetCategory.Handle(c, categoryKey)
+	category, err := h.app.Query.GetCategory.Handle(c.Request.Context(), categoryKey)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	name := category.Name()
-	c.JSON(http.StatusOK, Category{
-		Key:  category.Key(),
-		Name: &name,
-	})
+	c.JSON(http.StatusOK, toCategory(category))
 }
 
-func (h HttpServer) ListCompetitions(c *gin.Context, params ListCompetitionsParams) {
-	if params.First <= 0 || params.First > 50 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrSizeTooLarge.Error()})
+func (h HttpServer) ListCategoryCompetitions(c *gin.Context, categoryKey string, params ListCategoryCompetitionsParams) {
+	if categoryKey == "" {
+		writeError(c, ErrMissingKey)
 		return
 	}
-	if params.Page <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrPageTooSmall.Error()})
+	request, err := toPageRequest(params.First, params.Cursor)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
-	sportKey := ""
+	_, err = h.app.Query.GetCategory.Handle(c.Request.Context(), categoryKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	filter := competition.Filter{CategoryKey: categoryKey}
 	if params.Sport != nil {
-		sportKey = *params.Sport
+		filter.SportKey = *params.Sport
 	}
-	repoData, err := h.app.Query.ListCompetitions.Handle(c, int(params.First), int(params.Page), sportKey)
+	repoData, info, err := h.app.Query.ListCompetitions.Handle(c.Request.Context(), request, filter)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	result := make([]Competition, len(repoData))
-	for i, competition := range repoData {
-		name := competition.Name()
-		result[i] = Competition{
-			Key:  competition.Key(),
-			Name: &name,
-		}
+	c.JSON(http.StatusOK, toCompetitionList(repoData, info))
+}
+
+func (h HttpServer) ListCompetitions(c *gin.Context, params ListCompetitionsParams) {
+	request, err := toPageRequest(params.First, params.Cursor)
+	if err != nil {
+		writeError(c, err)
+		return
 	}
-	c.JSON(http.StatusOK, result)
+
+	var filter competition.Filter
+	if params.Sport != nil {
+		filter.SportKey = *params.Sport
+	}
+	if params.Category != nil {
+		filter.CategoryKey = *params.Category
+	}
+	repoData, info, err := h.app.Query.ListCompetitions.Handle(c.Request.Context(), request, filter)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, toCompetitionList(repoData, info))
 }
 
 func (h HttpServer) GetCompetition(c *gin.Context, competitionKey string) {
 	if competitionKey == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMissingKey.Error()})
+		writeError(c, ErrMissingKey)
 		return
 	}
 
-	competition, err := h.app.Query.GetCompetition.Handle(c, competitionKey)
+	competition, err := h.app.Query.GetCompetition.Handle(c.Request.Context(), competitionKey)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
 
-	name := competition.Name()
-	c.JSON(http.StatusOK, Competition{
-		Key:  competition.Key(),
-		Name: &name,
-	})
+	c.JSON(http.StatusOK, toCompetition(competition))
+}
+
+func (h HttpServer) ListCompetitionEvents(c *gin.Context, competitionKey string, params ListCompetitionEventsParams) {
+	if competitionKey == "" {
+		writeError(c, ErrMissingKey)
+		return
+	}
+	request, err := toPageRequest(params.First, params.Cursor)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	view, err := newEventView(params.Fields, params.Markets)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	_, err = h.app.Query.GetCompetition.Handle(c.Request.Context(), competitionKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	order, _ := event.NewOrder("", false)
+	repoData, info, err := h.app.Query.ListEvents.Handle(c.Request.Context(), request, event.Filter{CompetitionKey: competitionKey}, order)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	h.setCacheControl(c)
+	c.JSON(http.StatusOK, toEventList(repoData, info, view))
 }
 
 func (h HttpServer) ListEvents(c *gin.Context, params ListEventsParams) {
-	if params.First <= 0 || params.First > 50 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrSizeTooLarge.Error()})
+	request, err := toPageRequest(params.First, params.Cursor)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
-	if params.Page <= 0 {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrPageTooSmall.Error()})
+	view, err := newEventView(params.Fields, params.Markets)
+	if err != nil {
+		writeError(c, err)
 		return
 	}
 
-	sportKey := ""
+	var filter event.Filter
 	if params.Sport != nil {
-		sportKey = *params.Sport
+		filter.SportKey = *params.Sport
 	}
-	categoryKey := ""
 	if params.Category != nil {
-		categoryKey = *params.Category
+		filter.CategoryKey = *params.Category
 	}
-	competitionKey := ""
 	if params.Competition != nil {
-		competitionKey = *params.Competition
-	}
-	repoData, err := h.app.Query.ListEvents.Handle(c, int(params.First), int(params.Page), sportKey, categoryKey, competitionKey)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	result := make([]Event, len(repoData))
-	for i, event := range repoData {
-		name := event.Name()
-		sportKey := event.Sport().Key()
-		sportName := event.Sport().Name()
-		categoryKey := event.Category().Key()
-		categoryName := event.Category().Name()
-		competitionKey := event.Competition().Key()
-		competitionName := event.Competition().Name()
-		homeName := event.Home().Name()
-		homeAbbreviation := event.Home().Abbreviation()
-		homeNationality := event.Home().Nationality()
-		awayName := event.Away().Name()
-		awayAbbreviation := event.Away().Abbreviation()
-		awayNationality := event.Away().Nationality()
-		var market Event_Market
-		market.AdditionalProperties = make(map[string]Market)
-		for k, v := range event.Market() {
-			marketProperty := Market{}
-			subMarket := make(map[string][]Selection, len(v.Submarkets()))
-			for subMarketKey, subMarketVal := range v.Submarkets() {
-				subMarket[subMarketKey] = make([]Selection, len(subMarketVal))
-				for i, selectionVal := range subMarketVal {
-					outcome := selectionVal.Outcome()
-					params := selectionVal.Params()
-					price := selectionVal.Price()
-					maxStake := selectionVal.MaxStake()
-					probability := selectionVal.Probability()
-					status := selectionVal.Status()
-					var statusEnum SelectionStatus
-					if status == "SELECTION_DISABLED" {
-						statusEnum = SELECTIONDISABLED
-					} else {
-						statusEnum = SELECTIONENABLED
-					}
-					side := selectionVal.Side()
-					var sideEnum SelectionSide
-					if side == "BACK" {
-						sideEnum = BACK
-					} else {
-						sideEnum = LAY
-					}
-
-					subMarket[subMarketKey][i] = Selection{
-						Outcome:     &outcome,
-						Params:      &params,
-						Price:       &price,
-						MaxStake:    &maxStake,
-						Probability: &probability,
-						Status:      &statusEnum,
-						Side:        &sideEnum,
-					}
-				}
+		filter.CompetitionKey = *params.Competition
+	}
+	if params.Team != nil {
+		filter.TeamKey = *params.Team
+	}
+	if params.Nationality != nil {
+		filter.Nationality = *params.Nationality
+	}
+	if params.CutoffFrom != nil {
+		filter.CutOffFrom = *params.CutoffFrom
+	}
+	if params.CutoffTo != nil {
+		filter.CutOffTo = *params.CutoffTo
+	}
+	if params.Active != nil {
+		filter.ActiveOnly = *params.Active
+	}
+	if params.Live != nil {
+		filter.LiveOnly = *params.Live
+	}
+	sortBy := ""
+	if params.Sort != nil {
+		sortBy = string(*params.Sort)
+	}
+	order, err := event.NewOrder(sortBy, params.Order != nil && *params.Order == "desc")
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	repoData, info, err := h.app.Query.ListEvents.Handle(c.Request.Context(), request, filter, order)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	h.setCacheControl(c)
+	c.JSON(http.StatusOK, toEventList(repoData, info, view))
+}
+
+func (h HttpServer) GetEvent(c *gin.Context, eventKey string, params GetEventParams) {
+	if eventKey == "" {
+		writeError(c, ErrMissingKey)
+		return
+	}
+	view, err := newEventView(params.Fields, params.Markets)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	event, err := h.app.Query.GetEvent.Handle(c.Request.Context(), eventKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	h.setCacheControl(c)
+	if notModified(c, event.Epoch(), event.Version(), event.LastUpdated(), view.tag()) {
+		return
+	}
+	c.JSON(http.StatusOK, toEvent(event, view))
+}
+
+func (h HttpServer) GetEvents(c *gin.Context, params GetEventsParams) {
+	var body EventBatchRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, invalidBody(err))
+		return
+	}
+	view, err := newEventView(params.Fields, params.Markets)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	events, missing, err := h.app.Query.GetEvents.Handle(c.Request.Context(), body.Keys)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := make([]Event, len(events))
+	for i, event := range events {
+		result[i] = toEvent(event, view)
+	}
+	c.JSON(http.StatusOK, EventBatch{
+		Events:  result,
+		Missing: missing,
+	})
+}
+
+func (h HttpServer) ListTeams(c *gin.Context, params ListTeamsParams) {
+	request, err := toPageRequest(params.First, params.Cursor)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	nationality := ""
+	if params.Nationality != nil {
+		nationality = *params.Nationality
+	}
+	repoData, info, err := h.app.Query.ListTeams.Handle(c.Request.Context(), request, nationality)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := make([]Team, len(repoData))
+	for i, team := range repoData {
+		name := team.Name()
+		abbreviation := team.Abbreviation()
+		nationality := team.Nationality()
+		result[i] = Team{
+			Key:          team.Key(),
+			Name:         &name,
+			Abbreviation: &abbreviation,
+			Nationality:  &nationality,
+		}
+	}
+	nextCursor, prevCursor := toCursors(info)
+	c.JSON(http.StatusOK, TeamList{
+		Items:      result,
+		TotalCount: info.TotalCount(),
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	})
+}
+
+func (h HttpServer) GetTeam(c *gin.Context, teamKey string) {
+	if teamKey == "" {
+		writeError(c, ErrMissingKey)
+		return
+	}
+
+	team, err := h.app.Query.GetTeam.Handle(c.Request.Context(), teamKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	name := team.Name()
+	abbreviation := team.Abbreviation()
+	nationality := team.Nationality()
+	c.JSON(http.StatusOK, Team{
+		Key:          team.Key(),
+		Name:         &name,
+		Abbreviation: &abbreviation,
+		Nationality:  &nationality,
+	})
+}
+
+func (h HttpServer) ListTeamEvents(c *gin.Context, teamKey string, params ListTeamEventsParams) {
+	if teamKey == "" {
+		writeError(c, ErrMissingKey)
+		return
+	}
+	request, err := toPageRequest(params.First, params.Cursor)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	view, err := newEventView(params.Fields, params.Markets)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	_, err = h.app.Query.GetTeam.Handle(c.Request.Context(), teamKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+	order, _ := event.NewOrder("", false)
+	repoData, info, err := h.app.Query.ListEvents.Handle(c.Request.Context(), request, event.Filter{TeamKey: teamKey}, order)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	h.setCacheControl(c)
+	c.JSON(http.StatusOK, toEventList(repoData, info, view))
+}
+
+func (h HttpServer) GetEventLadder(c *gin.Context, eventKey string, marketKey string, params GetEventLadderParams) {
+	if eventKey == "" || marketKey == "" {
+		writeError(c, ErrMissingKey)
+		return
+	}
+
+	submarketKey := ""
+	if params.Submarket != nil {
+		submarketKey = *params.Submarket
+	}
+	ladders, err := h.app.Query.GetEventLadder.Handle(c.Request.Context(), eventKey, marketKey, submarketKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := make([]Ladder, len(ladders))
+	for i, ladder := range ladders {
+		mainLine, hasMainLine := ladder.MainLine()
+		lines := make([]Line, len(ladder.Lines()))
+		for j, line := range ladder.Lines() {
+			selections := make([]Selection, len(line.Selections()))
+			for k, selectionVal := range line.Selections() {
+				selections[k] = toSelection(selectionVal)
 			}
-			marketProperty.Submarkets = &Market_Submarkets{
-				AdditionalProperties: subMarket,
+			lines[j] = Line{
+				Line:       line.Value(),
+				Params:     line.Params(),
+				Margin:     line.Margin(),
+				Main:       hasMainLine && line.Params() == mainLine.Params(),
+				Selections: &selections,
 			}
-
-			market.AdditionalProperties[k] = marketProperty
 		}
-		var cutOffTime string
-		if event.CutOffTime().IsZero() {
-			cutOffTime = ""
-		} else {
-			cutOffTime = event.CutOffTime().Format(time.RFC3339)
+		result[i] = Ladder{
+			Submarket: ladder.Submarket(),
+			Lines:     lines,
+		}
+		if hasMainLine {
+			value := mainLine.Value()
+			result[i].MainLine = &value
 		}
-		var startTradingLiveTime string
-		if event.StartTradingLiveTime().IsZero() {
-			startTradingLiveTime = ""
-		} else {
-			startTradingLiveTime = event.StartTradingLiveTime().Format(time.RFC3339)
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h HttpServer) ListArbitrage(c *gin.Context, params ListArbitrageParams) {
+	if params.First <= 0 || params.First > 50 {
+		writeError(c, ErrSizeTooLarge)
+		return
+	}
+	if params.Page <= 0 {
+		writeError(c, ErrPageTooSmall)
+		return
+	}
+
+	repoData, err := h.app.Query.ListArbitrage.Handle(c.Request.Context(), int(params.First), int(params.Page))
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := make([]Arbitrage, len(repoData))
+	for i, opportunity := range repoData {
+		eventName := opportunity.EventName()
+		legs := make([]ArbitrageLeg, len(opportunity.Legs()))
+		for j, leg := range opportunity.Legs() {
+			legs[j] = ArbitrageLeg{
+				Outcome:  leg.Outcome(),
+				Params:   leg.Params(),
+				Side:     leg.Side(),
+				Price:    leg.Price(),
+				Stake:    leg.Stake(),
+				MaxStake: leg.MaxStake(),
+			}
 		}
-		var inactiveTime string
-		if event.InactiveTime().IsZero() {
-			inactiveTime = ""
-		} else {
-			inactiveTime = event.InactiveTime().Format(time.RFC3339)
+		result[i] = Arbitrage{
+			EventKey:           opportunity.EventKey(),
+			EventName:          &eventName,
+			MarketKey:          opportunity.MarketKey(),
+			SubmarketKey:       opportunity.SubmarketKey(),
+			Kind:               ArbitrageKind(opportunity.Kind()),
+			Legs:               legs,
+			ImpliedProbability: opportunity.ImpliedProbability(),
+			TotalStake:         opportunity.TotalStake(),
+			Profit:             opportunity.Profit(),
+			ProfitPercentage:   opportunity.ProfitPercentage(),
+			DetectedAt:         opportunity.DetectedAt().Format(time.RFC3339),
 		}
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func (h HttpServer) ListValueBets(c *gin.Context, params ListValueBetsParams) {
+	if params.First <= 0 || params.First > 50 {
+		writeError(c, ErrSizeTooLarge)
+		return
+	}
+	if params.Page <= 0 {
+		writeError(c, ErrPageTooSmall)
+		return
+	}
 
-		result[i] = Event{
-			Sport: &struct {
-				Key  *string `json:"key,omitempty"`
-				Name *string `json:"name,omitempty"`
-			}{
-				Key:  &sportKey,
-				Name: &sportName,
-			},
-			Category: &struct {
-				Key  *string `json:"key,omitempty"`
-				Name *string `json:"name,omitempty"`
-			}{
-				Key:  &categoryKey,
-				Name: &categoryName,
-			},
-			Competition: &struct {
-				Key  *string `json:"key,omitempty"`
-				Name *string `json:"name,omitempty"`
-			}{
-				Key:  &competitionKey,
-				Name: &competitionName,
-			},
-			Home: &Team{
-				Key:          event.Home().Key(),
-				Name:         &homeName,
-				Abbreviation: &homeAbbreviation,
-				Nationality:  &homeNationality,
-			},
-			Away: &Team{
-				Key:          event.Home().Key(),
-				Name:         &awayName,
-				Abbreviation: &awayAbbreviation,
-				Nationality:  &awayNationality,
-			},
-			Active:               event.Active(),
-			Market:               &market,
-			Name:                 &name,
-			Key:                  event.Key(),
-			CutOffTime:           &cutOffTime,
-			StartTradingLiveTime: &startTradingLiveTime,
-			InactiveTime:         &inactiveTime,
+	var edge float64
+	if params.Edge != nil {
+		edge = *params.Edge
+	}
+	var bankroll float64
+	if params.Bankroll != nil {
+		bankroll = *params.Bankroll
+	}
+	repoData, err := h.app.Query.ListValueBets.Handle(c.Request.Context(), int(params.First), int(params.Page), edge, bankroll)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := make([]ValueBet, len(repoData))
+	for i, valueBet := range repoData {
+		eventName := valueBet.EventName()
+		cutOffTime := valueBet.CutoffTime().Format(time.RFC3339)
+		result[i] = ValueBet{
+			EventKey:         valueBet.EventKey(),
+			EventName:        &eventName,
+			CutOffTime:       &cutOffTime,
+			Market:           valueBet.MarketKey(),
+			Submarket:        valueBet.SubmarketKey(),
+			Outcome:          valueBet.Outcome(),
+			Params:           valueBet.Params(),
+			Price:            valueBet.Price(),
+			ModelProbability: valueBet.ModelProbability(),
+			Edge:             valueBet.Edge(),
+			KellyFraction:    valueBet.KellyFraction(),
+			Stake:            valueBet.Stake(),
+			MaxStake:         valueBet.MaxStake(),
 		}
 	}
 	c.JSON(http.StatusOK, result)
 }
 
-func (h HttpServer) GetEvent(c *gin.Context, eventKey string) {
-	if eventKey == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMissingKey.Error()})
+func (h HttpServer) UploadModelProbabilities(c *gin.Context) {
+	var body UploadModelProbabilitiesJSONRequestBody
+	if err := c.ShouldBindJSON(&body); err != nil {
+		writeError(c, invalidBody(err))
 		return
 	}
 
-	event, err := h.app.Query.GetEvent.Handle(c, eventKey)
+	probabilities := make([]valuebet.Probability, len(body))
+	for i, v := range body {
+		params := ""
+		if v.Params != nil {
+			params = *v.Params
+		}
+		probability, err := valuebet.NewProbability(v.EventKey, v.Market, v.Outcome, params, v.Probability)
+		if err != nil {
+			writeError(c, err)
+			return
+		}
+		probabilities[i] = probability
+	}
+
+	err := h.app.Command.UploadModelProbabilities.Handle(c.Request.Context(), probabilities)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		writeError(c, err)
 		return
 	}
+	c.Status(http.StatusNoContent)
+}
+
+func toSport(sport sport.Sport) Sport {
+	name := sport.Name()
+	liveTime := sport.LiveTime()
+	if math.IsNaN(liveTime) {
+		liveTime = 0
+	}
+	categoryCount := sport.CategoryCount()
+	competitionCount := sport.CompetitionCount()
+	activity := toActivityFields(sport.Activity())
+	return Sport{
+		Key:              sport.Key(),
+		Name:             &name,
+		LiveTime:         &liveTime,
+		CategoryCount:    &categoryCount,
+		CompetitionCount: &competitionCount,
+		EventCount:       activity.eventCount,
+		ActiveEventCount: activity.activeEventCount,
+		LiveEventCount:   activity.liveEventCount,
+		NextCutOffTime:   activity.nextCutOffTime,
+		LastUpdated:      activity.lastUpdated,
+	}
+}
+
+func toCategoryList(categories []category.Category, info pagination.Info) CategoryList {
+	result := make([]Category, len(categories))
+	for i, category := range categories {
+		result[i] = toCategory(category)
+	}
+	nextCursor, prevCursor := toCursors(info)
+	return CategoryList{
+		Items:      result,
+		TotalCount: info.TotalCount(),
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	}
+}
+
+func toCategory(category category.Category) Category {
+	name := category.Name()
+	sports := category.SportKeys()
+	competitionCount := category.CompetitionCount()
+	activity := toActivityFields(category.Activity())
+	return Category{
+		Key:              category.Key(),
+		Name:             &name,
+		Sports:           &sports,
+		CompetitionCount: &competitionCount,
+		EventCount:       activity.eventCount,
+		ActiveEventCount: activity.activeEventCount,
+		LiveEventCount:   activity.liveEventCount,
+		NextCutOffTime:   activity.nextCutOffTime,
+		LastUpdated:      activity.lastUpdated,
+	}
+}
+
+func toCompetitionList(competitions []competition.Competition, info pagination.Info) CompetitionList {
+	result := make([]Competition, len(competitions))
+	for i, competition := range competitions {
+		result[i] = toCompetition(competition)
+	}
+	nextCursor, prevCursor := toCursors(info)
+	return CompetitionList{
+		Items:      result,
+		TotalCount: info.TotalCount(),
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	}
+}
+
+func toCompetition(competition competition.Competition) Competition {
+	name := competition.Name()
+	sportKey := competition.SportKey()
+	categoryKey := competition.CategoryKey()
+	activity := toActivityFields(competition.Activity())
+	return Competition{
+		Key:              competition.Key(),
+		Name:             &name,
+		Sport:            &sportKey,
+		Category:         &categoryKey,
+		EventCount:       activity.eventCount,
+		ActiveEventCount: activity.activeEventCount,
+		LiveEventCount:   activity.liveEventCount,
+		NextCutOffTime:   activity.nextCutOffTime,
+		LastUpdated:      activity.lastUpdated,
+	}
+}
+
+// activityFields hold the response properties shared by sports, categories and competitions
+type activityFields struct {
+	eventCount       *int
+	activeEventCount *int
+	liveEventCount   *int
+	nextCutOffTime   *string
+	lastUpdated      *string
+}
+
+func toActivityFields(activity activity.Activity) activityFields {
+	eventCount := activity.EventCount()
+	activeEventCount := activity.ActiveEventCount()
+	liveEventCount := activity.LiveEventCount()
+	fields := activityFields{
+		eventCount:       &eventCount,
+		activeEventCount: &activeEventCount,
+		liveEventCount:   &liveEventCount,
+	}
+	if !activity.NextCutOffTime().IsZero() {
+		nextCutOffTime := activity.NextCutOffTime().Format(time.RFC3339)
+		fields.nextCutOffTime = &nextCutOffTime
+	}
+	if !activity.LastUpdated().IsZero() {
+		lastUpdated := activity.LastUpdated().Format(time.RFC3339)
+		fields.lastUpdated = &lastUpdated
+	}
+	return fields
+}
+
+func toTeam(team event.TeamIdentifier) *Team {
+	if team.IsZero() {
+		return nil
+	}
+
+	name := team.Name()
+	abbreviation := team.Abbreviation()
+	nationality := team.Nationality()
+	return &Team{
+		Key:          team.Key(),
+		Name:         &name,
+		Abbreviation: &abbreviation,
+		Nationality:  &nationality,
+	}
+}
+
+// toPageRequest validate the page size and decode the cursor of a cursor paginated listing
+func toPageRequest(first First, cursor *Cursor) (pagination.Request, error) {
+	if first <= 0 || first > 50 {
+		return pagination.Request{}, ErrSizeTooLarge
+	}
+	var decoded *pagination.Cursor
+	if cursor != nil {
+		var err error
+		decoded, err = pagination.DecodeCursor(*cursor)
+		if err != nil {
+			return pagination.Request{}, err
+		}
+	}
+	return pagination.NewRequest(int(first), decoded), nil
+}
+
+// toCursors return the cursors of info, nil when there is no such page
+func toCursors(info pagination.Info) (*string, *string) {
+	var nextCursor, prevCursor *string
+	if info.NextCursor() != "" {
+		v := info.NextCursor()
+		nextCursor = &v
+	}
+	if info.PrevCursor() != "" {
+		v := info.PrevCursor()
+		prevCursor = &v
+	}
+	return nextCursor, prevCursor
+}
 
+func toEventList(events []event.Event, info pagination.Info, view eventView) EventList {
+	result := make([]Event, len(events))
+	for i, event := range events {
+		result[i] = toEvent(event, view)
+	}
+	nextCursor, prevCursor := toCursors(info)
+	return EventList{
+		Items:      result,
+		TotalCount: info.TotalCount(),
+		NextCursor: nextCursor,
+		PrevCursor: prevCursor,
+	}
+}
+
+func toEvent(event event.Event, view eventView) Event {
 	name := event.Name()
 	sportKey := event.Sport().Key()
 	sportName := event.Sport().Name()
@@ -357,51 +787,26 @@ func (h HttpServer) GetEvent(c *gin.Context, eventKey string) {
 	categoryName := event.Category().Name()
 	competitionKey := event.Competition().Key()
 	competitionName := event.Competition().Name()
-	homeName := event.Home().Name()
-	homeAbbreviation := event.Home().Abbreviation()
-	homeNationality := event.Home().Nationality()
-	awayName := event.Away().Name()
-	awayAbbreviation := event.Away().Abbreviation()
-	awayNationality := event.Away().Nationality()
 	var market Event_Market
 	market.AdditionalProperties = make(map[string]Market)
 	for k, v := range event.Market() {
+		if !view.includes("market") {
+			break
+		}
 		marketProperty := Market{}
 		subMarket := make(map[string][]Selection, len(v.Submarkets()))
 		for subMarketKey, subMarketVal := range v.Submarkets() {
+			if !view.includesSubmarket(k, subMarketKey) {
+				continue
+			}
 			subMarket[subMarketKey] = make([]Selection, len(subMarketVal))
 			for i, selectionVal := range subMarketVal {
-				outcome := selectionVal.Outcome()
-				params := selectionVal.Params()
-				price := selectionVal.Price()
-				maxStake := selectionVal.MaxStake()
-				probability := selectionVal.Probability()
-				status := selectionVal.Status()
-				var statusEnum SelectionStatus
-				if status == "SELECTION_DISABLED" {
-					statusEnum = SELECTIONDISABLED
-				} else {
-					statusEnum = SELECTIONENABLED
-				}
-				side := selectionVal.Side()
-				var sideEnum SelectionSide
-				if side == "BACK" {
-					sideEnum = BACK
-				} else {
-					sideEnum = LAY
-				}
-
-				subMarket[subMarketKey][i] = Selection{
-					Outcome:     &outcome,
-					Params:      &params,
-					Price:       &price,
-					MaxStake:    &maxStake,
-					Probability: &probability,
-					Status:      &statusEnum,
-					Side:        &sideEnum,
-				}
+				subMarket[subMarketKey][i] = toSelection(selectionVal)
 			}
 		}
+		if len(subMarket) == 0 {
+			continue
+		}
 		marketProperty.Submarkets = &Market_Submarkets{
 			AdditionalProperties: subMarket,
 		}
@@ -426,7 +831,15 @@ func (h HttpServer) GetEvent(c *gin.Context, eventKey string) {
 	} else {
 		inactiveTime = event.InactiveTime().Format(time.RFC3339)
 	}
-	c.JSON(http.StatusOK, Event{
+	live := event.Live()
+	version := int64(event.Version())
+	lastUpdated := event.LastUpdated().Format(time.RFC3339)
+	var margin *float64
+	if v, ok := event.Margin(); ok {
+		margin = &v
+	}
+
+	return view.apply(Event{
 		Sport: &struct {
 			Key  *string `json:"key,omitempty"`
 			Name *string `json:"name,omitempty"`
@@ -448,30 +861,54 @@ func (h HttpServer) GetEvent(c *gin.Context, eventKey string) {
 			Key:  &competitionKey,
 			Name: &competitionName,
 		},
-		Home: &Team{
-			Key:          event.Home().Key(),
-			Name:         &homeName,
-			Abbreviation: &homeAbbreviation,
-			Nationality:  &homeNationality,
-		},
-		Away: &Team{
-			Key:          event.Home().Key(),
-			Name:         &awayName,
-			Abbreviation: &awayAbbreviation,
-			Nationality:  &awayNationality,
-		},
+		Home:                 toTeam(event.Home()),
+		Away:                 toTeam(event.Away()),
 		Active:               event.Active(),
+		Live:                 &live,
+		Margin:               margin,
 		Market:               &market,
 		Name:                 &name,
 		Key:                  event.Key(),
 		CutOffTime:           &cutOffTime,
 		StartTradingLiveTime: &startTradingLiveTime,
 		InactiveTime:         &inactiveTime,
+		Version:              &version,
+		LastUpdated:          &lastUpdated,
 	})
 }
 
+// toSelection keep the status and side as crawled, the change feed carries them to replicas
+func toSelection(selectionVal event.Selection) Selection {
+	outcome := selectionVal.Outcome()
+	params := selectionVal.Params()
+	price := selectionVal.Price()
+	maxStake := selectionVal.MaxStake()
+	probability := selectionVal.Probability()
+	var status *SelectionStatus
+	if v := selectionVal.Status(); v != "" {
+		statusEnum := SelectionStatus(v)
+		status = &statusEnum
+	}
+	var side *SelectionSide
+	if v := selectionVal.Side(); v != "" {
+		sideEnum := SelectionSide(v)
+		side = &sideEnum
+	}
+
+	return Selection{
+		Outcome:     &outcome,
+		Params:      &params,
+		Price:       &price,
+		MaxStake:    &maxStake,
+		Probability: &probability,
+		Status:      status,
+		Side:        side,
+	}
+}
+
 func NewHandler(httpServer HttpServer) *gin.Engine {
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.Recovery(), tracingMiddleware, requestIDMiddleware, httpServer.accessLog, metricsMiddleware, problemMiddleware, httpServer.authenticate)
 
 	RegisterHandlers(router, httpServer)
 
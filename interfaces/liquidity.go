@@ -0,0 +1,92 @@
+package interfaces
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/awcjack/cloudbet/domain/event"
+	"github.com/gin-gonic/gin"
+)
+
+func (h HttpServer) GetEventLiquidity(c *gin.Context, eventKey string) {
+	if eventKey == "" {
+		writeError(c, ErrMissingKey)
+		return
+	}
+
+	liquidity, err := h.app.Query.GetEventLiquidity.Handle(c.Request.Context(), eventKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	submarkets := make([]SubmarketLiquidity, len(liquidity.Submarkets()))
+	for i, submarket := range liquidity.Submarkets() {
+		selections := make([]SelectionLiquidity, len(submarket.Selections()))
+		for j, selection := range submarket.Selections() {
+			selections[j] = toSelectionLiquidity(selection)
+		}
+		submarkets[i] = SubmarketLiquidity{
+			Market:        submarket.MarketKey(),
+			Submarket:     submarket.SubmarketKey(),
+			TotalMaxStake: submarket.TotalMaxStake(),
+			MaxLiability:  submarket.MaxLiability(),
+			Binding:       toSelectionLiquidity(submarket.Binding()),
+			Selections:    selections,
+		}
+	}
+	c.JSON(http.StatusOK, EventLiquidity{
+		EventKey:       liquidity.EventKey(),
+		TotalMaxStake:  liquidity.TotalMaxStake(),
+		TotalLiability: liquidity.TotalLiability(),
+		Submarkets:     submarkets,
+	})
+}
+
+func (h HttpServer) RankLiquidity(c *gin.Context, params RankLiquidityParams) {
+	if params.First <= 0 || params.First > 50 {
+		writeError(c, ErrSizeTooLarge)
+		return
+	}
+	if params.Page <= 0 {
+		writeError(c, ErrPageTooSmall)
+		return
+	}
+
+	sportKey := ""
+	if params.Sport != nil {
+		sportKey = *params.Sport
+	}
+	ranked, err := h.app.Query.RankLiquidity.Handle(c.Request.Context(), int(params.First), int(params.Page), sportKey)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := make([]LiquidityRank, len(ranked))
+	for i, v := range ranked {
+		name := v.Event.Name()
+		cutOffTime := v.Event.CutOffTime().Format(time.RFC3339)
+		result[i] = LiquidityRank{
+			EventKey:       v.Event.Key(),
+			EventName:      &name,
+			CutOffTime:     &cutOffTime,
+			TotalMaxStake:  v.Liquidity.TotalMaxStake(),
+			TotalLiability: v.Liquidity.TotalLiability(),
+			SubmarketCount: len(v.Liquidity.Submarkets()),
+		}
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func toSelectionLiquidity(selection event.SelectionLiquidity) SelectionLiquidity {
+	side := selection.Selection().Side()
+	return SelectionLiquidity{
+		Outcome:   selection.Selection().Outcome(),
+		Params:    selection.Selection().Params(),
+		Side:      &side,
+		Price:     selection.Selection().Price(),
+		MaxStake:  selection.Selection().MaxStake(),
+		Liability: selection.Liability(),
+	}
+}
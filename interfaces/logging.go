@@ -0,0 +1,69 @@
+package interfaces
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"regexp"
+	"time"
+
+	"github.com/awcjack/cloudbet/application/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const requestIDHeader = "X-Request-ID"
+
+// request IDs sent by callers are kept if they are safe to log as is
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+type logger interface {
+	logrus.FieldLogger
+}
+
+// requestIDMiddleware give every request a correlation ID, the one sent by the caller or a new one, echoed in the
+// response and added to everything logged while serving the request
+func requestIDMiddleware(c *gin.Context) {
+	requestID := c.GetHeader(requestIDHeader)
+	if !requestIDPattern.MatchString(requestID) {
+		requestID = newRequestID()
+	}
+	c.Header(requestIDHeader, requestID)
+	trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("http.request_id", requestID))
+	c.Request = c.Request.WithContext(logging.WithField(c.Request.Context(), logging.RequestIDField, requestID))
+	c.Next()
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		// still unique enough to correlate the lines of a request
+		return time.Now().UTC().Format("20060102T150405.000000000")
+	}
+	return hex.EncodeToString(id)
+}
+
+// accessLog log every request once served, with the errors its handlers recorded
+func (h HttpServer) accessLog(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	status := c.Writer.Status()
+	log := logging.FromContext(c.Request.Context(), h.logger).WithFields(logrus.Fields{
+		"method":    c.Request.Method,
+		"path":      c.Request.URL.Path,
+		"route":     c.FullPath(),
+		"status":    status,
+		"duration":  time.Since(start).String(),
+		"client_ip": c.ClientIP(),
+	})
+	if len(c.Errors) > 0 {
+		log = log.WithField("errors", c.Errors.Errors())
+	}
+	if status >= 500 {
+		log.Errorf("%s %s", c.Request.Method, c.Request.URL.Path)
+	} else {
+		log.Infof("%s %s", c.Request.Method, c.Request.URL.Path)
+	}
+}
@@ -0,0 +1,73 @@
+package interfaces
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/awcjack/cloudbet/application/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestRequestLogging(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	logger, hook := test.NewNullLogger()
+	router := gin.New()
+	router.Use(requestIDMiddleware, HttpServer{logger: logger}.accessLog)
+	router.GET("/event/:key", func(c *gin.Context) {
+		if c.Param("key") == "broken" {
+			writeError(c, http.ErrBodyNotAllowed)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name      string
+		requestID string
+		path      string
+		// empty when a new request ID is expected
+		wantID     string
+		wantStatus int
+		wantLevel  logrus.Level
+	}{
+		{name: "request ID kept", requestID: "abc-123.4:5_6", path: "/event/a", wantID: "abc-123.4:5_6", wantStatus: http.StatusOK, wantLevel: logrus.InfoLevel},
+		{name: "request ID generated", path: "/event/a", wantStatus: http.StatusOK, wantLevel: logrus.InfoLevel},
+		{name: "unsafe request ID replaced", requestID: "abc\n123", path: "/event/a", wantStatus: http.StatusOK, wantLevel: logrus.InfoLevel},
+		{name: "too long request ID replaced", requestID: strings.Repeat("a", 129), path: "/event/a", wantStatus: http.StatusOK, wantLevel: logrus.InfoLevel},
+		{name: "server error", requestID: "r1", path: "/event/broken", wantID: "r1", wantStatus: http.StatusInternalServerError, wantLevel: logrus.ErrorLevel},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook.Reset()
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", tt.path, nil)
+			if tt.requestID != "" {
+				req.Header.Set(requestIDHeader, tt.requestID)
+			}
+			router.ServeHTTP(w, req)
+
+			requestID := w.Header().Get(requestIDHeader)
+			if tt.wantID != "" && requestID != tt.wantID {
+				t.Errorf("%s = %q, want %q", requestIDHeader, requestID, tt.wantID)
+			}
+			if tt.wantID == "" && (requestID == tt.requestID || !requestIDPattern.MatchString(requestID)) {
+				t.Errorf("%s = %q, want a new request ID", requestIDHeader, requestID)
+			}
+			entry := hook.LastEntry()
+			if entry == nil {
+				t.Fatal("nothing logged")
+			}
+			if entry.Level != tt.wantLevel || entry.Data[logging.RequestIDField] != requestID || entry.Data["status"] != tt.wantStatus || entry.Data["route"] != "/event/:key" {
+				t.Errorf("logged %s %v, want %s with request ID %s and status %d", entry.Level, entry.Data, tt.wantLevel, requestID, tt.wantStatus)
+			}
+			if _, ok := entry.Data["errors"]; ok != (tt.wantStatus >= 500) {
+				t.Errorf("logged errors %v", entry.Data["errors"])
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package interfaces
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var httpDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: "cloudbet",
+	Name:      "http_request_duration_seconds",
+	Help:      "Duration of HTTP requests by method, route template and status code.",
+	Buckets:   prometheus.DefBuckets,
+}, []string{"method", "route", "status"})
+
+var metricsHandler = promhttp.Handler()
+
+// metricsMiddleware observe every request, including the ones rejected by later middlewares
+func metricsMiddleware(c *gin.Context) {
+	start := time.Now()
+	c.Next()
+
+	// label by route template rather than path, so the number of series stays bounded
+	route := c.FullPath()
+	if route == "" {
+		route = "unmatched"
+	}
+	httpDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).Observe(time.Since(start).Seconds())
+}
+
+func (h HttpServer) GetMetrics(c *gin.Context) {
+	metricsHandler.ServeHTTP(c.Writer, c.Request)
+}
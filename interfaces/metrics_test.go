@@ -0,0 +1,69 @@
+package interfaces
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// scrapeCount return the number of observations of a series of the HTTP duration histogram served by GetMetrics
+func scrapeCount(t *testing.T, labels string) float64 {
+	t.Helper()
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/metrics", nil)
+	HttpServer{}.GetMetrics(c)
+
+	prefix := "cloudbet_http_request_duration_seconds_count{" + labels + "} "
+	scanner := bufio.NewScanner(w.Body)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), prefix) {
+			count, err := strconv.ParseFloat(strings.TrimPrefix(scanner.Text(), prefix), 64)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return count
+		}
+	}
+	return 0
+}
+
+func TestMetricsMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(metricsMiddleware)
+	router.GET("/metrics-test/:key", func(c *gin.Context) {
+		if c.Param("key") == "missing" {
+			c.Status(http.StatusNotFound)
+			return
+		}
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		name   string
+		labels string
+		paths  []string
+	}{
+		{name: "labelled by route template", labels: `method="GET",route="/metrics-test/:key",status="200"`, paths: []string{"/metrics-test/a", "/metrics-test/b"}},
+		{name: "labelled by status", labels: `method="GET",route="/metrics-test/:key",status="404"`, paths: []string{"/metrics-test/missing"}},
+		{name: "unmatched routes share a label", labels: `method="GET",route="unmatched",status="404"`, paths: []string{"/nothing/here", "/metrics-test"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := scrapeCount(t, tt.labels)
+			for _, path := range tt.paths {
+				router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+			}
+			if got := scrapeCount(t, tt.labels) - before; got != float64(len(tt.paths)) {
+				t.Errorf("%d requests observed, want %d", int(got), len(tt.paths))
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package interfaces
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h HttpServer) GetReplicationStatus(c *gin.Context) {
+	status, err := h.app.Query.GetReplicationStatus.Handle(c.Request.Context())
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	secondsSinceSync := time.Since(status.LastSync()).Seconds()
+	result := ReplicationStatus{
+		Peer:             status.Peer(),
+		Sequence:         int64(status.Sequence()),
+		PeerSequence:     int64(status.PeerSequence()),
+		Lag:              int64(status.Lag()),
+		LastSync:         status.LastSync().Format(time.RFC3339),
+		SecondsSinceSync: &secondsSinceSync,
+		Resyncs:          status.Resyncs(),
+	}
+	if !status.LastChange().IsZero() {
+		lastChange := status.LastChange().Format(time.RFC3339)
+		result.LastChange = &lastChange
+	}
+	c.JSON(http.StatusOK, result)
+}
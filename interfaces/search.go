@@ -0,0 +1,42 @@
+package interfaces
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func (h HttpServer) Search(c *gin.Context, params SearchParams) {
+	limit := 10
+	if params.Limit != nil {
+		limit = int(*params.Limit)
+	}
+	var kinds []string
+	if params.Type != nil {
+		for _, kind := range *params.Type {
+			kinds = append(kinds, string(kind))
+		}
+	}
+
+	repoData, err := h.app.Query.Search.Handle(c.Request.Context(), params.Q, kinds, limit)
+	if err != nil {
+		writeError(c, err)
+		return
+	}
+
+	result := make([]SearchResult, len(repoData))
+	for i, v := range repoData {
+		result[i] = SearchResult{
+			Type:  v.Kind(),
+			Key:   v.Key(),
+			Name:  v.Name(),
+			Score: v.Score(),
+		}
+		if !v.CutoffTime().IsZero() {
+			cutOffTime := v.CutoffTime().Format(time.RFC3339)
+			result[i].CutOffTime = &cutOffTime
+		}
+	}
+	c.JSON(http.StatusOK, result)
+}
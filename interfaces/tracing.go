@@ -0,0 +1,38 @@
+package interfaces
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const serverName = "cloudbet"
+
+var tracer = otel.Tracer("github.com/awcjack/cloudbet/interfaces")
+
+// tracingMiddleware trace every request, continuing the trace of the caller if it sent one, and hand the span context
+// to the handlers through the request context
+func tracingMiddleware(c *gin.Context) {
+	ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+
+	// name by route template rather than path, like the metrics
+	route := c.FullPath()
+	name := c.Request.Method + " " + route
+	if route == "" {
+		name = c.Request.Method + " unmatched"
+	}
+	ctx, span := tracer.Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(semconv.HTTPServerAttributesFromHTTPRequest(serverName, route, c.Request)...),
+	)
+	defer span.End()
+
+	c.Request = c.Request.WithContext(ctx)
+	c.Next()
+
+	status := c.Writer.Status()
+	span.SetAttributes(semconv.HTTPAttributesFromHTTPStatusCode(status)...)
+	span.SetStatus(semconv.SpanStatusFromHTTPStatusCodeAndSpanKind(status, trace.SpanKindServer))
+}
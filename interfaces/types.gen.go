@@ -6,6 +6,63 @@ package interfaces
 import (
 	"encoding/json"
 	"fmt"
+	"time"
+)
+
+const (
+	ApiKeyScopes = "ApiKey.Scopes"
+)
+
+// Defines values for ApiKeyRequestScopes.
+const (
+	Admin  ApiKeyRequestScopes = "admin"
+	Read   ApiKeyRequestScopes = "read"
+	Stream ApiKeyRequestScopes = "stream"
+)
+
+// Defines values for ArbitrageKind.
+const (
+	BACKLAY ArbitrageKind = "BACK_LAY"
+	SUREBET ArbitrageKind = "SURE_BET"
+)
+
+// Defines values for ChangeType.
+const (
+	Created     ChangeType = "created"
+	Deleted     ChangeType = "deleted"
+	Inactivated ChangeType = "inactivated"
+	Updated     ChangeType = "updated"
+)
+
+// Defines values for CrawlItemErrorKind.
+const (
+	CrawlItemErrorKindCompetition CrawlItemErrorKind = "competition"
+	CrawlItemErrorKindEvent       CrawlItemErrorKind = "event"
+	CrawlItemErrorKindSport       CrawlItemErrorKind = "sport"
+)
+
+// Defines values for CrawlRunKind.
+const (
+	CrawlRunKindCompetition CrawlRunKind = "competition"
+	CrawlRunKindFull        CrawlRunKind = "full"
+)
+
+// Defines values for CrawlRunTrigger.
+const (
+	Manual    CrawlRunTrigger = "manual"
+	Scheduled CrawlRunTrigger = "scheduled"
+)
+
+// Defines values for HealthCheckStatus.
+const (
+	HealthCheckStatusFail HealthCheckStatus = "fail"
+	HealthCheckStatusPass HealthCheckStatus = "pass"
+)
+
+// Defines values for ReadinessStatus.
+const (
+	ReadinessStatusFail ReadinessStatus = "fail"
+	ReadinessStatusPass ReadinessStatus = "pass"
 )
 
 // Defines values for SelectionSide.
@@ -20,27 +77,347 @@ const (
 	SELECTIONENABLED  SelectionStatus = "SELECTION_ENABLED"
 )
 
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	CreatedAt string `json:"createdAt"`
+	Id        string `json:"id"`
+	Name      string `json:"name"`
+
+	// requests allowed per UTC day, 0 for unlimited
+	Quota int `json:"quota"`
+
+	// requests allowed per minute, 0 for unlimited
+	RateLimit int      `json:"rateLimit"`
+	Revoked   bool     `json:"revoked"`
+	RevokedAt *string  `json:"revokedAt,omitempty"`
+	Scopes    []string `json:"scopes"`
+}
+
+// ApiKeyList defines model for ApiKeyList.
+type ApiKeyList struct {
+	Items []ApiKey `json:"items"`
+}
+
+// ApiKeyRequest defines model for ApiKeyRequest.
+type ApiKeyRequest struct {
+	// description of the client using the key
+	Name string `json:"name"`
+
+	// requests allowed per UTC day, 0 or absent for unlimited
+	Quota *int `json:"quota,omitempty"`
+
+	// requests allowed per minute, 0 or absent for unlimited
+	RateLimit *int                  `json:"rateLimit,omitempty"`
+	Scopes    []ApiKeyRequestScopes `json:"scopes"`
+}
+
+// ApiKeyRequestScopes defines model for ApiKeyRequest.Scopes.
+type ApiKeyRequestScopes string
+
+// ApiKeySecret defines model for ApiKeySecret.
+type ApiKeySecret struct {
+	Key ApiKey `json:"key"`
+
+	// value of the X-API-Key header, it cannot be retrieved again
+	Secret string `json:"secret"`
+}
+
+// Arbitrage defines model for Arbitrage.
+type Arbitrage struct {
+	DetectedAt string  `json:"detectedAt"`
+	EventKey   string  `json:"eventKey"`
+	EventName  *string `json:"eventName,omitempty"`
+
+	// sum of implied probabilities for SURE_BET, lay price / back price for BACK_LAY
+	ImpliedProbability float64 `json:"impliedProbability"`
+
+	// SURE_BET backs every outcome of a line of a market type with a known set of outcomes, e.g. home, draw and away of match_odds, BACK_LAY backs and lays the same outcome
+	Kind      ArbitrageKind  `json:"kind"`
+	Legs      []ArbitrageLeg `json:"legs"`
+	MarketKey string         `json:"marketKey"`
+
+	// guaranteed profit in EUR when placing the total stake
+	Profit           float64 `json:"profit"`
+	ProfitPercentage float64 `json:"profitPercentage"`
+	SubmarketKey     string  `json:"submarketKey"`
+
+	// largest total stake in EUR respecting every leg's max stake
+	TotalStake float64 `json:"totalStake"`
+}
+
+// SURE_BET backs every outcome of a line of a market type with a known set of outcomes, e.g. home, draw and away of match_odds, BACK_LAY backs and lays the same outcome
+type ArbitrageKind string
+
+// ArbitrageLeg defines model for ArbitrageLeg.
+type ArbitrageLeg struct {
+	// maximum stake in EUR of this selection
+	MaxStake float64 `json:"maxStake"`
+	Outcome  string  `json:"outcome"`
+	Params   string  `json:"params"`
+	Price    float64 `json:"price"`
+
+	// BACK or LAY
+	Side string `json:"side"`
+
+	// suggested stake in EUR
+	Stake float64 `json:"stake"`
+}
+
+// CalculatedLeg defines model for CalculatedLeg.
+type CalculatedLeg struct {
+	MaxStake float64 `json:"maxStake"`
+
+	// live cached price of the selection
+	Price     float64            `json:"price"`
+	Selection SelectionReference `json:"selection"`
+
+	// BACK or LAY
+	Side *string `json:"side,omitempty"`
+
+	// calculated stake in EUR
+	Stake float64 `json:"stake"`
+}
+
 // Category defines model for Category.
 type Category struct {
+	// number of events of this category still trading
+	ActiveEventCount *int `json:"activeEventCount,omitempty"`
+
+	// number of competitions in this category, under the listed sport only when listed under a sport
+	CompetitionCount *int `json:"competitionCount,omitempty"`
+
+	// number of cached events in this category, active or not. Counts of a category cover the listed sport only when listed under a sport
+	EventCount *int `json:"eventCount,omitempty"`
+
 	// category key
 	Key string `json:"key"`
 
+	// time any event of this category last changed or was evicted, absent when never
+	LastUpdated *string `json:"lastUpdated,omitempty"`
+
+	// number of events of this category in TRADING_LIVE status
+	LiveEventCount *int `json:"liveEventCount,omitempty"`
+
 	// category name
 	Name *string `json:"name,omitempty"`
+
+	// earliest upcoming cutoff time of the active events of this category, absent when none
+	NextCutOffTime *string `json:"nextCutOffTime,omitempty"`
+
+	// keys of the sports having competitions in this category, only the listed sport when listed under a sport
+	Sports *[]string `json:"sports,omitempty"`
+}
+
+// page of categories ordered by key
+type CategoryList struct {
+	Items []Category `json:"items"`
+
+	// cursor of the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// cursor of the previous page, absent on the first page
+	PrevCursor *string `json:"prevCursor,omitempty"`
+
+	// number of items over all pages
+	TotalCount int `json:"totalCount"`
+}
+
+// Change defines model for Change.
+type Change struct {
+	Event    *Event `json:"event,omitempty"`
+	EventKey string `json:"eventKey"`
+
+	// global sequence of the change, strictly increasing across all events
+	Sequence int64      `json:"sequence"`
+	Time     string     `json:"time"`
+	Type     ChangeType `json:"type"`
+}
+
+// ChangeType defines model for Change.Type.
+type ChangeType string
+
+// ChangeList defines model for ChangeList.
+type ChangeList struct {
+	Changes []Change `json:"changes"`
+
+	// identify the change log the sequences belong to, a new one is started when the instance restarts. Sequences of different epochs are unrelated, so a client must resync from sequence 0 when the epoch changes.
+	Epoch string `json:"epoch"`
+
+	// latest sequence assigned when the changes were listed
+	LatestSequence int64 `json:"latestSequence"`
 }
 
 // Competition defines model for Competition.
 type Competition struct {
+	// number of events of this competition still trading
+	ActiveEventCount *int `json:"activeEventCount,omitempty"`
+
+	// key of the category of this competition
+	Category *string `json:"category,omitempty"`
+
+	// number of cached events of this competition, active or not
+	EventCount *int `json:"eventCount,omitempty"`
+
 	// competition key
 	Key string `json:"key"`
 
+	// time any event of this competition last changed or was evicted, absent when never
+	LastUpdated *string `json:"lastUpdated,omitempty"`
+
+	// number of events of this competition in TRADING_LIVE status
+	LiveEventCount *int `json:"liveEventCount,omitempty"`
+
 	// competition name
 	Name *string `json:"name,omitempty"`
+
+	// earliest upcoming cutoff time of the active events of this competition, absent when none
+	NextCutOffTime *string `json:"nextCutOffTime,omitempty"`
+
+	// key of the sport of this competition
+	Sport *string `json:"sport,omitempty"`
+}
+
+// page of competitions ordered by key
+type CompetitionList struct {
+	Items []Competition `json:"items"`
+
+	// cursor of the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// cursor of the previous page, absent on the first page
+	PrevCursor *string `json:"prevCursor,omitempty"`
+
+	// number of items over all pages
+	TotalCount int `json:"totalCount"`
+}
+
+// CrawlItemError defines model for CrawlItemError.
+type CrawlItemError struct {
+	// key of the failed item, absent when listing the sports failed
+	Key *string `json:"key,omitempty"`
+
+	// kind of the failed item
+	Kind    CrawlItemErrorKind `json:"kind"`
+	Message string             `json:"message"`
+
+	// HTTP status code returned by Cloudbet, 0 when no response was received or the item failed locally
+	StatusCode int    `json:"statusCode"`
+	Time       string `json:"time"`
+}
+
+// kind of the failed item
+type CrawlItemErrorKind string
+
+// CrawlRequest defines model for CrawlRequest.
+type CrawlRequest struct {
+	// crawl this competition only, every competition if absent
+	CompetitionKey *string `json:"competitionKey,omitempty"`
+}
+
+// CrawlRun defines model for CrawlRun.
+type CrawlRun struct {
+	// crawled competition of a competition crawl
+	CompetitionKey   *string `json:"competitionKey,omitempty"`
+	CompetitionsDone int     `json:"competitionsDone"`
+
+	// competitions found so far, grows while a full crawl discovers sports
+	CompetitionsTotal int `json:"competitionsTotal"`
+	ErrorCount        int `json:"errorCount"`
+
+	// first errors of the run, only returned when getting a single run
+	Errors *[]CrawlItemError `json:"errors,omitempty"`
+
+	// fetched events which were not stored before
+	EventsCreated int `json:"eventsCreated"`
+	EventsFetched int `json:"eventsFetched"`
+
+	// fetched events already stored as they are
+	EventsUnchanged int `json:"eventsUnchanged"`
+
+	// fetched events whose content changed
+	EventsUpdated int `json:"eventsUpdated"`
+
+	// absent while the crawl is running
+	FinishedAt    *string      `json:"finishedAt,omitempty"`
+	Id            int64        `json:"id"`
+	Kind          CrawlRunKind `json:"kind"`
+	SportsFetched int          `json:"sportsFetched"`
+
+	// time the crawl was scheduled or requested
+	StartedAt string          `json:"startedAt"`
+	Trigger   CrawlRunTrigger `json:"trigger"`
+}
+
+// CrawlRunKind defines model for CrawlRun.Kind.
+type CrawlRunKind string
+
+// CrawlRunTrigger defines model for CrawlRun.Trigger.
+type CrawlRunTrigger string
+
+// CrawlRunList defines model for CrawlRunList.
+type CrawlRunList struct {
+	Items []CrawlRun `json:"items"`
+
+	// value of before for the next page, absent on the last page
+	Next *int64 `json:"next,omitempty"`
+}
+
+// CrawlSettings defines model for CrawlSettings.
+type CrawlSettings struct {
+	// seconds between checks of events close to their cutoff, unchanged if absent
+	CutOffIntervalSeconds *int `json:"cutOffIntervalSeconds,omitempty"`
+
+	// seconds between scheduled full crawls, unchanged if absent
+	IntervalSeconds *int `json:"intervalSeconds,omitempty"`
 }
 
-// Error defines model for Error.
+// CrawlState defines model for CrawlState.
+type CrawlState struct {
+	Current               *CrawlRun `json:"current,omitempty"`
+	CutOffIntervalSeconds int       `json:"cutOffIntervalSeconds"`
+
+	// the crawler runs on this instance, false on replicas
+	Enabled         bool `json:"enabled"`
+	IntervalSeconds int  `json:"intervalSeconds"`
+
+	// time of the next scheduled crawl, absent when paused or disabled
+	NextRun *string `json:"nextRun,omitempty"`
+
+	// scheduled crawls and cutoff checks are suspended, manual crawls still run
+	Paused bool `json:"paused"`
+
+	// finished crawls, latest first
+	Runs []CrawlRun `json:"runs"`
+}
+
+// DutchingRequest defines model for DutchingRequest.
+type DutchingRequest struct {
+	Selections []SelectionReference `json:"selections"`
+
+	// total stake in EUR to be split
+	Stake float64 `json:"stake"`
+}
+
+// DutchingResult defines model for DutchingResult.
+type DutchingResult struct {
+	Legs []CalculatedLeg `json:"legs"`
+
+	// return in EUR if any of the selections wins
+	Payout     float64 `json:"payout"`
+	Profit     float64 `json:"profit"`
+	TotalStake float64 `json:"totalStake"`
+}
+
+// problem details returned by every failed request, list endpoints return an empty list rather than an error when nothing matches
 type Error struct {
-	Error *string `json:"error,omitempty"`
+	// stable machine readable error code
+	Code string `json:"code"`
+
+	// context of this occurrence, e.g. which selection or parameter failed
+	Details *string `json:"details,omitempty"`
+
+	// human readable description of the error code
+	Message string `json:"message"`
 }
 
 // Event defines model for Event.
@@ -76,6 +453,15 @@ type Event struct {
 	// event key
 	Key string `json:"key"`
 
+	// time when the event last changed, also returned as Last-Modified
+	LastUpdated *string `json:"lastUpdated,omitempty"`
+
+	// event in TRADING_LIVE status
+	Live *bool `json:"live,omitempty"`
+
+	// average bookmaker margin over every priced line, absent when nothing is priced
+	Margin *float64 `json:"margin,omitempty"`
+
 	// market info
 	Market *Event_Market `json:"market,omitempty"`
 
@@ -93,6 +479,9 @@ type Event struct {
 
 	// time that changed status to TRADING_LIVE
 	StartTradingLiveTime *string `json:"startTradingLiveTime,omitempty"`
+
+	// repository revision when the event last changed, part of the ETag
+	Version *int64 `json:"version,omitempty"`
 }
 
 // market info
@@ -100,6 +489,145 @@ type Event_Market struct {
 	AdditionalProperties map[string]Market `json:"-"`
 }
 
+// EventBatch defines model for EventBatch.
+type EventBatch struct {
+	// found events in the requested order
+	Events []Event `json:"events"`
+
+	// requested keys which are not cached
+	Missing []string `json:"missing"`
+}
+
+// EventBatchRequest defines model for EventBatchRequest.
+type EventBatchRequest struct {
+	// event keys, duplicates are looked up once
+	Keys []string `json:"keys"`
+}
+
+// EventLiquidity defines model for EventLiquidity.
+type EventLiquidity struct {
+	EventKey   string               `json:"eventKey"`
+	Submarkets []SubmarketLiquidity `json:"submarkets"`
+
+	// sum of the largest liability of every submarket
+	TotalLiability float64 `json:"totalLiability"`
+
+	// sum of max stake over all enabled selections
+	TotalMaxStake float64 `json:"totalMaxStake"`
+}
+
+// page of events in the requested sort order, ties broken by event key
+type EventList struct {
+	Items []Event `json:"items"`
+
+	// cursor of the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// cursor of the previous page, absent on the first page
+	PrevCursor *string `json:"prevCursor,omitempty"`
+
+	// number of items over all pages
+	TotalCount int `json:"totalCount"`
+}
+
+// Health defines model for Health.
+type Health struct {
+	Status string `json:"status"`
+}
+
+// HealthCheck defines model for HealthCheck.
+type HealthCheck struct {
+	Message string `json:"message"`
+
+	// data_loaded, data_freshness or cloudbet_circuit
+	Name   string            `json:"name"`
+	Status HealthCheckStatus `json:"status"`
+}
+
+// HealthCheckStatus defines model for HealthCheck.Status.
+type HealthCheckStatus string
+
+// HedgeRequest defines model for HedgeRequest.
+type HedgeRequest struct {
+	// price taken by the existing bet
+	Price     float64            `json:"price"`
+	Selection SelectionReference `json:"selection"`
+
+	// stake in EUR of the existing bet
+	Stake float64 `json:"stake"`
+}
+
+// HedgeResult defines model for HedgeResult.
+type HedgeResult struct {
+	Leg CalculatedLeg `json:"leg"`
+
+	// profit in EUR whichever way the event settles, negative for a loss
+	Profit float64 `json:"profit"`
+}
+
+// KellyRequest defines model for KellyRequest.
+type KellyRequest struct {
+	// bankroll in EUR
+	Bankroll float64 `json:"bankroll"`
+
+	// multiplier applied to the full Kelly stake, default 1
+	Fraction *float64 `json:"fraction,omitempty"`
+
+	// probability of the outcome
+	Probability float64            `json:"probability"`
+	Selection   SelectionReference `json:"selection"`
+}
+
+// KellyResult defines model for KellyResult.
+type KellyResult struct {
+	// price * probability - 1
+	Edge float64 `json:"edge"`
+
+	// fraction of bankroll to be staked
+	Fraction    float64       `json:"fraction"`
+	Leg         CalculatedLeg `json:"leg"`
+	Probability float64       `json:"probability"`
+}
+
+// Ladder defines model for Ladder.
+type Ladder struct {
+	Lines []Line `json:"lines"`
+
+	// line value with prices closest to even
+	MainLine *float64 `json:"mainLine,omitempty"`
+
+	// submarket key
+	Submarket string `json:"submarket"`
+}
+
+// Line defines model for Line.
+type Line struct {
+	// handicap or total of this line
+	Line float64 `json:"line"`
+
+	// whether this line has prices closest to even
+	Main bool `json:"main"`
+
+	// bookmaker margin of this line, sum of implied probabilities - 1
+	Margin float64 `json:"margin"`
+
+	// parameters shared by the selections of this line
+	Params     string       `json:"params"`
+	Selections *[]Selection `json:"selections,omitempty"`
+}
+
+// LiquidityRank defines model for LiquidityRank.
+type LiquidityRank struct {
+	CutOffTime *string `json:"cutOffTime,omitempty"`
+	EventKey   string  `json:"eventKey"`
+	EventName  *string `json:"eventName,omitempty"`
+
+	// number of submarkets with enabled selections
+	SubmarketCount int     `json:"submarketCount"`
+	TotalLiability float64 `json:"totalLiability"`
+	TotalMaxStake  float64 `json:"totalMaxStake"`
+}
+
 // Market defines model for Market.
 type Market struct {
 	Submarkets *Market_Submarkets `json:"submarkets,omitempty"`
@@ -110,6 +638,87 @@ type Market_Submarkets struct {
 	AdditionalProperties map[string][]Selection `json:"-"`
 }
 
+// ModelProbability defines model for ModelProbability.
+type ModelProbability struct {
+	EventKey string  `json:"eventKey"`
+	Market   string  `json:"market"`
+	Outcome  string  `json:"outcome"`
+	Params   *string `json:"params,omitempty"`
+
+	// fair probability of the outcome
+	Probability float64 `json:"probability"`
+}
+
+// ParlayRequest defines model for ParlayRequest.
+type ParlayRequest struct {
+	Selections []SelectionReference `json:"selections"`
+
+	// stake in EUR on the accumulator
+	Stake float64 `json:"stake"`
+}
+
+// ParlayResult defines model for ParlayResult.
+type ParlayResult struct {
+	Legs   []CalculatedLeg `json:"legs"`
+	Payout float64         `json:"payout"`
+
+	// combined price of all legs
+	Price float64 `json:"price"`
+	Stake float64 `json:"stake"`
+}
+
+// Readiness defines model for Readiness.
+type Readiness struct {
+	Checks []HealthCheck `json:"checks"`
+
+	// pass when every check passed
+	Status ReadinessStatus `json:"status"`
+}
+
+// pass when every check passed
+type ReadinessStatus string
+
+// ReplicationStatus defines model for ReplicationStatus.
+type ReplicationStatus struct {
+	// number of peer changes not applied yet as of the last sync
+	Lag int64 `json:"lag"`
+
+	// time the last applied change happened on the peer
+	LastChange *string `json:"lastChange,omitempty"`
+
+	// time of the last successful sync
+	LastSync string `json:"lastSync"`
+
+	// base URL of the followed peer
+	Peer string `json:"peer"`
+
+	// latest sequence of the peer when last synced
+	PeerSequence int64 `json:"peerSequence"`
+
+	// number of full resyncs since start, including the initial bootstrap
+	Resyncs int `json:"resyncs"`
+
+	// seconds since the last successful sync, grows when the peer is unreachable
+	SecondsSinceSync *float64 `json:"secondsSinceSync,omitempty"`
+
+	// last peer sequence applied locally
+	Sequence int64 `json:"sequence"`
+}
+
+// SearchResult defines model for SearchResult.
+type SearchResult struct {
+	// cutoff time of the event, or the nearest upcoming event for other types
+	CutOffTime *string `json:"cutOffTime,omitempty"`
+	Key        string  `json:"key"`
+	Name       string  `json:"name"`
+
+	// relevance of the match, higher is better
+	Score float64 `json:"score"`
+
+	// event, team, competition or category
+	Type string `json:"type"`
+}
+
 // Selection defines model for Selection.
 type Selection struct {
 	// maximum stake in EUR which can be placed in bets on this Selection; market liability = selection max stake * (price - 1);
@@ -124,29 +733,99 @@ type Selection struct {
 	Price       *float64 `json:"price,omitempty"`
 	Probability *float64 `json:"probability,omitempty"`
 
-	// Side of a selection signals whether a selection is available for back or lay side betting
+	// Side of a selection signals whether a selection is available for back or lay side betting, absent when Cloudbet sent none
 	Side *SelectionSide `json:"side,omitempty"`
 
-	// SelectionStatus presents the current status for a given selection
+	// SelectionStatus presents the current status for a given selection, absent when Cloudbet sent none
 	Status *SelectionStatus `json:"status,omitempty"`
 }
 
-// Side of a selection signals whether a selection is available for back or lay side betting
+// Side of a selection signals whether a selection is available for back or lay side betting, absent when Cloudbet sent none
 type SelectionSide string
 
-// SelectionStatus presents the current status for a given selection
+// SelectionStatus presents the current status for a given selection, absent when Cloudbet sent none
 type SelectionStatus string
 
+// SelectionLiquidity defines model for SelectionLiquidity.
+type SelectionLiquidity struct {
+	// max stake * (price - 1)
+	Liability float64 `json:"liability"`
+	MaxStake  float64 `json:"maxStake"`
+	Outcome   string  `json:"outcome"`
+	Params    string  `json:"params"`
+	Price     float64 `json:"price"`
+
+	// BACK or LAY
+	Side *string `json:"side,omitempty"`
+}
+
+// SelectionReference defines model for SelectionReference.
+type SelectionReference struct {
+	EventKey string  `json:"eventKey"`
+	Market   string  `json:"market"`
+	Outcome  string  `json:"outcome"`
+	Params   *string `json:"params,omitempty"`
+}
+
 // Sport defines model for Sport.
 type Sport struct {
+	// number of events of this sport still trading
+	ActiveEventCount *int `json:"activeEventCount,omitempty"`
+
+	// number of categories with competitions of this sport
+	CategoryCount *int `json:"categoryCount,omitempty"`
+
+	// number of competitions of this sport
+	CompetitionCount *int `json:"competitionCount,omitempty"`
+
+	// number of cached events of this sport, active or not
+	EventCount *int `json:"eventCount,omitempty"`
+
 	// sport key
 	Key string `json:"key"`
 
+	// time any event of this sport last changed or was evicted, absent when never
+	LastUpdated *string `json:"lastUpdated,omitempty"`
+
+	// number of events of this sport in TRADING_LIVE status
+	LiveEventCount *int `json:"liveEventCount,omitempty"`
+
 	// average time of event stay in trading_live status
 	LiveTime *float64 `json:"liveTime,omitempty"`
 
 	// sport name
 	Name *string `json:"name,omitempty"`
+
+	// earliest upcoming cutoff time of the active events of this sport, absent when none
+	NextCutOffTime *string `json:"nextCutOffTime,omitempty"`
+}
+
+// page of sports ordered by key
+type SportList struct {
+	Items []Sport `json:"items"`
+
+	// cursor of the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// cursor of the previous page, absent on the first page
+	PrevCursor *string `json:"prevCursor,omitempty"`
+
+	// number of items over all pages
+	TotalCount int `json:"totalCount"`
+}
+
+// SubmarketLiquidity defines model for SubmarketLiquidity.
+type SubmarketLiquidity struct {
+	Binding SelectionLiquidity `json:"binding"`
+	Market  string             `json:"market"`
+
+	// largest liability over enabled selections
+	MaxLiability float64              `json:"maxLiability"`
+	Selections   []SelectionLiquidity `json:"selections"`
+	Submarket    string               `json:"submarket"`
+
+	// sum of max stake over enabled selections
+	TotalMaxStake float64 `json:"totalMaxStake"`
 }
 
 // Team defines model for Team.
@@ -164,43 +843,193 @@ type Team struct {
 	Nationality *string `json:"nationality,omitempty"`
 }
 
+// page of teams ordered by key
+type TeamList struct {
+	Items []Team `json:"items"`
+
+	// cursor of the next page, absent on the last page
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// cursor of the previous page, absent on the first page
+	PrevCursor *string `json:"prevCursor,omitempty"`
+
+	// number of items over all pages
+	TotalCount int `json:"totalCount"`
+}
+
+// ValueBet defines model for ValueBet.
+type ValueBet struct {
+	CutOffTime *string `json:"cutOffTime,omitempty"`
+
+	// price * model probability - 1
+	Edge      float64 `json:"edge"`
+	EventKey  string  `json:"eventKey"`
+	EventName *string `json:"eventName,omitempty"`
+
+	// fraction of bankroll suggested by the Kelly criterion
+	KellyFraction    float64 `json:"kellyFraction"`
+	Market           string  `json:"market"`
+	MaxStake         float64 `json:"maxStake"`
+	ModelProbability float64 `json:"modelProbability"`
+	Outcome          string  `json:"outcome"`
+	Params           string  `json:"params"`
+	Price            float64 `json:"price"`
+
+	// Kelly suggested stake in EUR capped at max stake
+	Stake     float64 `json:"stake"`
+	Submarket string  `json:"submarket"`
+}
+
 // CategoryKey defines model for CategoryKey.
 type CategoryKey = string
 
 // CompetitionKey defines model for CompetitionKey.
 type CompetitionKey = string
 
+// Cursor defines model for Cursor.
+type Cursor = string
+
+// Fields defines model for Fields.
+type Fields = string
+
 // First defines model for First.
 type First = int32
 
+// KeyId defines model for KeyId.
+type KeyId = string
+
+// Markets defines model for Markets.
+type Markets = string
+
+// Nationality defines model for Nationality.
+type Nationality = string
+
 // Page defines model for Page.
 type Page = int32
 
 // SportKey defines model for SportKey.
 type SportKey = string
 
-// ListCategoriesParams defines parameters for ListCategories.
-type ListCategoriesParams struct {
+// TeamKey defines model for TeamKey.
+type TeamKey = string
+
+// GetCrawlStateParams defines parameters for GetCrawlState.
+type GetCrawlStateParams struct {
+	// maximum number of finished runs, latest first
+	Runs *int `form:"runs,omitempty" json:"runs,omitempty"`
+}
+
+// UpdateCrawlSettingsJSONBody defines parameters for UpdateCrawlSettings.
+type UpdateCrawlSettingsJSONBody = CrawlSettings
+
+// ListCrawlRunsParams defines parameters for ListCrawlRuns.
+type ListCrawlRunsParams struct {
+	// list runs with a smaller id only, next of the previous page
+	Before *int64 `form:"before,omitempty" json:"before,omitempty"`
+
+	// maximum number of runs
+	Limit   *int                        `form:"limit,omitempty" json:"limit,omitempty"`
+	Kind    *ListCrawlRunsParamsKind    `form:"kind,omitempty" json:"kind,omitempty"`
+	Trigger *ListCrawlRunsParamsTrigger `form:"trigger,omitempty" json:"trigger,omitempty"`
+
+	// list runs with at least one error only
+	Failed *bool `form:"failed,omitempty" json:"failed,omitempty"`
+}
+
+// ListCrawlRunsParamsKind defines parameters for ListCrawlRuns.
+type ListCrawlRunsParamsKind string
+
+// ListCrawlRunsParamsTrigger defines parameters for ListCrawlRuns.
+type ListCrawlRunsParamsTrigger string
+
+// TriggerCrawlJSONBody defines parameters for TriggerCrawl.
+type TriggerCrawlJSONBody = CrawlRequest
+
+// CreateApiKeyJSONBody defines parameters for CreateApiKey.
+type CreateApiKeyJSONBody = ApiKeyRequest
+
+// ListArbitrageParams defines parameters for ListArbitrage.
+type ListArbitrageParams struct {
 	// first n items to be queried
 	First First `form:"first" json:"first"`
 
 	// page number
 	Page Page `form:"page" json:"page"`
+}
+
+// CalculateDutchingJSONBody defines parameters for CalculateDutching.
+type CalculateDutchingJSONBody = DutchingRequest
+
+// CalculateHedgeJSONBody defines parameters for CalculateHedge.
+type CalculateHedgeJSONBody = HedgeRequest
+
+// CalculateKellyJSONBody defines parameters for CalculateKelly.
+type CalculateKellyJSONBody = KellyRequest
+
+// CalculateParlayJSONBody defines parameters for CalculateParlay.
+type CalculateParlayJSONBody = ParlayRequest
+
+// ListCategoriesParams defines parameters for ListCategories.
+type ListCategoriesParams struct {
+	// first n items to be queried
+	First First `form:"first" json:"first"`
+
+	// opaque nextCursor or prevCursor of a previous response, omit for the first page. A cursor is only valid with the sort order it was issued for
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
 
 	// sport key for filtering
 	Sport *SportKey `form:"sport,omitempty" json:"sport,omitempty"`
 }
 
+// ListCategoryCompetitionsParams defines parameters for ListCategoryCompetitions.
+type ListCategoryCompetitionsParams struct {
+	// first n items to be queried
+	First First `form:"first" json:"first"`
+
+	// opaque nextCursor or prevCursor of a previous response, omit for the first page. A cursor is only valid with the sort order it was issued for
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// sport key for filtering
+	Sport *SportKey `form:"sport,omitempty" json:"sport,omitempty"`
+}
+
+// ListChangesParams defines parameters for ListChanges.
+type ListChangesParams struct {
+	// return changes with a larger sequence than this, 0 for everything
+	Since *int64 `form:"since,omitempty" json:"since,omitempty"`
+
+	// maximum number of changes
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // ListCompetitionsParams defines parameters for ListCompetitions.
 type ListCompetitionsParams struct {
 	// first n items to be queried
 	First First `form:"first" json:"first"`
 
-	// page number
-	Page Page `form:"page" json:"page"`
+	// opaque nextCursor or prevCursor of a previous response, omit for the first page. A cursor is only valid with the sort order it was issued for
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
 
 	// sport key for filtering
 	Sport *SportKey `form:"sport,omitempty" json:"sport,omitempty"`
+
+	// category key for filtering
+	Category *CategoryKey `form:"category,omitempty" json:"category,omitempty"`
+}
+
+// ListCompetitionEventsParams defines parameters for ListCompetitionEvents.
+type ListCompetitionEventsParams struct {
+	// first n items to be queried
+	First First `form:"first" json:"first"`
+
+	// opaque nextCursor or prevCursor of a previous response, omit for the first page. A cursor is only valid with the sort order it was issued for
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// comma separated event properties to return, e.g. name,cutOffTime,market. key and active are always returned, omit for every property
+	Fields *Fields `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// comma separated market patterns, each a market key optionally followed by /submarket key. Both parts accept * ? and [] wildcards, e.g. soccer.* or soccer.asian_handicap/period=ft. Markets without a matching submarket are left out, omit for every market
+	Markets *Markets `form:"markets,omitempty" json:"markets,omitempty"`
 }
 
 // ListEventsParams defines parameters for ListEvents.
@@ -208,8 +1037,8 @@ type ListEventsParams struct {
 	// first n items to be queried
 	First First `form:"first" json:"first"`
 
-	// page number
-	Page Page `form:"page" json:"page"`
+	// opaque nextCursor or prevCursor of a previous response, omit for the first page. A cursor is only valid with the sort order it was issued for
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
 
 	// sport key for filtering
 	Sport *SportKey `form:"sport,omitempty" json:"sport,omitempty"`
@@ -219,17 +1048,188 @@ type ListEventsParams struct {
 
 	// category key for filtering
 	Category *CategoryKey `form:"category,omitempty" json:"category,omitempty"`
+
+	// team key for filtering, matching either home or away team
+	Team *TeamKey `form:"team,omitempty" json:"team,omitempty"`
+
+	// team country code for filtering
+	Nationality *Nationality `form:"nationality,omitempty" json:"nationality,omitempty"`
+
+	// only events with cut off time at or after this time (RFC3339)
+	CutoffFrom *time.Time `form:"cutoffFrom,omitempty" json:"cutoffFrom,omitempty"`
+
+	// only events with cut off time before this time (RFC3339)
+	CutoffTo *time.Time `form:"cutoffTo,omitempty" json:"cutoffTo,omitempty"`
+
+	// only events still trading
+	Active *bool `form:"active,omitempty" json:"active,omitempty"`
+
+	// only events in TRADING_LIVE status
+	Live *bool `form:"live,omitempty" json:"live,omitempty"`
+
+	// sort events by cut off time (default), name or average margin
+	Sort *ListEventsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// sort direction, events without margin are always listed last
+	Order *ListEventsParamsOrder `form:"order,omitempty" json:"order,omitempty"`
+
+	// comma separated event properties to return, e.g. name,cutOffTime,market. key and active are always returned, omit for every property
+	Fields *Fields `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// comma separated market patterns, each a market key optionally followed by /submarket key. Both parts accept * ? and [] wildcards, e.g. soccer.* or soccer.asian_handicap/period=ft. Markets without a matching submarket are left out, omit for every market
+	Markets *Markets `form:"markets,omitempty" json:"markets,omitempty"`
+}
+
+// ListEventsParamsSort defines parameters for ListEvents.
+type ListEventsParamsSort string
+
+// ListEventsParamsOrder defines parameters for ListEvents.
+type ListEventsParamsOrder string
+
+// GetEventsJSONBody defines parameters for GetEvents.
+type GetEventsJSONBody = EventBatchRequest
+
+// GetEventsParams defines parameters for GetEvents.
+type GetEventsParams struct {
+	// comma separated event properties to return, e.g. name,cutOffTime,market. key and active are always returned, omit for every property
+	Fields *Fields `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// comma separated market patterns, each a market key optionally followed by /submarket key. Both parts accept * ? and [] wildcards, e.g. soccer.* or soccer.asian_handicap/period=ft. Markets without a matching submarket are left out, omit for every market
+	Markets *Markets `form:"markets,omitempty" json:"markets,omitempty"`
 }
 
+// GetEventParams defines parameters for GetEvent.
+type GetEventParams struct {
+	// comma separated event properties to return, e.g. name,cutOffTime,market. key and active are always returned, omit for every property
+	Fields *Fields `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// comma separated market patterns, each a market key optionally followed by /submarket key. Both parts accept * ? and [] wildcards, e.g. soccer.* or soccer.asian_handicap/period=ft. Markets without a matching submarket are left out, omit for every market
+	Markets *Markets `form:"markets,omitempty" json:"markets,omitempty"`
+}
+
+// GetEventLadderParams defines parameters for GetEventLadder.
+type GetEventLadderParams struct {
+	// submarket key for filtering
+	Submarket *string `form:"submarket,omitempty" json:"submarket,omitempty"`
+}
+
+// RankLiquidityParams defines parameters for RankLiquidity.
+type RankLiquidityParams struct {
+	// first n items to be queried
+	First First `form:"first" json:"first"`
+
+	// page number
+	Page Page `form:"page" json:"page"`
+
+	// sport key for filtering
+	Sport *SportKey `form:"sport,omitempty" json:"sport,omitempty"`
+}
+
+// SearchParams defines parameters for Search.
+type SearchParams struct {
+	// search text
+	Q string `form:"q" json:"q"`
+
+	// restrict results to these types
+	Type *[]SearchParamsType `form:"type,omitempty" json:"type,omitempty"`
+
+	// maximum number of results
+	Limit *int32 `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// SearchParamsType defines parameters for Search.
+type SearchParamsType string
+
 // ListSportsParams defines parameters for ListSports.
 type ListSportsParams struct {
 	// first n items to be queried
 	First First `form:"first" json:"first"`
 
+	// opaque nextCursor or prevCursor of a previous response, omit for the first page. A cursor is only valid with the sort order it was issued for
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListSportCategoriesParams defines parameters for ListSportCategories.
+type ListSportCategoriesParams struct {
+	// first n items to be queried
+	First First `form:"first" json:"first"`
+
+	// opaque nextCursor or prevCursor of a previous response, omit for the first page. A cursor is only valid with the sort order it was issued for
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListTeamsParams defines parameters for ListTeams.
+type ListTeamsParams struct {
+	// first n items to be queried
+	First First `form:"first" json:"first"`
+
+	// opaque nextCursor or prevCursor of a previous response, omit for the first page. A cursor is only valid with the sort order it was issued for
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// team country code for filtering
+	Nationality *Nationality `form:"nationality,omitempty" json:"nationality,omitempty"`
+}
+
+// ListTeamEventsParams defines parameters for ListTeamEvents.
+type ListTeamEventsParams struct {
+	// first n items to be queried
+	First First `form:"first" json:"first"`
+
+	// opaque nextCursor or prevCursor of a previous response, omit for the first page. A cursor is only valid with the sort order it was issued for
+	Cursor *Cursor `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// comma separated event properties to return, e.g. name,cutOffTime,market. key and active are always returned, omit for every property
+	Fields *Fields `form:"fields,omitempty" json:"fields,omitempty"`
+
+	// comma separated market patterns, each a market key optionally followed by /submarket key. Both parts accept * ? and [] wildcards, e.g. soccer.* or soccer.asian_handicap/period=ft. Markets without a matching submarket are left out, omit for every market
+	Markets *Markets `form:"markets,omitempty" json:"markets,omitempty"`
+}
+
+// ListValueBetsParams defines parameters for ListValueBets.
+type ListValueBetsParams struct {
+	// first n items to be queried
+	First First `form:"first" json:"first"`
+
 	// page number
 	Page Page `form:"page" json:"page"`
+
+	// minimum edge (price * model probability - 1) to be listed
+	Edge *float64 `form:"edge,omitempty" json:"edge,omitempty"`
+
+	// bankroll in EUR used for Kelly suggested stakes
+	Bankroll *float64 `form:"bankroll,omitempty" json:"bankroll,omitempty"`
 }
 
+// UploadModelProbabilitiesJSONBody defines parameters for UploadModelProbabilities.
+type UploadModelProbabilitiesJSONBody = []ModelProbability
+
+// UpdateCrawlSettingsJSONRequestBody defines body for UpdateCrawlSettings for application/json ContentType.
+type UpdateCrawlSettingsJSONRequestBody = UpdateCrawlSettingsJSONBody
+
+// TriggerCrawlJSONRequestBody defines body for TriggerCrawl for application/json ContentType.
+type TriggerCrawlJSONRequestBody = TriggerCrawlJSONBody
+
+// CreateApiKeyJSONRequestBody defines body for CreateApiKey for application/json ContentType.
+type CreateApiKeyJSONRequestBody = CreateApiKeyJSONBody
+
+// CalculateDutchingJSONRequestBody defines body for CalculateDutching for application/json ContentType.
+type CalculateDutchingJSONRequestBody = CalculateDutchingJSONBody
+
+// CalculateHedgeJSONRequestBody defines body for CalculateHedge for application/json ContentType.
+type CalculateHedgeJSONRequestBody = CalculateHedgeJSONBody
+
+// CalculateKellyJSONRequestBody defines body for CalculateKelly for application/json ContentType.
+type CalculateKellyJSONRequestBody = CalculateKellyJSONBody
+
+// CalculateParlayJSONRequestBody defines body for CalculateParlay for application/json ContentType.
+type CalculateParlayJSONRequestBody = CalculateParlayJSONBody
+
+// GetEventsJSONRequestBody defines body for GetEvents for application/json ContentType.
+type GetEventsJSONRequestBody = GetEventsJSONBody
+
+// UploadModelProbabilitiesJSONRequestBody defines body for UploadModelProbabilities for application/json ContentType.
+type UploadModelProbabilitiesJSONRequestBody = UploadModelProbabilitiesJSONBody
+
 // Getter for additional properties for Event_Market. Returns the specified
 // element and whether it was found
 func (a Event_Market) Get(fieldName string) (value Market, found bool) {
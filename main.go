@@ -3,64 +3,258 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
 	"github.com/awcjack/cloudbet/application"
+	"github.com/awcjack/cloudbet/domain/apikey"
 	"github.com/awcjack/cloudbet/infrastructure"
 	"github.com/awcjack/cloudbet/interfaces"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
 )
 
+const (
+	crawlInterval = 5 * time.Second
+	// how long inactive events, then their tombstones in the change feed, are kept
+	eventRetention = time.Hour
+	// interval between evictions of inactive events, replicas also follow their peer on it
+	evictionInterval = 5 * time.Second
+	// instances serving data older than this are not ready
+	maxDataAge = 5 * time.Minute
+	// number of crawl runs kept
+	crawlRunRetention = 1000
+	// secret of the admin key created on start, a random one is generated and logged if not set
+	adminKeyEnv = "CLOUDBET_ADMIN_KEY"
+	// secret sent to the peer by a replica, it needs the stream scope there
+	replicaKeyEnv = "CLOUDBET_REPLICA_KEY"
+	// log format, text or json, and minimum level, e.g. info
+	logFormatEnv = "LOG_FORMAT"
+	logLevelEnv  = "LOG_LEVEL"
+	// span exporter, otlp, console or none, and the standard OpenTelemetry settings of the otlp one
+	tracesExporterEnv = "OTEL_TRACES_EXPORTER"
+	otlpEndpointEnv   = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	otlpHeadersEnv    = "OTEL_EXPORTER_OTLP_HEADERS"
+	serviceNameEnv    = "OTEL_SERVICE_NAME"
+)
+
+// newLogger create the logger formatted and leveled as configured by the environment
+func newLogger() (*logrus.Logger, error) {
+	logger := logrus.New()
+	switch os.Getenv(logFormatEnv) {
+	case "", "text":
+	case "json":
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	default:
+		return nil, fmt.Errorf("unknown %s %q, expected text or json", logFormatEnv, os.Getenv(logFormatEnv))
+	}
+
+	level := logrus.DebugLevel
+	if os.Getenv(logLevelEnv) != "" {
+		var err error
+		level, err = logrus.ParseLevel(os.Getenv(logLevelEnv))
+		if err != nil {
+			return nil, err
+		}
+	}
+	logger.SetLevel(level)
+	return logger, nil
+}
+
+// newTracerProvider install the tracer provider exporting spans as configured by the environment, nil if tracing is off
+func newTracerProvider() (*sdktrace.TracerProvider, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var exporter sdktrace.SpanExporter
+	switch os.Getenv(tracesExporterEnv) {
+	case "", "none":
+		return nil, nil
+	case "console":
+		exporter = infrastructure.NewStdoutSpanExporter(os.Stdout)
+	case "otlp":
+		endpoint := os.Getenv(otlpEndpointEnv)
+		if endpoint == "" {
+			endpoint = "http://localhost:4318"
+		}
+		headers := make(map[string]string)
+		for _, header := range strings.Split(os.Getenv(otlpHeadersEnv), ",") {
+			if key, value, ok := strings.Cut(header, "="); ok {
+				headers[strings.TrimSpace(key)] = strings.TrimSpace(value)
+			}
+		}
+		exporter = infrastructure.NewOtlpSpanExporter(endpoint, headers)
+	default:
+		return nil, fmt.Errorf("unknown %s %q, expected otlp, console or none", tracesExporterEnv, os.Getenv(tracesExporterEnv))
+	}
+
+	serviceName := os.Getenv(serviceNameEnv)
+	if serviceName == "" {
+		serviceName = "cloudbet"
+	}
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.SchemaURL, semconv.ServiceNameKey.String(serviceName))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider, nil
+}
+
 func main() {
+	replicaOf := flag.String("replica-of", "", "base URL of a peer instance to follow instead of crawling Cloudbet, e.g. http://primary:8080")
+	shutdownTimeout := flag.Duration("shutdown-timeout", 10*time.Second, "time given to in-flight requests, the crawl and background jobs to finish on SIGINT or SIGTERM")
+	flag.Parse()
+
 	repo := infrastructure.NewMemoryRepository()
-	logger := logrus.New()
-	logger.SetLevel(logrus.DebugLevel)
+	arbitrageRepo := infrastructure.NewArbitrageMemoryRepository()
+	replicationRepo := infrastructure.NewReplicationMemoryRepository()
+	apiKeyRepo := infrastructure.NewApiKeyMemoryRepository()
+	logger, err := newLogger()
+	if err != nil {
+		log.Fatalf("Logger setup error %s", err)
+	}
 
-	app := application.NewApplication(repo, repo, repo, repo, logger)
+	tracerProvider, err := newTracerProvider()
+	if err != nil {
+		logger.Panicf("Tracing setup error %s", err)
+	}
+
+	cloudbetCrawler := application.NewCloudbetHander(repo, logger, "<YOUR_API_KEY>")
+	crawlRunRepo := infrastructure.NewCrawlRunMemoryRepository(crawlRunRetention)
+	arbitrageScanner := application.NewArbitrageScanner(repo, arbitrageRepo, logger)
+	crawlScheduler := application.NewCrawlScheduler(cloudbetCrawler, arbitrageScanner, crawlRunRepo, logger, crawlInterval, crawlInterval)
 
-	httpServer := interfaces.NewHttpServer(*app)
+	app := application.NewApplication(repo, repo, repo, repo, repo, arbitrageRepo, repo, repo, repo, replicationRepo, apiKeyRepo, crawlRunRepo, crawlScheduler, maxDataAge, logger)
+
+	if secret := os.Getenv(adminKeyEnv); secret != "" {
+		adminKey, err := apikey.NewKey("admin", "admin", apikey.Hash(secret), []string{apikey.ScopeAdmin}, 0, 0, time.Now())
+		if err != nil {
+			logger.Panicf("Create admin key error %s", err)
+		}
+		if err := apiKeyRepo.SaveKey(context.Background(), adminKey); err != nil {
+			logger.Panicf("Store admin key error %s", err)
+		}
+	} else {
+		_, secret, err := app.Command.CreateKey.Handle(context.Background(), "admin", []string{apikey.ScopeAdmin}, 0, 0)
+		if err != nil {
+			logger.Panicf("Create admin key error %s", err)
+		}
+		// printed once outside the logger so the secret does not end up in shipped logs
+		logger.Warningf("%s not set, generated an admin key, printed to stderr", adminKeyEnv)
+		fmt.Fprintf(os.Stderr, "generated admin key %s\n", secret)
+	}
+
+	httpServer := interfaces.NewHttpServer(*app, logger)
 
 	server := &http.Server{
 		Addr:    ":8080",
 		Handler: interfaces.NewHandler(*httpServer),
 	}
 
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+
+	// closed if the server stopped on its own, shutting the rest down
+	serverFailed := make(chan struct{})
 	go func() {
-		if err := server.ListenAndServe(); err != nil && errors.Is(err, http.ErrServerClosed) {
-			log.Printf("listen: %s\n", err)
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.WithError(err).Errorf("HTTP server error")
+			close(serverFailed)
+			quit <- syscall.SIGTERM
 		}
 	}()
 
-	cloudbetCrawler := application.NewCloudbetHander(repo, logger, "<YOUR_API_KEY>")
+	eventEvictor := application.NewEventEvictor(repo, repo, logger)
+	var replica *application.Replica
+	if *replicaOf != "" {
+		replica = application.NewReplica(repo, replicationRepo, logger, *replicaOf, os.Getenv(replicaKeyEnv))
+	}
+	// cancelled on shutdown, stopping the crawl and the background jobs tracked by workers
+	workCtx, stopWork := context.WithCancel(context.Background())
+	workers := &sync.WaitGroup{}
+	if replica == nil {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			crawlScheduler.Run(workCtx)
+		}()
+	}
 
-	ticker := time.NewTicker(5 * time.Second)
-	exitTicker := make(chan struct{})
+	workers.Add(1)
 	go func() {
+		defer workers.Done()
+		ticker := time.NewTicker(evictionInterval)
+		defer ticker.Stop()
 		for {
 			select {
 			case <-ticker.C:
-				cloudbetCrawler.StoreAllEvents(context.Background())
-				cloudbetCrawler.CheckEventsCloseToCutOff(context.Background())
-				// do stuff
-			case <-exitTicker:
-				ticker.Stop()
+				if replica != nil {
+					// the crawler scans after every crawl, replicas after every sync
+					if err := replica.Sync(workCtx); err == nil {
+						arbitrageScanner.Scan(workCtx)
+					}
+					eventEvictor.PruneTombstones(workCtx, eventRetention)
+				} else {
+					eventEvictor.Evict(workCtx, eventRetention)
+				}
+			case <-workCtx.Done():
 				return
 			}
 		}
 	}()
 
-	quit := make(chan os.Signal)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
-	close(exitTicker)
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	logger.Infof("Shutting down within %s", *shutdownTimeout)
+	go func() {
+		<-quit
+		logger.Warningf("Second signal received, exiting now")
+		os.Exit(1)
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
 	defer cancel()
+
+	// the crawl stops at its next request or event, the jobs between two steps
+	stopWork()
+	// stop accepting requests and wait for the in-flight ones
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatal("Server forced to shutdown:", err)
+		logger.WithError(err).Errorf("HTTP server shutdown error")
+	}
+	// every repository write comes from a request, the crawl or a job, so none is in progress once they all returned
+	drained := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		logger.Errorf("Crawl or background jobs still running after %s", *shutdownTimeout)
+	}
+
+	if tracerProvider != nil {
+		// export the spans still buffered
+		if err := tracerProvider.Shutdown(ctx); err != nil {
+			logger.WithError(err).Errorf("Tracer shutdown error")
+		}
+	}
+	select {
+	case <-serverFailed:
+		os.Exit(1)
+	default:
+	}
+	if ctx.Err() != nil {
+		os.Exit(1)
 	}
+	logger.Infof("Shut down")
 }